@@ -0,0 +1,187 @@
+import { useMutation, useQuery } from "@tanstack/react-query";
+
+import { api } from "@/lib/api";
+
+export type CreateStremioSimklLinkParams = {
+  stremio_account_id: string;
+  sync_config: SyncConfig;
+  simkl_account_id: string;
+};
+
+export type StremioSimklLink = {
+  created_at: string;
+  stremio_account_id: string;
+  sync_config: SyncConfig;
+  sync_state: SyncState;
+  simkl_account_id: string;
+  updated_at: string;
+};
+
+export type SyncConfig = {
+  watched: SyncConfigWatched;
+};
+
+export type SyncConfigWatched = {
+  dir: SyncDirection;
+};
+
+export type SyncDirection =
+  | "both"
+  | "none"
+  | "simkl_to_stremio"
+  | "stremio_to_simkl";
+
+export type SyncState = {
+  watched: SyncStateWatched;
+};
+
+export type SyncStateWatched = {
+  last_synced_at?: string;
+};
+
+export type UpdateStremioSimklLinkParams = {
+  sync_config: SyncConfig;
+};
+
+export function useStremioSimklLinkMutation() {
+  const create = useMutation({
+    mutationFn: createStremioSimklLink,
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/sync/stremio-simkl/links"],
+      });
+    },
+  });
+
+  const update = useMutation({
+    mutationFn: async ({
+      stremio_account_id,
+      simkl_account_id,
+      ...params
+    }: UpdateStremioSimklLinkParams & {
+      stremio_account_id: string;
+      simkl_account_id: string;
+    }) => {
+      return updateStremioSimklLink(
+        stremio_account_id,
+        simkl_account_id,
+        params,
+      );
+    },
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/sync/stremio-simkl/links"],
+      });
+    },
+  });
+
+  const remove = useMutation({
+    mutationFn: ({
+      stremio_account_id,
+      simkl_account_id,
+    }: {
+      stremio_account_id: string;
+      simkl_account_id: string;
+    }) => deleteStremioSimklLink(stremio_account_id, simkl_account_id),
+    onSuccess: async (_, { stremio_account_id, simkl_account_id }, __, ctx) => {
+      ctx.client.setQueryData<StremioSimklLink[]>(
+        ["/sync/stremio-simkl/links"],
+        (list) =>
+          list?.filter(
+            (item) =>
+              item.stremio_account_id !== stremio_account_id ||
+              item.simkl_account_id !== simkl_account_id,
+          ),
+      );
+    },
+  });
+
+  const sync = useMutation({
+    mutationFn: ({
+      stremio_account_id,
+      simkl_account_id,
+    }: {
+      stremio_account_id: string;
+      simkl_account_id: string;
+    }) => syncStremioSimklLink(stremio_account_id, simkl_account_id),
+  });
+
+  const resetSyncState = useMutation({
+    mutationFn: ({
+      stremio_account_id,
+      simkl_account_id,
+    }: {
+      stremio_account_id: string;
+      simkl_account_id: string;
+    }) => resetStremioSimklLinkSyncState(stremio_account_id, simkl_account_id),
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/sync/stremio-simkl/links"],
+      });
+    },
+  });
+
+  return { create, remove, resetSyncState, sync, update };
+}
+
+export function useStremioSimklLinks() {
+  return useQuery({
+    queryFn: getStremioSimklLinks,
+    queryKey: ["/sync/stremio-simkl/links"],
+  });
+}
+
+async function createStremioSimklLink(params: CreateStremioSimklLinkParams) {
+  const { data } = await api<StremioSimklLink>(
+    "POST /sync/stremio-simkl/links",
+    {
+      body: params,
+    },
+  );
+  return data;
+}
+
+async function deleteStremioSimklLink(
+  stremioAccountId: string,
+  simklAccountId: string,
+) {
+  await api(
+    `DELETE /sync/stremio-simkl/links/${stremioAccountId}:${simklAccountId}`,
+  );
+}
+
+async function getStremioSimklLinks() {
+  const { data } = await api<StremioSimklLink[]>("/sync/stremio-simkl/links");
+  return data;
+}
+
+async function resetStremioSimklLinkSyncState(
+  stremioAccountId: string,
+  simklAccountId: string,
+) {
+  const { data } = await api<StremioSimklLink>(
+    `POST /sync/stremio-simkl/links/${stremioAccountId}:${simklAccountId}/reset-sync-state`,
+  );
+  return data;
+}
+
+async function syncStremioSimklLink(
+  stremioAccountId: string,
+  simklAccountId: string,
+) {
+  await api(
+    `POST /sync/stremio-simkl/links/${stremioAccountId}:${simklAccountId}/sync`,
+  );
+}
+
+async function updateStremioSimklLink(
+  stremioAccountId: string,
+  simklAccountId: string,
+  params: UpdateStremioSimklLinkParams,
+) {
+  const { data } = await api<StremioSimklLink>(
+    `PATCH /sync/stremio-simkl/links/${stremioAccountId}:${simklAccountId}`,
+    { body: params },
+  );
+  return data;
+}
@@ -18,13 +18,34 @@ export type StremioTraktLink = {
 };
 
 export type SyncConfig = {
+  collection: SyncConfigCollection;
+  playback: SyncConfigPlayback;
+  ratings: SyncConfigRatings;
   watched: SyncConfigWatched;
+  watchlist: SyncConfigWatchlist;
+};
+
+export type SyncConfigCollection = {
+  dir: SyncDirection;
+};
+
+export type SyncConfigPlayback = {
+  dir: SyncDirection;
+};
+
+export type SyncConfigRatings = {
+  dir: SyncDirection;
+  push: boolean;
 };
 
 export type SyncConfigWatched = {
   dir: SyncDirection;
 };
 
+export type SyncConfigWatchlist = {
+  dir: SyncDirection;
+};
+
 export type SyncDirection =
   | "both"
   | "none"
@@ -32,7 +53,24 @@ export type SyncDirection =
   | "trakt_to_stremio";
 
 export type SyncState = {
+  collection: SyncStateList;
+  playback: SyncStatePlayback;
+  ratings: SyncStateRatings;
   watched: SyncStateWatched;
+  watchlist: SyncStateList;
+};
+
+export type SyncStateList = {
+  ids: null | string[];
+  last_synced_at?: string;
+};
+
+export type SyncStatePlayback = {
+  last_synced_at?: string;
+};
+
+export type SyncStateRatings = {
+  last_synced_at?: string;
 };
 
 export type SyncStateWatched = {
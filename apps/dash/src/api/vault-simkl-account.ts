@@ -0,0 +1,77 @@
+import { useMutation, useQuery } from "@tanstack/react-query";
+
+import { api } from "@/lib/api";
+
+export type CreateSimklAccountParams = {
+  oauth_token_id: string;
+};
+
+export type SimklAccount = {
+  created_at: string;
+  id: string;
+  is_valid: boolean;
+  updated_at: string;
+  user_name: string;
+};
+
+export type SimklAuthURL = {
+  url: string;
+};
+
+export async function getSimklAuthURL(state: string) {
+  const { data } = await api<SimklAuthURL>(
+    `/vault/simkl/auth/url?state=${state}`,
+  );
+  return data.url;
+}
+
+export function useSimklAccountMutation() {
+  const create = useMutation({
+    mutationFn: createSimklAccount,
+    onSuccess: async (_, __, ___, ctx) => {
+      await ctx.client.invalidateQueries({
+        queryKey: ["/vault/simkl/accounts"],
+      });
+    },
+  });
+
+  const remove = useMutation({
+    mutationFn: deleteSimklAccount,
+    onSuccess: async (_, id, __, ctx) => {
+      const list = ctx.client.getQueryData<SimklAccount[]>([
+        "/vault/simkl/accounts",
+      ]);
+      if (list) {
+        ctx.client.setQueryData(
+          ["/vault/simkl/accounts"],
+          list.filter((item) => item.id !== id),
+        );
+      }
+    },
+  });
+
+  return { create, remove };
+}
+
+export function useSimklAccounts() {
+  return useQuery({
+    queryFn: getSimklAccounts,
+    queryKey: ["/vault/simkl/accounts"],
+  });
+}
+
+async function createSimklAccount(params: CreateSimklAccountParams) {
+  const { data } = await api<SimklAccount>("POST /vault/simkl/accounts", {
+    body: params,
+  });
+  return data;
+}
+
+async function deleteSimklAccount(id: string) {
+  await api(`DELETE /vault/simkl/accounts/${id}`);
+}
+
+async function getSimklAccounts() {
+  const { data } = await api<SimklAccount[]>("/vault/simkl/accounts");
+  return data;
+}
@@ -11,7 +11,7 @@ export type TorznabIndexer = {
   url: string;
 };
 
-export type TorznabIndexerType = "jackett";
+export type TorznabIndexerType = "jackett" | "prowlarr";
 
 type CreateTorznabIndexerParams = {
   api_key: string;
@@ -1,6 +1,7 @@
 import { createFormHook, createFormHookContexts } from "@tanstack/react-form";
 
 import { FormInput } from "./Input";
+import { FormSelect } from "./Select";
 import { SubmitButton } from "./SubmitButton";
 
 export const { fieldContext, formContext, useFieldContext, useFormContext } =
@@ -9,6 +10,7 @@ export const { fieldContext, formContext, useFieldContext, useFormContext } =
 export const { useAppForm, withForm } = createFormHook({
   fieldComponents: {
     Input: FormInput,
+    Select: FormSelect,
   },
   fieldContext,
   formComponents: {
@@ -295,6 +295,12 @@ function useNavItems(): NavItem[] {
         path: "/dash/vault/stremio-accounts",
         title: "Stremio Accounts",
       });
+      if (server.integration.simkl) {
+        vault.items!.push({
+          path: "/dash/vault/simkl-accounts",
+          title: "Simkl Accounts",
+        });
+      }
       if (server.integration.trakt) {
         vault.items!.push({
           path: "/dash/vault/trakt-accounts",
@@ -328,9 +334,19 @@ function useNavItems(): NavItem[] {
           title: "Stremio ↔ Trakt",
         });
       }
+      if (server.integration.simkl) {
+        sync.items!.push({
+          path: "/dash/sync/stremio-simkl",
+          title: "Stremio ↔ Simkl",
+        });
+      }
       items.push(sync);
     }
 
     return items;
-  }, [server?.feature.vault, server?.integration.trakt]);
+  }, [
+    server?.feature.vault,
+    server?.integration.simkl,
+    server?.integration.trakt,
+  ]);
 }
@@ -24,9 +24,11 @@ import { Route as DashListsIndexRouteImport } from './routes/dash/lists/index'
 import { Route as DashVaultTraktAccountsRouteImport } from './routes/dash/vault/trakt-accounts'
 import { Route as DashVaultTorznabIndexersRouteImport } from './routes/dash/vault/torznab-indexers'
 import { Route as DashVaultStremioAccountsRouteImport } from './routes/dash/vault/stremio-accounts'
+import { Route as DashVaultSimklAccountsRouteImport } from './routes/dash/vault/simkl-accounts'
 import { Route as DashTorrentsIndexersSyncRouteImport } from './routes/dash/torrents/indexers-sync'
 import { Route as DashSyncStremioTraktRouteImport } from './routes/dash/sync/stremio-trakt'
 import { Route as DashSyncStremioStremioRouteImport } from './routes/dash/sync/stremio-stremio'
+import { Route as DashSyncStremioSimklRouteImport } from './routes/dash/sync/stremio-simkl'
 
 const DashRoute = DashRouteImport.update({
   id: '/dash',
@@ -105,6 +107,11 @@ const DashVaultStremioAccountsRoute =
     path: '/stremio-accounts',
     getParentRoute: () => DashVaultRoute,
   } as any)
+const DashVaultSimklAccountsRoute = DashVaultSimklAccountsRouteImport.update({
+  id: '/simkl-accounts',
+  path: '/simkl-accounts',
+  getParentRoute: () => DashVaultRoute,
+} as any)
 const DashTorrentsIndexersSyncRoute =
   DashTorrentsIndexersSyncRouteImport.update({
     id: '/indexers-sync',
@@ -121,6 +128,11 @@ const DashSyncStremioStremioRoute = DashSyncStremioStremioRouteImport.update({
   path: '/stremio-stremio',
   getParentRoute: () => DashSyncRoute,
 } as any)
+const DashSyncStremioSimklRoute = DashSyncStremioSimklRouteImport.update({
+  id: '/stremio-simkl',
+  path: '/stremio-simkl',
+  getParentRoute: () => DashSyncRoute,
+} as any)
 
 export interface FileRoutesByFullPath {
   '/dash': typeof DashRouteWithChildren
@@ -131,9 +143,11 @@ export interface FileRoutesByFullPath {
   '/dash/vault': typeof DashVaultRouteWithChildren
   '/dash/workers': typeof DashWorkersRoute
   '/dash/': typeof DashIndexRoute
+  '/dash/sync/stremio-simkl': typeof DashSyncStremioSimklRoute
   '/dash/sync/stremio-stremio': typeof DashSyncStremioStremioRoute
   '/dash/sync/stremio-trakt': typeof DashSyncStremioTraktRoute
   '/dash/torrents/indexers-sync': typeof DashTorrentsIndexersSyncRoute
+  '/dash/vault/simkl-accounts': typeof DashVaultSimklAccountsRoute
   '/dash/vault/stremio-accounts': typeof DashVaultStremioAccountsRoute
   '/dash/vault/torznab-indexers': typeof DashVaultTorznabIndexersRoute
   '/dash/vault/trakt-accounts': typeof DashVaultTraktAccountsRoute
@@ -146,9 +160,11 @@ export interface FileRoutesByTo {
   '/dash/login': typeof DashLoginRoute
   '/dash/workers': typeof DashWorkersRoute
   '/dash': typeof DashIndexRoute
+  '/dash/sync/stremio-simkl': typeof DashSyncStremioSimklRoute
   '/dash/sync/stremio-stremio': typeof DashSyncStremioStremioRoute
   '/dash/sync/stremio-trakt': typeof DashSyncStremioTraktRoute
   '/dash/torrents/indexers-sync': typeof DashTorrentsIndexersSyncRoute
+  '/dash/vault/simkl-accounts': typeof DashVaultSimklAccountsRoute
   '/dash/vault/stremio-accounts': typeof DashVaultStremioAccountsRoute
   '/dash/vault/torznab-indexers': typeof DashVaultTorznabIndexersRoute
   '/dash/vault/trakt-accounts': typeof DashVaultTraktAccountsRoute
@@ -167,9 +183,11 @@ export interface FileRoutesById {
   '/dash/vault': typeof DashVaultRouteWithChildren
   '/dash/workers': typeof DashWorkersRoute
   '/dash/': typeof DashIndexRoute
+  '/dash/sync/stremio-simkl': typeof DashSyncStremioSimklRoute
   '/dash/sync/stremio-stremio': typeof DashSyncStremioStremioRoute
   '/dash/sync/stremio-trakt': typeof DashSyncStremioTraktRoute
   '/dash/torrents/indexers-sync': typeof DashTorrentsIndexersSyncRoute
+  '/dash/vault/simkl-accounts': typeof DashVaultSimklAccountsRoute
   '/dash/vault/stremio-accounts': typeof DashVaultStremioAccountsRoute
   '/dash/vault/torznab-indexers': typeof DashVaultTorznabIndexersRoute
   '/dash/vault/trakt-accounts': typeof DashVaultTraktAccountsRoute
@@ -189,9 +207,11 @@ export interface FileRouteTypes {
     | '/dash/vault'
     | '/dash/workers'
     | '/dash/'
+    | '/dash/sync/stremio-simkl'
     | '/dash/sync/stremio-stremio'
     | '/dash/sync/stremio-trakt'
     | '/dash/torrents/indexers-sync'
+    | '/dash/vault/simkl-accounts'
     | '/dash/vault/stremio-accounts'
     | '/dash/vault/torznab-indexers'
     | '/dash/vault/trakt-accounts'
@@ -204,9 +224,11 @@ export interface FileRouteTypes {
     | '/dash/login'
     | '/dash/workers'
     | '/dash'
+    | '/dash/sync/stremio-simkl'
     | '/dash/sync/stremio-stremio'
     | '/dash/sync/stremio-trakt'
     | '/dash/torrents/indexers-sync'
+    | '/dash/vault/simkl-accounts'
     | '/dash/vault/stremio-accounts'
     | '/dash/vault/torznab-indexers'
     | '/dash/vault/trakt-accounts'
@@ -224,9 +246,11 @@ export interface FileRouteTypes {
     | '/dash/vault'
     | '/dash/workers'
     | '/dash/'
+    | '/dash/sync/stremio-simkl'
     | '/dash/sync/stremio-stremio'
     | '/dash/sync/stremio-trakt'
     | '/dash/torrents/indexers-sync'
+    | '/dash/vault/simkl-accounts'
     | '/dash/vault/stremio-accounts'
     | '/dash/vault/torznab-indexers'
     | '/dash/vault/trakt-accounts'
@@ -347,6 +371,13 @@ declare module '@tanstack/react-router' {
       preLoaderRoute: typeof DashVaultStremioAccountsRouteImport
       parentRoute: typeof DashVaultRoute
     }
+    '/dash/vault/simkl-accounts': {
+      id: '/dash/vault/simkl-accounts'
+      path: '/simkl-accounts'
+      fullPath: '/dash/vault/simkl-accounts'
+      preLoaderRoute: typeof DashVaultSimklAccountsRouteImport
+      parentRoute: typeof DashVaultRoute
+    }
     '/dash/torrents/indexers-sync': {
       id: '/dash/torrents/indexers-sync'
       path: '/indexers-sync'
@@ -368,6 +399,13 @@ declare module '@tanstack/react-router' {
       preLoaderRoute: typeof DashSyncStremioStremioRouteImport
       parentRoute: typeof DashSyncRoute
     }
+    '/dash/sync/stremio-simkl': {
+      id: '/dash/sync/stremio-simkl'
+      path: '/stremio-simkl'
+      fullPath: '/dash/sync/stremio-simkl'
+      preLoaderRoute: typeof DashSyncStremioSimklRouteImport
+      parentRoute: typeof DashSyncRoute
+    }
   }
 }
 
@@ -384,12 +422,14 @@ const DashListsRouteWithChildren = DashListsRoute._addFileChildren(
 )
 
 interface DashSyncRouteChildren {
+  DashSyncStremioSimklRoute: typeof DashSyncStremioSimklRoute
   DashSyncStremioStremioRoute: typeof DashSyncStremioStremioRoute
   DashSyncStremioTraktRoute: typeof DashSyncStremioTraktRoute
   DashSyncIndexRoute: typeof DashSyncIndexRoute
 }
 
 const DashSyncRouteChildren: DashSyncRouteChildren = {
+  DashSyncStremioSimklRoute: DashSyncStremioSimklRoute,
   DashSyncStremioStremioRoute: DashSyncStremioStremioRoute,
   DashSyncStremioTraktRoute: DashSyncStremioTraktRoute,
   DashSyncIndexRoute: DashSyncIndexRoute,
@@ -414,6 +454,7 @@ const DashTorrentsRouteWithChildren = DashTorrentsRoute._addFileChildren(
 )
 
 interface DashVaultRouteChildren {
+  DashVaultSimklAccountsRoute: typeof DashVaultSimklAccountsRoute
   DashVaultStremioAccountsRoute: typeof DashVaultStremioAccountsRoute
   DashVaultTorznabIndexersRoute: typeof DashVaultTorznabIndexersRoute
   DashVaultTraktAccountsRoute: typeof DashVaultTraktAccountsRoute
@@ -421,6 +462,7 @@ interface DashVaultRouteChildren {
 }
 
 const DashVaultRouteChildren: DashVaultRouteChildren = {
+  DashVaultSimklAccountsRoute: DashVaultSimklAccountsRoute,
   DashVaultStremioAccountsRoute: DashVaultStremioAccountsRoute,
   DashVaultTorznabIndexersRoute: DashVaultTorznabIndexersRoute,
   DashVaultTraktAccountsRoute: DashVaultTraktAccountsRoute,
@@ -15,6 +15,7 @@ import { toast } from "sonner";
 
 import {
   StremioTraktLink,
+  SyncConfig,
   SyncDirection,
   useStremioTraktLinkMutation,
   useStremioTraktLinks,
@@ -119,7 +120,13 @@ function LinkAccountSheet({
     onSubmit: async ({ value }) => {
       await create.mutateAsync({
         stremio_account_id: value.stremio_account_id,
-        sync_config: { watched: { dir: "none" } },
+        sync_config: {
+          collection: { dir: "none" },
+          playback: { dir: "none" },
+          ratings: { dir: "none", push: false },
+          watched: { dir: "none" },
+          watchlist: { dir: "none" },
+        },
         trakt_account_id: value.trakt_account_id,
       });
       toast.success("Accounts linked successfully!");
@@ -220,6 +227,58 @@ function LinkAccountSheet({
   );
 }
 
+function SyncDirectionSelect({
+  directions,
+  label,
+  onChange,
+  value,
+}: {
+  directions?: SyncDirection[];
+  label: string;
+  onChange: (value: SyncDirection) => void;
+  value: SyncDirection;
+}) {
+  const options = directions
+    ? syncDirectionOptions.filter((opt) => directions.includes(opt.value))
+    : syncDirectionOptions;
+  const selectedOption = syncDirectionOptions.find(
+    (opt) => opt.value === value,
+  );
+  const SyncDirectionIcon = selectedOption?.icon || XCircle;
+
+  return (
+    <div className="flex flex-col gap-2">
+      <label className="text-sm font-medium">{label}</label>
+      <Select
+        onValueChange={(value) => onChange(value as SyncDirection)}
+        value={value}
+      >
+        <SelectTrigger className="w-full">
+          <SelectValue>
+            <div className="flex items-center gap-2">
+              <SyncDirectionIcon className="size-4" />
+              {selectedOption?.label}
+            </div>
+          </SelectValue>
+        </SelectTrigger>
+        <SelectContent>
+          {options.map((option) => {
+            const OptionIcon = option.icon;
+            return (
+              <SelectItem key={option.value} value={option.value}>
+                <div className="flex items-center gap-2">
+                  <OptionIcon className="size-4" />
+                  {option.label}
+                </div>
+              </SelectItem>
+            );
+          })}
+        </SelectContent>
+      </Select>
+    </div>
+  );
+}
+
 function LinkCard({
   link,
   stremioAccount,
@@ -232,16 +291,19 @@ function LinkCard({
   const { remove, resetSyncState, sync, update } =
     useStremioTraktLinkMutation();
 
-  const selectedWatchedSyncDirection = syncDirectionOptions.find(
-    (opt) => opt.value === link.sync_config.watched.dir,
-  );
-  const SyncDirectionIcon = selectedWatchedSyncDirection?.icon || XCircle;
+  const syncConfig = link.sync_config;
 
-  const handleWatchedSyncDirectionChange = (value: string) => {
+  const handleSyncDirectionChange = (
+    key: keyof SyncConfig,
+    value: SyncDirection,
+  ) => {
     toast.promise(
       update.mutateAsync({
         stremio_account_id: link.stremio_account_id,
-        sync_config: { watched: { dir: value as SyncDirection } },
+        sync_config: {
+          ...syncConfig,
+          [key]: { ...syncConfig[key], dir: value },
+        },
         trakt_account_id: link.trakt_account_id,
       }),
       {
@@ -261,6 +323,44 @@ function LinkCard({
     );
   };
 
+  const handleRatingsPushChange = (push: boolean) => {
+    toast.promise(
+      update.mutateAsync({
+        stremio_account_id: link.stremio_account_id,
+        sync_config: {
+          ...syncConfig,
+          ratings: { ...syncConfig.ratings, push },
+        },
+        trakt_account_id: link.trakt_account_id,
+      }),
+      {
+        error(err: APIError) {
+          console.error(err);
+          return {
+            closeButton: true,
+            message: err.message,
+          };
+        },
+        loading: "Updating ratings push...",
+        success: {
+          closeButton: true,
+          message: "Ratings push updated!",
+        },
+      },
+    );
+  };
+
+  const lastSyncedAt = [
+    link.sync_state.watched.last_synced_at,
+    link.sync_state.watchlist.last_synced_at,
+    link.sync_state.collection.last_synced_at,
+    link.sync_state.playback.last_synced_at,
+    link.sync_state.ratings.last_synced_at,
+  ]
+    .filter((v): v is string => Boolean(v))
+    .sort()
+    .at(-1);
+
   const handleSync = () => {
     toast.promise(
       sync.mutateAsync({
@@ -351,46 +451,68 @@ function LinkCard({
         </CardDescription>
       </CardHeader>
       <CardContent className="flex flex-col gap-4">
+        <SyncDirectionSelect
+          label="Watched Sync Direction"
+          onChange={(value) => handleSyncDirectionChange("watched", value)}
+          value={syncConfig.watched.dir}
+        />
+        <SyncDirectionSelect
+          label="Playback Progress Sync Direction"
+          onChange={(value) => handleSyncDirectionChange("playback", value)}
+          value={syncConfig.playback.dir}
+        />
+        <SyncDirectionSelect
+          label="Watchlist Sync Direction"
+          onChange={(value) => handleSyncDirectionChange("watchlist", value)}
+          value={syncConfig.watchlist.dir}
+        />
+        <SyncDirectionSelect
+          label="Collection Sync Direction"
+          onChange={(value) => handleSyncDirectionChange("collection", value)}
+          value={syncConfig.collection.dir}
+        />
+        <p className="text-muted-foreground text-xs">
+          Watchlist and Collection are synced with the Stremio Library. Only one
+          of them can be synced at a time. When synced both ways, only the
+          library items changed after linking are added to Trakt.
+        </p>
+        <SyncDirectionSelect
+          directions={["none", "trakt_to_stremio"]}
+          label="Ratings Sync Direction"
+          onChange={(value) => handleSyncDirectionChange("ratings", value)}
+          value={syncConfig.ratings.dir}
+        />
         <div className="flex flex-col gap-2">
-          <label className="text-sm font-medium">Watched Sync Direction</label>
+          <label className="text-sm font-medium">Push Ratings to Trakt</label>
           <Select
-            onValueChange={handleWatchedSyncDirectionChange}
-            value={link.sync_config.watched.dir}
+            onValueChange={(value) => handleRatingsPushChange(value === "yes")}
+            value={syncConfig.ratings.push ? "yes" : "no"}
           >
             <SelectTrigger className="w-full">
-              <SelectValue>
-                <div className="flex items-center gap-2">
-                  <SyncDirectionIcon className="size-4" />
-                  {selectedWatchedSyncDirection?.label}
-                </div>
-              </SelectValue>
+              <SelectValue />
             </SelectTrigger>
             <SelectContent>
-              {syncDirectionOptions.map((option) => {
-                const OptionIcon = option.icon;
-                return (
-                  <SelectItem key={option.value} value={option.value}>
-                    <div className="flex items-center gap-2">
-                      <OptionIcon className="size-4" />
-                      {option.label}
-                    </div>
-                  </SelectItem>
-                );
-              })}
+              <SelectItem value="no">Disabled</SelectItem>
+              <SelectItem value="yes">Enabled</SelectItem>
             </SelectContent>
           </Select>
         </div>
+        <p className="text-muted-foreground text-xs">
+          Ratings are recorded for the items in Stremio Library. Ratings set
+          via StremThru are pushed to Trakt when enabled, and dropped when
+          disabled.
+        </p>
 
-        {link.sync_state.watched.last_synced_at && (
+        {lastSyncedAt && (
           <div className="text-muted-foreground flex flex-col gap-1 text-sm">
             <div className="flex items-center justify-between gap-2">
               <div className="flex items-center gap-1">
                 <CheckCircle className="size-3.5 text-green-500" />
                 <span>
                   Last synced:{" "}
-                  {DateTime.fromISO(
-                    link.sync_state.watched.last_synced_at,
-                  ).toLocaleString(DateTime.DATETIME_MED)}
+                  {DateTime.fromISO(lastSyncedAt).toLocaleString(
+                    DateTime.DATETIME_MED,
+                  )}
                 </span>
               </div>
               <AlertDialog>
@@ -428,7 +550,15 @@ function LinkCard({
       <CardFooter className="mt-auto gap-4">
         <Button
           className="flex-1"
-          disabled={link.sync_config.watched.dir === "none" || sync.isPending}
+          disabled={
+            (syncConfig.watched.dir === "none" &&
+              syncConfig.playback.dir === "none" &&
+              syncConfig.watchlist.dir === "none" &&
+              syncConfig.collection.dir === "none" &&
+              syncConfig.ratings.dir === "none" &&
+              !syncConfig.ratings.push) ||
+            sync.isPending
+          }
           onClick={handleSync}
           size="sm"
           variant="outline"
@@ -504,7 +634,8 @@ function RouteComponent() {
         <div>
           <h2 className="text-lg font-semibold">Stremio ↔ Trakt Sync</h2>
           <p className="text-muted-foreground text-sm">
-            Link Stremio and Trakt accounts to sync watch history
+            Link Stremio and Trakt accounts to sync watch history, watchlist and
+            collection
           </p>
         </div>
         <Sheet onOpenChange={setSheetOpen} open={sheetOpen}>
@@ -7,6 +7,7 @@ import { toast } from "sonner";
 
 import {
   TorznabIndexer,
+  TorznabIndexerType,
   useTorznabIndexerMutation,
   useTorznabIndexers,
 } from "@/api/vault-torznab-indexer";
@@ -57,6 +58,14 @@ declare module "@/components/data-table" {
   }
 }
 
+const indexerTypeOptions: Array<{
+  label: string;
+  value: TorznabIndexerType;
+}> = [
+  { label: "Jackett", value: "jackett" },
+  { label: "Prowlarr", value: "prowlarr" },
+];
+
 const col = createColumnHelper<TorznabIndexer>();
 
 const columns: ColumnDef<TorznabIndexer>[] = [
@@ -201,6 +210,7 @@ function TorznabIndexerFormSheet({
     defaultValues: {
       api_key: "",
       name: editItem?.name ?? "",
+      type: editItem?.type ?? ("jackett" as TorznabIndexerType),
       url: editItem?.url ?? "",
     },
     onSubmit: async ({ value }) => {
@@ -215,6 +225,7 @@ function TorznabIndexerFormSheet({
         await create.mutateAsync({
           api_key: value.api_key,
           name: value.name,
+          type: value.type,
           url: value.url,
         });
         toast.success("Created successfully!");
@@ -247,12 +258,21 @@ function TorznabIndexerFormSheet({
             <SheetDescription>
               {editItem
                 ? "Update the API key for this Torznab indexer."
-                : "Add a Jackett indexer. The API key will be encrypted before storage."}
+                : "Add a Jackett or Prowlarr indexer. The API key will be encrypted before storage."}
             </SheetDescription>
           </SheetHeader>
 
           <ScrollArea className="overflow-hidden">
             <div className="flex flex-col gap-4 px-4">
+              <form.AppField name="type">
+                {(field) => (
+                  <field.Select
+                    disabled={Boolean(editItem)}
+                    label="Type"
+                    options={indexerTypeOptions}
+                  />
+                )}
+              </form.AppField>
               <form.AppField name="name">
                 {(field) => <field.Input label="Name" type="text" />}
               </form.AppField>
@@ -61,6 +61,9 @@ func Decrypt(secret, value string) (string, error) {
 	}
 
 	nonceSize := aesGCM.NonceSize()
+	if len(nonce_and_ciphertext) < nonceSize {
+		return "", errors.New("invalid ciphertext")
+	}
 	nonce, ciphertext := nonce_and_ciphertext[:nonceSize], nonce_and_ciphertext[nonceSize:]
 
 	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -180,18 +181,59 @@ var IdMapColumns = []string{
 }
 
 var query_get_id_map = fmt.Sprintf(
-	"SELECT %s FROM %s WHERE %s IN ",
+	"SELECT %s FROM %s WHERE ",
 	strings.Join(IdMapColumns, ","),
 	IdMapTableName,
-	IdMapColumn.AniList,
 )
 
 func GetIdMapsForAniList(ids []int) ([]AnimeIdMap, error) {
+	return getIdMapsByColumn(IdMapColumn.AniList, intIdsToStrings(ids))
+}
+
+func GetIdMapsForMAL(ids []int) ([]AnimeIdMap, error) {
+	return getIdMapsByColumn(IdMapColumn.MAL, intIdsToStrings(ids))
+}
+
+func intIdsToStrings(ids []int) []string {
+	strIds := make([]string, len(ids))
+	for i := range ids {
+		strIds[i] = strconv.Itoa(ids[i])
+	}
+	return strIds
+}
+
+var idMapLookupColumns = []string{
+	IdMapColumn.AniDB,
+	IdMapColumn.AniList,
+	IdMapColumn.AniSearch,
+	IdMapColumn.AnimePlanet,
+	IdMapColumn.IMDB,
+	IdMapColumn.Kitsu,
+	IdMapColumn.Letterboxd,
+	IdMapColumn.LiveChart,
+	IdMapColumn.MAL,
+	IdMapColumn.NotifyMoe,
+	IdMapColumn.TMDB,
+	IdMapColumn.TVDB,
+	IdMapColumn.Trakt,
+}
+
+func GetIdMapsByColumn(column string, ids []string) ([]AnimeIdMap, error) {
+	if !slices.Contains(idMapLookupColumns, column) {
+		return nil, fmt.Errorf("unexpected column: %s", column)
+	}
+	return getIdMapsByColumn(column, ids)
+}
+
+func getIdMapsByColumn(column string, ids []string) ([]AnimeIdMap, error) {
 	count := len(ids)
-	query := query_get_id_map + "(" + util.RepeatJoin("?", count, ",") + ")"
+	if count == 0 {
+		return []AnimeIdMap{}, nil
+	}
+	query := query_get_id_map + column + " IN (" + util.RepeatJoin("?", count, ",") + ")"
 	args := make([]any, count)
 	for i := range ids {
-		args[i] = strconv.Itoa(ids[i])
+		args[i] = ids[i]
 	}
 	rows, err := db.Query(query, args...)
 	if err != nil {
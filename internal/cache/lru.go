@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/elastic/go-freelru"
 	"github.com/zeebo/xxh3"
 )
@@ -40,6 +41,7 @@ func (cache *LRUCache[V]) Get(key string, value *V) bool {
 
 	val, ok := cache.c.Get(key)
 	*value = val
+	metrics.ObserveCacheGet(cache.name, ok)
 	return ok
 }
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/elastic/go-freelru"
 	rc "github.com/go-redis/cache/v9"
 	r "github.com/redis/go-redis/v9"
@@ -114,10 +115,8 @@ func (cache *RedisCache[V]) AddWithLifetime(key string, value V, lifetime time.D
 
 func (cache *RedisCache[V]) Get(key string, value *V) bool {
 	err := cache.c.Get(context.Background(), cache.name+":"+key, value)
-	if err != nil {
-		return false
-	}
-	return true
+	metrics.ObserveCacheGet(cache.name, err == nil)
+	return err == nil
 }
 
 func (cache *RedisCache[V]) Remove(key string) {
@@ -148,3 +147,8 @@ func newRedisCache[V any](conf *CacheConfig) *RedisCache[V] {
 
 	return cache
 }
+
+// GetRedisClient returns the shared redis client, nil if redis is not configured.
+func GetRedisClient() *r.Client {
+	return redis
+}
@@ -56,6 +56,7 @@ var defaultValueByEnv = map[string]map[string]string{
 	},
 	"": {
 		"STREMTHRU_BASE_URL":                               "http://localhost:8080",
+		"STREMTHRU_CONTENT_PROXY_BANDWIDTH_LIMIT":          "*:0",
 		"STREMTHRU_CONTENT_PROXY_CONNECTION_LIMIT":         "*:0",
 		"STREMTHRU_DATABASE_URI":                           "sqlite://./data/stremthru.db",
 		"STREMTHRU_DATA_DIR":                               "./data",
@@ -69,7 +70,9 @@ var defaultValueByEnv = map[string]map[string]string{
 		"STREMTHRU_INTEGRATION_ANILIST_LIST_STALE_TIME":    "12h",
 		"STREMTHRU_INTEGRATION_LETTERBOXD_LIST_STALE_TIME": "24h",
 		"STREMTHRU_INTEGRATION_LETTERBOXD_USER_AGENT":      "stremthru",
+		"STREMTHRU_INTEGRATION_MAL_LIST_STALE_TIME":        "12h",
 		"STREMTHRU_INTEGRATION_MDBLIST_LIST_STALE_TIME":    "12h",
+		"STREMTHRU_INTEGRATION_SIMKL_LIST_STALE_TIME":      "12h",
 		"STREMTHRU_INTEGRATION_TMDB_LIST_STALE_TIME":       "12h",
 		"STREMTHRU_INTEGRATION_TRAKT_LIST_STALE_TIME":      "12h",
 		"STREMTHRU_INTEGRATION_TVDB_LIST_STALE_TIME":       "12h",
@@ -81,7 +84,10 @@ var defaultValueByEnv = map[string]map[string]string{
 		"STREMTHRU_STREMIO_TORZ_PUBLIC_MAX_STORE_COUNT":    "3",
 		"STREMTHRU_STREMIO_WRAP_PUBLIC_MAX_UPSTREAM_COUNT": "5",
 		"STREMTHRU_STREMIO_WRAP_PUBLIC_MAX_STORE_COUNT":    "3",
+		"STREMTHRU_TORRENT_STREAM_DISK_BUDGET":             "10GB",
 		"STREMTHRU_IP_CHECKER":                             "aws",
+		"STREMTHRU_TUNNEL_POOL_SELECTION":                  "round-robin",
+		"STREMTHRU_TUNNEL_HEALTH_CHECK_INTERVAL":           "1m",
 	},
 }
 
@@ -205,6 +211,7 @@ const (
 	FeatureStremioStore    string = "stremio_store"
 	FeatureStremioTorz     string = "stremio_torz"
 	FeatureStremioWrap     string = "stremio_wrap"
+	FeatureTorrentStream   string = "torrent_stream"
 	FeatureVault           string = "vault"
 )
 
@@ -218,6 +225,7 @@ var features = []string{
 	FeatureStremioStore,
 	FeatureStremioTorz,
 	FeatureStremioWrap,
+	FeatureTorrentStream,
 	FeatureVault,
 }
 
@@ -258,6 +266,10 @@ func (f FeatureConfig) HasIMDBTitle() bool {
 	return !f.IsDisabled(FeatureIMDBTitle) && f.HasTorrentInfo()
 }
 
+func (f FeatureConfig) HasTorrentStream() bool {
+	return f.IsEnabled(FeatureTorrentStream) && f.IsEnabled(FeatureStremioP2P)
+}
+
 func (f FeatureConfig) HasVault() bool {
 	return !f.IsDisabled(FeatureVault) && VaultSecret != ""
 }
@@ -290,6 +302,17 @@ func (cpcl ContentProxyConnectionLimitMap) Get(user string) int {
 	return cpcl[user]
 }
 
+// ContentProxyBandwidthLimitMap holds the bandwidth limit per user, in
+// bytes per second.
+type ContentProxyBandwidthLimitMap map[string]int64
+
+func (cpbl ContentProxyBandwidthLimitMap) Get(user string) int64 {
+	if limit, ok := cpbl[user]; ok {
+		return limit
+	}
+	return cpbl["*"]
+}
+
 type storeContentCachedStaleTimeMapItem struct {
 	cached   time.Duration
 	uncached time.Duration
@@ -385,10 +408,12 @@ type Config struct {
 	StoreContentCachedStaleTime storeContentCachedStaleTimeMap
 	StoreClientUserAgent        string
 	ContentProxyConnectionLimit ContentProxyConnectionLimitMap
+	ContentProxyBandwidthLimit  ContentProxyBandwidthLimitMap
 	IP                          *IPResolver
 
-	DataDir     string
-	VaultSecret string
+	DataDir         string
+	VaultSecret     string
+	VaultOldSecrets []string
 }
 
 func parseUri(uri string) (parsedUrl, parsedToken string) {
@@ -484,7 +509,7 @@ var config = func() Config {
 	databaseUri := getEnv("STREMTHRU_DATABASE_URI")
 
 	feature := FeatureConfig{
-		disabled: []string{FeatureAnime, FeatureStremioP2P},
+		disabled: []string{FeatureAnime, FeatureStremioP2P, FeatureTorrentStream},
 	}
 	for _, name := range strings.FieldsFunc(strings.TrimSpace(getEnv("STREMTHRU_FEATURE")), func(c rune) bool {
 		return c == ','
@@ -550,6 +575,23 @@ var config = func() Config {
 		}
 	}
 
+	contentProxyBandwidthMap := make(ContentProxyBandwidthLimitMap)
+	contentProxyBandwidthList := strings.FieldsFunc(getEnv("STREMTHRU_CONTENT_PROXY_BANDWIDTH_LIMIT"), func(c rune) bool {
+		return c == ','
+	})
+	for _, contentProxyBandwidth := range contentProxyBandwidthList {
+		if user, limitStr, ok := strings.Cut(contentProxyBandwidth, ":"); ok {
+			limit := int64(0)
+			if limitStr != "0" {
+				limit = util.ToBytes(limitStr)
+				if limit < 0 {
+					log.Fatalf("Invalid content proxy bandwidth limit: %s", limitStr)
+				}
+			}
+			contentProxyBandwidthMap[user] = limit
+		}
+	}
+
 	dataDir, err := filepath.Abs(getEnv("STREMTHRU_DATA_DIR"))
 	if err != nil {
 		log.Fatalf("failed to resolve data directory: %v", err)
@@ -565,6 +607,12 @@ var config = func() Config {
 	}
 
 	vaultSecret := getEnv("STREMTHRU_VAULT_SECRET")
+	vaultOldSecrets := []string{}
+	for secret := range strings.SplitSeq(getEnv("STREMTHRU_VAULT_OLD_SECRETS"), ",") {
+		if secret = strings.TrimSpace(secret); secret != "" && secret != vaultSecret {
+			vaultOldSecrets = append(vaultOldSecrets, secret)
+		}
+	}
 
 	// @deprecated
 	lazyPeer := strings.ToLower(getEnv("STREMTHRU_LAZY_PEER"))
@@ -612,12 +660,14 @@ var config = func() Config {
 		StoreContentCachedStaleTime: storeContentCachedStaleTimeMap,
 		StoreClientUserAgent:        getEnv("STREMTHRU_STORE_CLIENT_USER_AGENT"),
 		ContentProxyConnectionLimit: contentProxyConnectionMap,
+		ContentProxyBandwidthLimit:  contentProxyBandwidthMap,
 		IP: &IPResolver{
 			checker: getEnv("STREMTHRU_IP_CHECKER"),
 		},
 
-		DataDir:     dataDir,
-		VaultSecret: vaultSecret,
+		DataDir:         dataDir,
+		VaultSecret:     vaultSecret,
+		VaultOldSecrets: vaultOldSecrets,
 	}
 }()
 
@@ -646,6 +696,7 @@ var StoreContentProxy = config.StoreContentProxy
 var StoreContentCachedStaleTime = config.StoreContentCachedStaleTime
 var StoreClientUserAgent = config.StoreClientUserAgent
 var ContentProxyConnectionLimit = config.ContentProxyConnectionLimit
+var ContentProxyBandwidthLimit = config.ContentProxyBandwidthLimit
 var InstanceId = strings.ReplaceAll(uuid.NewString(), "-", "")
 var IP = config.IP
 
@@ -668,6 +719,7 @@ var IsTrusted = func() bool {
 
 var DataDir = config.DataDir
 var VaultSecret = config.VaultSecret
+var VaultOldSecrets = config.VaultOldSecrets
 
 var IsPublicInstance = len(ProxyAuthPassword) == 0
 
@@ -718,28 +770,31 @@ func PrintConfig(state *AppState) {
 
 	if hasTunnel {
 		l.Println(" Tunnel:")
-		if defaultProxy := Tunnel.getProxy("*"); defaultProxy != nil && defaultProxy.Host != "" {
+		if defaultPool := Tunnel.getPool("*"); defaultPool != nil && defaultPool.hasProxy() {
 			defaultProxyConfig := ""
 			if noProxy := getEnv("NO_PROXY"); noProxy == "*" {
 				defaultProxyConfig = " (disabled)"
 			}
-			l.Println("   Default: " + defaultProxy.Redacted() + defaultProxyConfig)
-			l.Println("   [Store]: " + defaultProxy.Redacted())
+			l.Println("   Default: " + defaultPool.Redacted() + defaultProxyConfig)
+			l.Println("   [Store]: " + defaultPool.Redacted())
+			if len(defaultPool.exits) > 1 {
+				l.Println(" Selection: " + string(tunnelPoolSelection))
+			}
 		}
 
 		if len(Tunnel) > 1 {
 			l.Println("   By Host:")
-			for hostname, proxy := range Tunnel {
+			for hostname, pool := range Tunnel {
 				if hostname == "*" {
 					continue
 				}
 
-				if proxy.Host == "" {
+				if !pool.hasProxy() {
 					if defaultProxyHost != "" {
 						l.Println("     " + hostname + ": (disabled)")
 					}
 				} else {
-					l.Println("     " + hostname + ": " + proxy.Redacted())
+					l.Println("     " + hostname + ": " + pool.Redacted())
 				}
 			}
 		}
@@ -781,6 +836,9 @@ func PrintConfig(state *AppState) {
 			if cpcl := ContentProxyConnectionLimit.Get(user); cpcl > 0 {
 				l.Println("       content_proxy_connection_limit: " + strconv.FormatUint(uint64(cpcl), 10))
 			}
+			if cpbl := ContentProxyBandwidthLimit.Get(user); cpbl > 0 {
+				l.Println("       content_proxy_bandwidth_limit: " + util.ToSize(cpbl) + "/s")
+			}
 		}
 		l.Println()
 	}
@@ -888,6 +946,10 @@ func PrintConfig(state *AppState) {
 			if !Feature.HasIMDBTitle() {
 				disabled = " (disabled)"
 			}
+		case FeatureTorrentStream:
+			if !Feature.HasTorrentStream() {
+				disabled = " (disabled)"
+			}
 		case FeatureVault:
 			if !Feature.HasVault() {
 				disabled = " (disabled)"
@@ -920,14 +982,19 @@ func PrintConfig(state *AppState) {
 		case FeatureStremioWrap:
 			l.Println("       public max upstream count: " + strconv.Itoa(Stremio.Wrap.PublicMaxUpstreamCount))
 			l.Println("          public max store count: " + strconv.Itoa(Stremio.Wrap.PublicMaxStoreCount))
+		case FeatureTorrentStream:
+			l.Println("       disk budget: " + util.ToSize(TorrentStream.DiskBudget))
 		case FeatureVault:
 			l.Println("       secret: " + strings.Repeat("*", len(VaultSecret)))
+			if len(VaultOldSecrets) > 0 {
+				l.Println("       old secrets: " + strconv.Itoa(len(VaultOldSecrets)))
+			}
 		}
 	}
 	l.Println()
 
 	l.Println(" Integrations:")
-	for _, integration := range []string{"anilist.co", "bitmagnet.io", "github.com", "kitsu.app", "letterboxd.com", "mdblist.com", "themoviedb.org", "trakt.tv", "thetvdb.com"} {
+	for _, integration := range []string{"anilist.co", "bitmagnet.io", "github.com", "kitsu.app", "letterboxd.com", "mdblist.com", "myanimelist.net", "simkl.com", "themoviedb.org", "trakt.tv", "thetvdb.com"} {
 		switch integration {
 		case "anilist.co":
 			disabled := ""
@@ -991,6 +1058,27 @@ func PrintConfig(state *AppState) {
 		case "mdblist.com":
 			l.Println("   - " + integration)
 			l.Println("       list stale time: " + Integration.MDBList.ListStaleTime.String())
+		case "myanimelist.net":
+			disabled := ""
+			if !Feature.IsEnabled(FeatureAnime) || !Integration.MAL.IsEnabled() {
+				disabled = " (disabled)"
+			}
+			l.Println("   - " + integration + disabled)
+			if disabled == "" {
+				l.Println("             client_id: " + Integration.MAL.ClientId[0:3] + "..." + Integration.MAL.ClientId[len(Integration.MAL.ClientId)-3:])
+				l.Println("       list stale time: " + Integration.MAL.ListStaleTime.String())
+			}
+		case "simkl.com":
+			disabled := ""
+			if !Integration.Simkl.IsEnabled() {
+				disabled = " (disabled)"
+			}
+			l.Println("   - " + integration + disabled)
+			if disabled == "" {
+				l.Println("             client_id: " + Integration.Simkl.ClientId[0:3] + "..." + Integration.Simkl.ClientId[len(Integration.Simkl.ClientId)-3:])
+				l.Println("         client_secret: " + Integration.Simkl.ClientSecret[0:3] + "..." + Integration.Simkl.ClientSecret[len(Integration.Simkl.ClientSecret)-3:])
+				l.Println("       list stale time: " + Integration.Simkl.ListStaleTime.String())
+			}
 		case "themoviedb.org":
 			disabled := ""
 			if !Integration.TMDB.IsEnabled() {
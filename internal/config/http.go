@@ -20,11 +20,11 @@ const (
 	TUNNEL_TYPE_FORCED TunnelType = "f"
 )
 
-type TunnelMap map[string]url.URL
+type TunnelMap map[string]*TunnelPool
 
 func (tm TunnelMap) hasProxy() bool {
-	for _, proxyUrl := range tm {
-		if proxyUrl.Host != "" {
+	for _, pool := range tm {
+		if pool.hasProxy() {
 			return true
 		}
 	}
@@ -38,14 +38,11 @@ func (tm TunnelMap) GetDefaultProxyHost() string {
 	return ""
 }
 
-func (tm TunnelMap) getProxy(hostname string) *url.URL {
+func (tm TunnelMap) getPool(hostname string) *TunnelPool {
 	hn := hostname
 	for {
-		if proxy, ok := tm[hn]; ok {
-			if hn != hostname {
-				tm[hostname] = proxy
-			}
-			return &proxy
+		if pool, ok := tm[hn]; ok {
+			return pool
 		}
 
 		_, hn, _ = strings.Cut(hn, ".")
@@ -56,26 +53,39 @@ func (tm TunnelMap) getProxy(hostname string) *url.URL {
 	return nil
 }
 
+func (tm TunnelMap) getProxy(hostname string) *url.URL {
+	if pool := tm.getPool(hostname); pool != nil {
+		return pool.primary()
+	}
+	return nil
+}
+
 // If tunnel is configured for `hostname` use that.
 // Otherwise fallback to environment proxy, i.e. `HTTP_PROXY`, `HTTPS_PROXY`, `NO_PROXY`
 func (tm TunnelMap) autoProxy(r *http.Request) (*url.URL, error) {
-	proxy := tm.getProxy(r.URL.Hostname())
-	if proxy == nil {
-		return http.ProxyFromEnvironment(r)
+	pool := tm.getPool(r.URL.Hostname())
+	if pool == nil {
+		proxy, err := http.ProxyFromEnvironment(r)
+		if err == nil && proxy != nil {
+			if defaultPool := tm.getPool("*"); defaultPool != nil && defaultPool.primary().String() == proxy.String() {
+				return defaultPool.Select(r), nil
+			}
+		}
+		return proxy, err
 	}
-	if proxy.Host == "" {
+	if !pool.hasProxy() {
 		return nil, nil
 	}
-	return proxy, nil
+	return pool.Select(r), nil
 }
 
 // Use the default tunnel, ignore `NO_PROXY`
 func (tm TunnelMap) forcedProxy(r *http.Request) (*url.URL, error) {
-	if proxy := tm.getProxy(r.URL.Hostname()); proxy != nil && proxy.Host != "" {
-		return proxy, nil
+	if pool := tm.getPool(r.URL.Hostname()); pool != nil && pool.hasProxy() {
+		return pool.Select(r), nil
 	}
-	if proxy := tm.getProxy("*"); proxy != nil && proxy.Host != "" {
-		return proxy, nil
+	if pool := tm.getPool("*"); pool != nil && pool.hasProxy() {
+		return pool.Select(r), nil
 	}
 	return nil, nil
 }
@@ -96,33 +106,37 @@ func (tm TunnelMap) GetProxy(tunnelType TunnelType) func(req *http.Request) (*ur
 func parseTunnel(httpProxy, httpsProxy, tunnel string) TunnelMap {
 	tunnelMap := make(TunnelMap)
 
-	defaultProxy := &url.URL{}
+	exitByURL := map[string]*TunnelExit{}
+	defaultPool := &TunnelPool{selection: tunnelPoolSelection}
 
 	if value := httpProxy; len(value) > 0 {
+		defaultPool = parseTunnelPool(value, exitByURL)
+		if defaultPool.hasProxy() {
+			value = defaultPool.primary().String()
+		}
 		if err := os.Setenv("HTTP_PROXY", value); err != nil {
 			log.Fatal("failed to set http_proxy")
 		}
 		if err := os.Setenv("HTTPS_PROXY", value); err != nil {
 			log.Fatal("failed to set https_proxy")
 		}
-		if u, err := url.Parse(value); err == nil {
-			defaultProxy = u
-		}
 	}
 
 	// deprecated
 	if value := httpsProxy; len(value) > 0 {
+		pool := parseTunnelPool(value, exitByURL)
+		if pool.hasProxy() {
+			value = pool.primary().String()
+		}
 		if err := os.Setenv("HTTPS_PROXY", value); err != nil {
 			log.Fatal("failed to set https_proxy")
 		}
-		if defaultProxy.Host == "" {
-			if u, err := url.Parse(value); err == nil {
-				defaultProxy = u
-			}
+		if !defaultPool.hasProxy() {
+			defaultPool = pool
 		}
 	}
 
-	tunnelMap["*"] = *defaultProxy
+	tunnelMap["*"] = defaultPool
 
 	tunnelList := strings.FieldsFunc(tunnel, func(c rune) bool {
 		return c == ','
@@ -145,12 +159,12 @@ func parseTunnel(httpProxy, httpsProxy, tunnel string) TunnelMap {
 
 			switch proxy {
 			case "false":
-				tunnelMap[hostname] = url.URL{}
+				tunnelMap[hostname] = &TunnelPool{selection: tunnelPoolSelection}
 			case "true":
-				tunnelMap[hostname] = *defaultProxy
+				tunnelMap[hostname] = defaultPool
 			default:
-				if u, err := url.Parse(proxy); err == nil {
-					tunnelMap[hostname] = *u
+				if pool := parseTunnelPool(proxy, exitByURL); pool.hasProxy() {
+					tunnelMap[hostname] = pool
 				}
 			}
 		}
@@ -239,18 +253,18 @@ func parseStoreTunnel(storeTunnel string, tunnelMap TunnelMap) StoreTunnelConfig
 				for _, hostname := range contentHostnameByStore {
 					if _, exists := tunnelMap[hostname]; !exists {
 						if tunnel == "true" {
-							tunnelMap[hostname] = *tunnelMap.getProxy("*")
+							tunnelMap[hostname] = tunnelMap.getPool("*")
 						} else {
-							tunnelMap[hostname] = url.URL{}
+							tunnelMap[hostname] = &TunnelPool{selection: tunnelPoolSelection}
 						}
 					}
 				}
 			default:
 				if hostname, ok := contentHostnameByStore[store]; ok {
 					if tunnel == "true" {
-						tunnelMap[hostname] = *tunnelMap.getProxy("*")
+						tunnelMap[hostname] = tunnelMap.getPool("*")
 					} else {
-						tunnelMap[hostname] = url.URL{}
+						tunnelMap[hostname] = &TunnelPool{selection: tunnelPoolSelection}
 					}
 				}
 			}
@@ -321,6 +335,7 @@ func (ipr *IPResolver) getIp(client *http.Client) (string, error) {
 		if err != nil {
 			return "", err
 		}
+		defer res.Body.Close()
 		body, err := io.ReadAll(res.Body)
 		if err != nil {
 			return "", err
@@ -335,6 +350,7 @@ func (ipr *IPResolver) getIp(client *http.Client) (string, error) {
 		if err != nil {
 			return "", err
 		}
+		defer res.Body.Close()
 		body, err := io.ReadAll(res.Body)
 		if err != nil {
 			return "", err
@@ -380,29 +396,32 @@ func (ipr *IPResolver) resolveTunnelIPMap() error {
 	proxyIpByHostname := map[string]string{}
 	errs := []error{}
 
-	for hostname, u := range Tunnel {
-		if ip, ok := proxyIpByProxyHost[u.Host]; ok {
-			proxyIpByHostname[hostname] = ip
+	for hostname, pool := range Tunnel {
+		if !pool.hasProxy() {
+			proxyIpByHostname[hostname] = ipr.GetMachineIP()
 			continue
 		}
-		var ip string
-		if u.Host == "" {
-			ip = ipr.GetMachineIP()
-		} else {
-			client := getHTTPClientWithProxy(&u)
-			client.Timeout = 30 * time.Second
-			if proxyIp, err := ipr.getIp(client); err == nil {
-				ip = proxyIp
-			} else {
-				errs = append(errs, err)
+		ips := make([]string, 0, len(pool.exits))
+		for _, exit := range pool.exits {
+			u := exit.URL
+			ip, ok := proxyIpByProxyHost[u.Host]
+			if !ok {
+				client := getHTTPClientWithProxy(&u)
+				client.Timeout = 30 * time.Second
+				if proxyIp, err := ipr.getIp(client); err == nil {
+					ip = proxyIp
+				} else {
+					errs = append(errs, err)
+				}
+				proxyIpByProxyHost[u.Host] = ip
+			}
+			if ip != "" {
+				ips = append(ips, ip)
 			}
 		}
-		proxyIpByHostname[hostname] = ip
-		proxyIpByProxyHost[u.Host] = ip
+		proxyIpByHostname[hostname] = strings.Join(ips, ",")
 	}
 
-	delete(proxyIpByProxyHost, "")
-
 	ipr.proxyIpByHostname = proxyIpByHostname
 	ipr.proxyIpByProxyHost = proxyIpByProxyHost
 	ipr.proxyIpMapStaleAt = time.Now().Add(30 * time.Minute)
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"os"
@@ -88,6 +89,74 @@ func (s *TunnelTestSuite) TestDefaultOn() {
 	s.Equal(tunnel.getProxy("a.x.y"), &url.URL{})
 }
 
+func (s *TunnelTestSuite) TestPool() {
+	httpProxy := "socks5://10.0.0.1:1080|socks5://10.0.0.2:1080"
+	tunnel := parseTunnel(httpProxy, httpProxy, "*:true,j.k:http://warp:1080|socks5://10.0.0.1:1080")
+
+	s.Equal(os.Getenv("HTTP_PROXY"), "socks5://10.0.0.1:1080")
+	s.Equal(tunnel.GetDefaultProxyHost(), "10.0.0.1:1080")
+	s.Len(tunnel.getExits(), 3)
+
+	req := &http.Request{
+		URL: &url.URL{Host: "abc.xyz", Scheme: "https"},
+	}
+	proxies := []string{}
+	for range 4 {
+		proxy, err := tunnel.forcedProxy(req)
+		s.Nil(err)
+		proxies = append(proxies, proxy.String())
+	}
+	s.Equal(proxies, []string{
+		"socks5://10.0.0.1:1080",
+		"socks5://10.0.0.2:1080",
+		"socks5://10.0.0.1:1080",
+		"socks5://10.0.0.2:1080",
+	})
+
+	pool := tunnel.getPool("*")
+	pool.exits[0].recordHealthCheck("", errors.New("timeout"))
+	s.True(pool.exits[0].IsHealthy())
+	pool.exits[0].recordHealthCheck("", errors.New("timeout"))
+	s.False(pool.exits[0].IsHealthy())
+	for range 2 {
+		proxy, err := tunnel.forcedProxy(req)
+		s.Nil(err)
+		s.Equal(proxy.String(), "socks5://10.0.0.2:1080")
+	}
+
+	pool.exits[1].recordHealthCheck("", errors.New("timeout"))
+	pool.exits[1].recordHealthCheck("", errors.New("timeout"))
+	proxy, err := tunnel.forcedProxy(req)
+	s.Nil(err)
+	s.NotNil(proxy)
+
+	pool.exits[0].recordHealthCheck("1.1.1.1", nil)
+	pool.exits[1].recordHealthCheck("2.2.2.2", nil)
+	s.True(pool.exits[0].IsHealthy())
+	s.Equal(pool.exits[0].GetHealth().IP, "1.1.1.1")
+
+	s.Same(tunnel.getPool("j.k").exits[1], pool.exits[0])
+}
+
+func (s *TunnelTestSuite) TestPoolSticky() {
+	pool := parseTunnelPool("http://a:1080|http://b:1080|http://c:1080", map[string]*TunnelExit{})
+	pool.selection = TunnelPoolSelectionSticky
+
+	selected := pool.selectExit("user")
+	for range 5 {
+		s.Same(pool.selectExit("user"), selected)
+	}
+
+	selected.recordHealthCheck("", errors.New("timeout"))
+	selected.recordHealthCheck("", errors.New("timeout"))
+	fallback := pool.selectExit("user")
+	s.NotSame(fallback, selected)
+	s.Same(pool.selectExit("user"), fallback)
+
+	selected.recordHealthCheck("1.1.1.1", nil)
+	s.Same(pool.selectExit("user"), selected)
+}
+
 func TestTunnel(t *testing.T) {
 	suite.Run(t, new(TunnelTestSuite))
 }
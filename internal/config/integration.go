@@ -45,6 +45,15 @@ func (c integrationConfigLettterboxd) IsPiggybacked() bool {
 	return !c.IsEnabled() && HasPeer
 }
 
+type integrationConfigMAL struct {
+	ClientId      string
+	ListStaleTime time.Duration
+}
+
+func (c integrationConfigMAL) IsEnabled() bool {
+	return c.ClientId != ""
+}
+
 type integrationConfigMDBList struct {
 	ListStaleTime time.Duration
 }
@@ -59,6 +68,16 @@ func (c integrationConfigTrakt) IsEnabled() bool {
 	return c.ClientId != "" && c.ClientSecret != ""
 }
 
+type integrationConfigSimkl struct {
+	ClientId      string
+	ClientSecret  string
+	ListStaleTime time.Duration
+}
+
+func (c integrationConfigSimkl) IsEnabled() bool {
+	return c.ClientId != "" && c.ClientSecret != ""
+}
+
 type integrationConfigKitsu struct {
 	ClientId     string
 	ClientSecret string
@@ -103,7 +122,9 @@ type IntegrationConfig struct {
 	Bitmagnet  integrationConfigBitmagnet
 	GitHub     integrationConfigGitHub
 	Letterboxd integrationConfigLettterboxd
+	MAL        integrationConfigMAL
 	MDBList    integrationConfigMDBList
+	Simkl      integrationConfigSimkl
 	Trakt      integrationConfigTrakt
 	Kitsu      integrationConfigKitsu
 	TMDB       integrationConfigTMDB
@@ -141,9 +162,18 @@ func parseIntegration() IntegrationConfig {
 			Token: getEnv("STREMTHRU_INTEGRATION_GITHUB_TOKEN"),
 		},
 		Letterboxd: letterboxd,
+		MAL: integrationConfigMAL{
+			ClientId:      getEnv("STREMTHRU_INTEGRATION_MAL_CLIENT_ID"),
+			ListStaleTime: mustParseDuration("mal list stale time", getEnv("STREMTHRU_INTEGRATION_MAL_LIST_STALE_TIME"), 15*time.Minute),
+		},
 		MDBList: integrationConfigMDBList{
 			ListStaleTime: mustParseDuration("mdblist list stale time", getEnv("STREMTHRU_INTEGRATION_MDBLIST_LIST_STALE_TIME"), 15*time.Minute),
 		},
+		Simkl: integrationConfigSimkl{
+			ClientId:      getEnv("STREMTHRU_INTEGRATION_SIMKL_CLIENT_ID"),
+			ClientSecret:  getEnv("STREMTHRU_INTEGRATION_SIMKL_CLIENT_SECRET"),
+			ListStaleTime: mustParseDuration("simkl list stale time", getEnv("STREMTHRU_INTEGRATION_SIMKL_LIST_STALE_TIME"), 15*time.Minute),
+		},
 		Trakt: integrationConfigTrakt{
 			ClientId:      getEnv("STREMTHRU_INTEGRATION_TRAKT_CLIENT_ID"),
 			ClientSecret:  getEnv("STREMTHRU_INTEGRATION_TRAKT_CLIENT_SECRET"),
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type TorrentStreamConfig struct {
+	DiskBudget int64
+	DataDir    string
+}
+
+func parseTorrentStream() TorrentStreamConfig {
+	diskBudget := util.ToBytes(getEnv("STREMTHRU_TORRENT_STREAM_DISK_BUDGET"))
+	if diskBudget <= 0 {
+		panic("invalid torrent stream disk budget")
+	}
+	torrentStream := TorrentStreamConfig{
+		DiskBudget: diskBudget,
+		DataDir:    filepath.Join(DataDir, "torrent"),
+	}
+	return torrentStream
+}
+
+var TorrentStream = parseTorrentStream()
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"hash/fnv"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type TunnelPoolSelection string
+
+const (
+	TunnelPoolSelectionRoundRobin TunnelPoolSelection = "round-robin"
+	TunnelPoolSelectionSticky     TunnelPoolSelection = "sticky"
+)
+
+var tunnelPoolSelection = func() TunnelPoolSelection {
+	selection := TunnelPoolSelection(getEnv("STREMTHRU_TUNNEL_POOL_SELECTION"))
+	switch selection {
+	case TunnelPoolSelectionRoundRobin, TunnelPoolSelectionSticky:
+		return selection
+	default:
+		log.Fatalf("invalid tunnel pool selection: %s", selection)
+		return ""
+	}
+}()
+
+var TunnelHealthCheckInterval = mustParseDuration("tunnel health check interval", getEnv("STREMTHRU_TUNNEL_HEALTH_CHECK_INTERVAL"), 10*time.Second)
+
+const tunnelExitUnhealthyThreshold = 2
+
+type TunnelExit struct {
+	URL url.URL
+
+	unhealthy           atomic.Bool
+	m                   sync.Mutex
+	ip                  string
+	consecutiveFailures int
+	lastError           string
+	lastCheckedAt       time.Time
+}
+
+func (te *TunnelExit) IsHealthy() bool {
+	return !te.unhealthy.Load()
+}
+
+func (te *TunnelExit) recordHealthCheck(ip string, err error) {
+	te.m.Lock()
+	defer te.m.Unlock()
+
+	te.lastCheckedAt = time.Now()
+	if err != nil {
+		te.consecutiveFailures++
+		te.lastError = err.Error()
+		if te.consecutiveFailures >= tunnelExitUnhealthyThreshold {
+			te.unhealthy.Store(true)
+		}
+		return
+	}
+	te.ip = ip
+	te.consecutiveFailures = 0
+	te.lastError = ""
+	te.unhealthy.Store(false)
+}
+
+type TunnelExitHealth struct {
+	Proxy               string `json:"proxy"`
+	Healthy             bool   `json:"healthy"`
+	IP                  string `json:"ip"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	LastCheckedAt       string `json:"last_checked_at,omitempty"`
+}
+
+func (te *TunnelExit) GetHealth() TunnelExitHealth {
+	te.m.Lock()
+	defer te.m.Unlock()
+
+	health := TunnelExitHealth{
+		Proxy:               te.URL.Redacted(),
+		Healthy:             te.IsHealthy(),
+		IP:                  te.ip,
+		ConsecutiveFailures: te.consecutiveFailures,
+		LastError:           te.lastError,
+	}
+	if !te.lastCheckedAt.IsZero() {
+		health.LastCheckedAt = te.lastCheckedAt.Format(time.RFC3339)
+	}
+	return health
+}
+
+// TunnelPool is a list of proxies used for a tunnel entry, an empty pool
+// means the tunnel is disabled.
+type TunnelPool struct {
+	exits     []*TunnelExit
+	selection TunnelPoolSelection
+	counter   atomic.Uint64
+}
+
+func (tp *TunnelPool) hasProxy() bool {
+	return len(tp.exits) > 0
+}
+
+func (tp *TunnelPool) primary() *url.URL {
+	if !tp.hasProxy() {
+		return &url.URL{}
+	}
+	u := tp.exits[0].URL
+	return &u
+}
+
+func (tp *TunnelPool) Redacted() string {
+	proxies := make([]string, len(tp.exits))
+	for i, exit := range tp.exits {
+		proxies[i] = exit.URL.Redacted()
+	}
+	return strings.Join(proxies, " | ")
+}
+
+// selectExit skips unhealthy exits, unless none of them are healthy. For
+// sticky selection, rendezvous hashing keeps the same exit for the key as
+// long as it is healthy.
+func (tp *TunnelPool) selectExit(key string) *TunnelExit {
+	count := len(tp.exits)
+	if count == 0 {
+		return nil
+	}
+	if count == 1 {
+		return tp.exits[0]
+	}
+
+	exits := make([]*TunnelExit, 0, count)
+	for _, exit := range tp.exits {
+		if exit.IsHealthy() {
+			exits = append(exits, exit)
+		}
+	}
+	if len(exits) == 0 {
+		exits = tp.exits
+	}
+
+	if tp.selection == TunnelPoolSelectionSticky && key != "" {
+		var selected *TunnelExit
+		var maxScore uint64
+		for _, exit := range exits {
+			h := fnv.New64a()
+			h.Write([]byte(key))
+			h.Write([]byte(exit.URL.String()))
+			if score := h.Sum64(); selected == nil || score > maxScore {
+				selected = exit
+				maxScore = score
+			}
+		}
+		return selected
+	}
+
+	return exits[(tp.counter.Add(1)-1)%uint64(len(exits))]
+}
+
+func (tp *TunnelPool) Select(r *http.Request) *url.URL {
+	exit := tp.selectExit(request.GetTunnelStickyKey(r.Context()))
+	if exit == nil {
+		return nil
+	}
+	u := exit.URL
+	return &u
+}
+
+func parseTunnelPool(value string, exitByURL map[string]*TunnelExit) *TunnelPool {
+	pool := &TunnelPool{selection: tunnelPoolSelection}
+	for proxy := range strings.SplitSeq(value, "|") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		exit, ok := exitByURL[u.String()]
+		if !ok {
+			exit = &TunnelExit{URL: *u}
+			exitByURL[u.String()] = exit
+		}
+		pool.exits = append(pool.exits, exit)
+	}
+	return pool
+}
+
+func (tm TunnelMap) getExits() []*TunnelExit {
+	exits := []*TunnelExit{}
+	seen := map[*TunnelExit]struct{}{}
+	for _, pool := range tm {
+		for _, exit := range pool.exits {
+			if _, ok := seen[exit]; !ok {
+				seen[exit] = struct{}{}
+				exits = append(exits, exit)
+			}
+		}
+	}
+	return exits
+}
+
+func (tm TunnelMap) GetExitHealths() []TunnelExitHealth {
+	exits := tm.getExits()
+	healths := make([]TunnelExitHealth, len(exits))
+	for i, exit := range exits {
+		healths[i] = exit.GetHealth()
+	}
+	return healths
+}
+
+func (tm TunnelMap) checkExitHealth(exits []*TunnelExit) {
+	var wg sync.WaitGroup
+	for _, exit := range exits {
+		wg.Go(func() {
+			client := getHTTPClientWithProxy(&exit.URL)
+			client.Timeout = 15 * time.Second
+			ip, err := IP.getIp(client)
+			wasHealthy := exit.IsHealthy()
+			exit.recordHealthCheck(ip, err)
+			if isHealthy := exit.IsHealthy(); isHealthy != wasHealthy {
+				if isHealthy {
+					log.Printf("tunnel exit is healthy again: %s\n", exit.URL.Redacted())
+				} else {
+					log.Printf("tunnel exit is unhealthy: %s (%v)\n", exit.URL.Redacted(), err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// StartTunnelHealthCheck periodically checks the tunnel exits, unhealthy
+// exits are skipped by the pools until they recover.
+func StartTunnelHealthCheck() (stop func()) {
+	exits := Tunnel.getExits()
+	if len(exits) == 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(TunnelHealthCheckInterval)
+		defer ticker.Stop()
+
+		Tunnel.checkExitHealth(exits)
+		for {
+			select {
+			case <-ticker.C:
+				Tunnel.checkExitHealth(exits)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
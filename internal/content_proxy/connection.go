@@ -0,0 +1,202 @@
+package content_proxy
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+)
+
+var log = logger.Scoped("content_proxy")
+
+// connections not renewed within the lease duration are considered dead,
+// e.g. when the instance serving them crashed.
+const leaseDuration = 30 * time.Second
+const leaseRenewInterval = 10 * time.Second
+
+type Connection struct {
+	Id           string    `json:"id"`
+	User         string    `json:"user"`
+	IP           string    `json:"ip"`
+	Link         string    `json:"link"`
+	Instance     string    `json:"instance"`
+	BytesWritten int64     `json:"bytes_written"`
+	StartedAt    time.Time `json:"started_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type registry interface {
+	// acquire registers the connection and returns the number of active
+	// connections of the user, 0 if the user already has `limit` active
+	// connections.
+	acquire(conn *Connection, limit int) (int, error)
+	// renew extends the lease of the connection and returns the number of
+	// active connections of the user.
+	renew(conn *Connection) (killed bool, count int, err error)
+	release(conn *Connection) error
+	list() ([]Connection, error)
+	kill(id string) (bool, error)
+}
+
+var reg = func() registry {
+	if client := cache.GetRedisClient(); client != nil {
+		return &redisRegistry{client: client}
+	}
+	return &sqlRegistry{}
+}()
+
+var localLeases sync.Map
+
+type Lease struct {
+	conn           Connection
+	bytesWritten   atomic.Int64
+	bandwidthLimit int64
+	throttle       throttle
+	ctx            context.Context
+	cancel         context.CancelFunc
+	done           chan struct{}
+	releaseOnce    sync.Once
+}
+
+// Acquire registers the connection across all instances. It returns nil if
+// the user reached the connection limit. The lease keeps the connection
+// alive until it is released, and cancels its context if it is killed.
+func Acquire(ctx context.Context, conn Connection, connectionLimit int, bandwidthLimit int64) (*Lease, error) {
+	now := time.Now()
+	conn.Instance = config.InstanceId
+	conn.StartedAt = now
+	conn.ExpiresAt = now.Add(leaseDuration)
+
+	count, err := reg.acquire(&conn, connectionLimit)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	lease := &Lease{
+		conn:           conn,
+		bandwidthLimit: bandwidthLimit,
+		done:           make(chan struct{}),
+	}
+	lease.ctx, lease.cancel = context.WithCancel(ctx)
+	lease.updateRate(count)
+	localLeases.Store(conn.Id, lease)
+
+	go lease.keepAlive()
+
+	return lease, nil
+}
+
+// the bandwidth limit is shared among the active connections of the user.
+func (l *Lease) updateRate(count int) {
+	if l.bandwidthLimit <= 0 {
+		return
+	}
+	l.throttle.setRate(l.bandwidthLimit / int64(max(1, count)))
+}
+
+func (l *Lease) keepAlive() {
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			l.conn.BytesWritten = l.bytesWritten.Load()
+			l.conn.ExpiresAt = time.Now().Add(leaseDuration)
+			killed, count, err := reg.renew(&l.conn)
+			if err != nil {
+				log.Error("failed to renew lease", "error", err, "id", l.conn.Id)
+				continue
+			}
+			if killed {
+				log.Info("connection killed", "id", l.conn.Id, "user", l.conn.User)
+				l.cancel()
+				continue
+			}
+			l.updateRate(count)
+		}
+	}
+}
+
+func (l *Lease) Context() context.Context {
+	return l.ctx
+}
+
+func (l *Lease) Release() {
+	l.releaseOnce.Do(func() {
+		close(l.done)
+		l.cancel()
+		localLeases.Delete(l.conn.Id)
+		if err := reg.release(&l.conn); err != nil {
+			log.Error("failed to release lease", "error", err, "id", l.conn.Id)
+		}
+	})
+}
+
+// Writer throttles the response to the bandwidth limit, and stops writing
+// once the connection is killed.
+func (l *Lease) Writer(w http.ResponseWriter) http.ResponseWriter {
+	return &leaseWriter{ResponseWriter: w, lease: l}
+}
+
+type leaseWriter struct {
+	http.ResponseWriter
+	lease *Lease
+}
+
+const writeChunkSize = 32 * 1024
+
+func (w *leaseWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), writeChunkSize)]
+		if err := w.lease.throttle.wait(w.lease.ctx, len(chunk)); err != nil {
+			return written, err
+		}
+		n, err := w.ResponseWriter.Write(chunk)
+		written += n
+		w.lease.bytesWritten.Add(int64(n))
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (w *leaseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// List returns the live connections across all instances.
+func List() ([]Connection, error) {
+	conns, err := reg.list()
+	if err != nil {
+		return nil, err
+	}
+	for i := range conns {
+		if lease, ok := localLeases.Load(conns[i].Id); ok {
+			conns[i].BytesWritten = lease.(*Lease).bytesWritten.Load()
+		}
+	}
+	return conns, nil
+}
+
+// Kill stops the connection. Connections served by other instances are
+// stopped on their next lease renewal.
+func Kill(id string) (bool, error) {
+	if lease, ok := localLeases.Load(id); ok {
+		lease.(*Lease).cancel()
+	}
+	return reg.kill(id)
+}
@@ -0,0 +1,234 @@
+package content_proxy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+)
+
+const TableName = "content_proxy_connection"
+
+var Column = struct {
+	Id        string
+	Username  string
+	IP        string
+	Link      string
+	Instance  string
+	Bytes     string
+	Killed    string
+	CAt       string
+	ExpiresAt string
+}{
+	Id:        "id",
+	Username:  "username",
+	IP:        "ip",
+	Link:      "link",
+	Instance:  "instance",
+	Bytes:     "bytes",
+	Killed:    "killed",
+	CAt:       "cat",
+	ExpiresAt: "eat",
+}
+
+var columns = []string{
+	Column.Id,
+	Column.Username,
+	Column.IP,
+	Column.Link,
+	Column.Instance,
+	Column.Bytes,
+	Column.Killed,
+	Column.CAt,
+	Column.ExpiresAt,
+}
+
+type sqlRegistry struct{}
+
+var query_delete_expired = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s < ?`,
+	TableName,
+	Column.ExpiresAt,
+)
+
+var query_insert = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?,?,?,?,?,?,?,?,?)`,
+	TableName,
+	db.JoinColumnNames(columns...),
+)
+
+var query_count_by_username = fmt.Sprintf(
+	`SELECT COUNT(%s) FROM %s WHERE %s = ? AND %s >= ?`,
+	Column.Id,
+	TableName,
+	Column.Username,
+	Column.ExpiresAt,
+)
+
+func (sr *sqlRegistry) count(user string) (int, error) {
+	var count int
+	row := db.QueryRow(query_count_by_username, user, db.Timestamp{Time: time.Now()})
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (sr *sqlRegistry) insert(conn *Connection) error {
+	_, err := db.Exec(
+		query_insert,
+		conn.Id,
+		conn.User,
+		conn.IP,
+		conn.Link,
+		conn.Instance,
+		conn.BytesWritten,
+		false,
+		db.Timestamp{Time: conn.StartedAt},
+		db.Timestamp{Time: conn.ExpiresAt},
+	)
+	return err
+}
+
+func (sr *sqlRegistry) acquire(conn *Connection, limit int) (int, error) {
+	if _, err := db.Exec(query_delete_expired, db.Timestamp{Time: time.Now()}); err != nil {
+		return 0, err
+	}
+
+	if limit > 0 {
+		// db level advisory lock to prevent race condition in multi-node deployment
+		if lock := db.NewAdvisoryLock("content_proxy", "connection", conn.User); lock == nil {
+			return 0, errors.New("failed to create advisory lock")
+		} else if !lock.Acquire() {
+			return 0, errors.New("failed to acquire advisory lock")
+		} else {
+			defer lock.Release()
+		}
+
+		count, err := sr.count(conn.User)
+		if err != nil {
+			return 0, err
+		}
+		if count >= limit {
+			return 0, nil
+		}
+	}
+
+	if err := sr.insert(conn); err != nil {
+		return 0, err
+	}
+	return sr.count(conn.User)
+}
+
+var query_renew = fmt.Sprintf(
+	`UPDATE %s SET %s = ?, %s = ? WHERE %s = ?`,
+	TableName,
+	Column.Bytes,
+	Column.ExpiresAt,
+	Column.Id,
+)
+
+var query_get_killed = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ?`,
+	Column.Killed,
+	TableName,
+	Column.Id,
+)
+
+func (sr *sqlRegistry) renew(conn *Connection) (bool, int, error) {
+	result, err := db.Exec(query_renew, conn.BytesWritten, db.Timestamp{Time: conn.ExpiresAt}, conn.Id)
+	if err != nil {
+		return false, 0, err
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return false, 0, err
+	} else if rowsAffected == 0 {
+		// the lease expired and was cleaned up, while the connection is
+		// still alive.
+		if err := sr.insert(conn); err != nil {
+			return false, 0, err
+		}
+	}
+
+	var killed bool
+	if err := db.QueryRow(query_get_killed, conn.Id).Scan(&killed); err != nil {
+		return false, 0, err
+	}
+	count, err := sr.count(conn.User)
+	if err != nil {
+		return false, 0, err
+	}
+	return killed, count, nil
+}
+
+var query_delete = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	TableName,
+	Column.Id,
+)
+
+func (sr *sqlRegistry) release(conn *Connection) error {
+	_, err := db.Exec(query_delete, conn.Id)
+	return err
+}
+
+var query_list = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s >= ? ORDER BY %s`,
+	db.JoinColumnNames(columns...),
+	TableName,
+	Column.ExpiresAt,
+	Column.CAt,
+)
+
+func (sr *sqlRegistry) list() ([]Connection, error) {
+	rows, err := db.Query(query_list, db.Timestamp{Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conns := []Connection{}
+	for rows.Next() {
+		conn := Connection{}
+		var killed bool
+		var startedAt, expiresAt db.Timestamp
+		if err := rows.Scan(
+			&conn.Id,
+			&conn.User,
+			&conn.IP,
+			&conn.Link,
+			&conn.Instance,
+			&conn.BytesWritten,
+			&killed,
+			&startedAt,
+			&expiresAt,
+		); err != nil {
+			return nil, err
+		}
+		conn.StartedAt = startedAt.Time
+		conn.ExpiresAt = expiresAt.Time
+		conns = append(conns, conn)
+	}
+	return conns, rows.Err()
+}
+
+var query_kill = fmt.Sprintf(
+	`UPDATE %s SET %s = ? WHERE %s = ? AND %s >= ?`,
+	TableName,
+	Column.Killed,
+	Column.Id,
+	Column.ExpiresAt,
+)
+
+func (sr *sqlRegistry) kill(id string) (bool, error) {
+	result, err := db.Exec(query_kill, true, id, db.Timestamp{Time: time.Now()})
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
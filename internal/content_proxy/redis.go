@@ -0,0 +1,163 @@
+package content_proxy
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	r "github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "cproxyconn:"
+
+func getRedisConnectionsKey() string {
+	return redisKeyPrefix + "conns"
+}
+
+func getRedisUserConnectionsKey(user string) string {
+	return redisKeyPrefix + "user:" + user
+}
+
+func getRedisConnectionKey(id string) string {
+	return redisKeyPrefix + "conn:" + id
+}
+
+func getRedisKillKey(id string) string {
+	return redisKeyPrefix + "kill:" + id
+}
+
+// The sorted sets are scored by lease expiry, expired members are dropped
+// before counting.
+var redisAcquireScript = r.NewScript(`
+local now = tonumber(ARGV[1])
+local expires_at = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+if limit > 0 and redis.call('ZCARD', KEYS[1]) >= limit then
+  return 0
+end
+redis.call('ZADD', KEYS[1], expires_at, ARGV[4])
+redis.call('ZADD', KEYS[2], expires_at, ARGV[4])
+redis.call('SET', KEYS[3], ARGV[5], 'PX', ARGV[6])
+return redis.call('ZCARD', KEYS[1])
+`)
+
+type redisRegistry struct {
+	client *r.Client
+}
+
+func (rr *redisRegistry) acquire(conn *Connection, limit int) (int, error) {
+	data, err := json.Marshal(conn)
+	if err != nil {
+		return 0, err
+	}
+	count, err := redisAcquireScript.Run(
+		context.Background(),
+		rr.client,
+		[]string{
+			getRedisUserConnectionsKey(conn.User),
+			getRedisConnectionsKey(),
+			getRedisConnectionKey(conn.Id),
+		},
+		time.Now().UnixMilli(),
+		conn.ExpiresAt.UnixMilli(),
+		limit,
+		conn.Id,
+		data,
+		leaseDuration.Milliseconds(),
+	).Int()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (rr *redisRegistry) renew(conn *Connection) (bool, int, error) {
+	data, err := json.Marshal(conn)
+	if err != nil {
+		return false, 0, err
+	}
+
+	ctx := context.Background()
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	expiresAt := float64(conn.ExpiresAt.UnixMilli())
+	userKey := getRedisUserConnectionsKey(conn.User)
+
+	pipe := rr.client.TxPipeline()
+	killed := pipe.Exists(ctx, getRedisKillKey(conn.Id))
+	pipe.ZAdd(ctx, userKey, r.Z{Score: expiresAt, Member: conn.Id})
+	pipe.ZAdd(ctx, getRedisConnectionsKey(), r.Z{Score: expiresAt, Member: conn.Id})
+	pipe.Set(ctx, getRedisConnectionKey(conn.Id), data, leaseDuration)
+	pipe.ZRemRangeByScore(ctx, userKey, "-inf", now)
+	count := pipe.ZCard(ctx, userKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, 0, err
+	}
+	return killed.Val() > 0, int(count.Val()), nil
+}
+
+func (rr *redisRegistry) release(conn *Connection) error {
+	ctx := context.Background()
+	pipe := rr.client.TxPipeline()
+	pipe.ZRem(ctx, getRedisUserConnectionsKey(conn.User), conn.Id)
+	pipe.ZRem(ctx, getRedisConnectionsKey(), conn.Id)
+	pipe.Del(ctx, getRedisConnectionKey(conn.Id), getRedisKillKey(conn.Id))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (rr *redisRegistry) list() ([]Connection, error) {
+	ctx := context.Background()
+	key := getRedisConnectionsKey()
+	if err := rr.client.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().UnixMilli(), 10)).Err(); err != nil {
+		return nil, err
+	}
+	ids, err := rr.client.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	conns := []Connection{}
+	if len(ids) == 0 {
+		return conns, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = getRedisConnectionKey(id)
+	}
+	values, err := rr.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		conn := Connection{}
+		if err := json.Unmarshal([]byte(data), &conn); err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
+
+func (rr *redisRegistry) kill(id string) (bool, error) {
+	ctx := context.Background()
+	expiresAt, err := rr.client.ZScore(ctx, getRedisConnectionsKey(), id).Result()
+	if err != nil {
+		if err == r.Nil {
+			return false, nil
+		}
+		return false, err
+	}
+	if int64(expiresAt) < time.Now().UnixMilli() {
+		return false, nil
+	}
+	if err := rr.client.Set(ctx, getRedisKillKey(id), 1, 2*leaseDuration).Err(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
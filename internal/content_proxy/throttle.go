@@ -0,0 +1,59 @@
+package content_proxy
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// throttle is a token bucket, with a burst of one second worth of bytes.
+type throttle struct {
+	rate   atomic.Int64 // bytes per second, 0 for unlimited
+	m      sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (t *throttle) setRate(rate int64) {
+	t.rate.Store(max(0, rate))
+}
+
+func (t *throttle) wait(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rate := float64(t.rate.Load())
+	if rate <= 0 {
+		return nil
+	}
+
+	t.m.Lock()
+	now := time.Now()
+	if t.last.IsZero() {
+		t.tokens = rate
+	} else {
+		t.tokens = min(rate, t.tokens+now.Sub(t.last).Seconds()*rate)
+	}
+	t.last = now
+	t.tokens -= float64(n)
+	delay := time.Duration(0)
+	if t.tokens < 0 {
+		delay = time.Duration(-t.tokens / rate * float64(time.Second))
+	}
+	t.m.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
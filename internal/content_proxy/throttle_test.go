@@ -0,0 +1,41 @@
+package content_proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		th := throttle{}
+		start := time.Now()
+		for range 100 {
+			assert.NoError(t, th.wait(context.Background(), 1<<20))
+		}
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		th := throttle{}
+		th.setRate(1 << 20)
+		start := time.Now()
+		assert.NoError(t, th.wait(context.Background(), 1<<20))
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+		assert.NoError(t, th.wait(context.Background(), 1<<18))
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+		assert.Less(t, elapsed, 500*time.Millisecond)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		th := throttle{}
+		th.setRate(1 << 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		assert.ErrorIs(t, th.wait(ctx, 1<<20), context.Canceled)
+		assert.ErrorIs(t, th.wait(ctx, 1), context.Canceled)
+	})
+}
@@ -0,0 +1,82 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/content_proxy"
+)
+
+type ContentProxyConnectionResponse struct {
+	Id           string `json:"id"`
+	User         string `json:"user"`
+	IP           string `json:"ip"`
+	Link         string `json:"link"`
+	Instance     string `json:"instance"`
+	IsLocal      bool   `json:"is_local"`
+	BytesWritten int64  `json:"bytes_written"`
+	StartedAt    string `json:"started_at"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+func handleGetContentProxyConnections(w http.ResponseWriter, r *http.Request) {
+	items, err := content_proxy.List()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]ContentProxyConnectionResponse, len(items))
+	for i := range items {
+		item := &items[i]
+		data[i] = ContentProxyConnectionResponse{
+			Id:           item.Id,
+			User:         item.User,
+			IP:           item.IP,
+			Link:         item.Link,
+			Instance:     item.Instance,
+			IsLocal:      item.Instance == config.InstanceId,
+			BytesWritten: item.BytesWritten,
+			StartedAt:    item.StartedAt.Format(time.RFC3339),
+			ExpiresAt:    item.ExpiresAt.Format(time.RFC3339),
+		}
+	}
+
+	SendData(w, r, 200, data)
+}
+
+func handleKillContentProxyConnection(w http.ResponseWriter, r *http.Request) {
+	killed, err := content_proxy.Kill(r.PathValue("id"))
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if !killed {
+		ErrorNotFound(r, "connection not found").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func AddContentProxyEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/content-proxy/connections", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetContentProxyConnections(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/content-proxy/connections/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			handleKillContentProxyConnection(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
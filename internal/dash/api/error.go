@@ -114,6 +114,15 @@ func ErrorNotFound(r *http.Request, msg string) *APIError {
 	return err
 }
 
+func ErrorConflict(r *http.Request, msg string) *APIError {
+	if msg == "" {
+		msg = "Conflict"
+	}
+	err := NewAPIError(http.StatusConflict, msg)
+	err.InjectRequest(r)
+	return err
+}
+
 func ErrorMethodNotAllowed(r *http.Request) *APIError {
 	err := NewAPIError(http.StatusMethodNotAllowed, "Method Not Allowed")
 	err.InjectRequest(r)
@@ -87,6 +87,7 @@ type ServerStatsFeature struct {
 }
 
 type ServerStatsIntegration struct {
+	Simkl bool `json:"simkl"`
 	Trakt bool `json:"trakt"`
 }
 
@@ -110,6 +111,7 @@ func HandleGetServerStats(w http.ResponseWriter, r *http.Request) {
 			Vault: config.Feature.HasVault(),
 		},
 		Integration: ServerStatsIntegration{
+			Simkl: config.Integration.Simkl.IsEnabled(),
 			Trakt: config.Integration.Trakt.IsEnabled(),
 		},
 	}
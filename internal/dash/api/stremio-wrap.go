@@ -0,0 +1,669 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+	stremio_wrap "github.com/MunifTanjim/stremthru/internal/stremio/wrap"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+const errMessageReservedTransformerEntityId = "✨-prefixed ids are reserved"
+
+func formatTransformerEntityTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+type StremioWrapExtractorResponse struct {
+	Id        string `json:"id"`
+	Value     string `json:"value"`
+	IsBuiltIn bool   `json:"is_builtin"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func toStremioWrapExtractorResponse(item *stremio_wrap.TransformerEntity[stremio_transformer.StreamExtractorBlob]) StremioWrapExtractorResponse {
+	return StremioWrapExtractorResponse{
+		Id:        item.Id,
+		Value:     string(item.Value),
+		IsBuiltIn: item.IsBuiltIn,
+		CreatedAt: formatTransformerEntityTime(item.CreatedAt),
+		UpdatedAt: formatTransformerEntityTime(item.UpdatedAt),
+	}
+}
+
+type StremioWrapExtractorVersionResponse struct {
+	Version   int64  `json:"version"`
+	Value     string `json:"value"`
+	CreatedAt string `json:"created_at"`
+}
+
+func handleGetStremioWrapExtractors(w http.ResponseWriter, r *http.Request) {
+	items, err := stremio_wrap.ExtractorStore.List()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioWrapExtractorResponse, len(items))
+	for i := range items {
+		data[i] = toStremioWrapExtractorResponse(&items[i])
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type SaveStremioWrapExtractorRequest struct {
+	Id    string `json:"id"`
+	Value string `json:"value"`
+}
+
+func validateStremioWrapExtractor(value stremio_transformer.StreamExtractorBlob) []Error {
+	errs := []Error{}
+	if value == "" {
+		errs = append(errs, Error{
+			Location: "value",
+			Message:  "missing value",
+		})
+	} else if _, err := value.Parse(); err != nil {
+		errs = append(errs, Error{
+			Location: "value",
+			Message:  err.Error(),
+		})
+	}
+	return errs
+}
+
+func handleCreateStremioWrapExtractor(w http.ResponseWriter, r *http.Request) {
+	request := &SaveStremioWrapExtractorRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	errs := []Error{}
+	if request.Id == "" {
+		errs = append(errs, Error{
+			Location: "id",
+			Message:  "missing id",
+		})
+	} else if stremio_wrap.IsBuiltInTransformerEntityId(request.Id) {
+		errs = append(errs, Error{
+			Location: "id",
+			Message:  errMessageReservedTransformerEntityId,
+		})
+	}
+	value := stremio_transformer.StreamExtractorBlob(request.Value)
+	errs = append(errs, validateStremioWrapExtractor(value)...)
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := stremio_wrap.ExtractorStore.Get(request.Id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing != nil {
+		ErrorConflict(r, "extractor already exists").Send(w, r)
+		return
+	}
+
+	if err := stremio_wrap.ExtractorStore.Save(request.Id, value); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	item, err := stremio_wrap.ExtractorStore.Get(request.Id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 201, toStremioWrapExtractorResponse(item))
+}
+
+func handleGetStremioWrapExtractor(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	item, err := stremio_wrap.ExtractorStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if item == nil {
+		ErrorNotFound(r, "extractor not found").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 200, toStremioWrapExtractorResponse(item))
+}
+
+func handleUpdateStremioWrapExtractor(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if stremio_wrap.IsBuiltInTransformerEntityId(id) {
+		ErrorForbidden(r, errMessageReservedTransformerEntityId).Send(w, r)
+		return
+	}
+
+	request := &SaveStremioWrapExtractorRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	value := stremio_transformer.StreamExtractorBlob(request.Value)
+	if errs := validateStremioWrapExtractor(value); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := stremio_wrap.ExtractorStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing == nil {
+		ErrorNotFound(r, "extractor not found").Send(w, r)
+		return
+	}
+
+	if existing.Value != value {
+		if err := stremio_wrap.ExtractorStore.Save(id, value); err != nil {
+			SendError(w, r, err)
+			return
+		}
+	}
+
+	item, err := stremio_wrap.ExtractorStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStremioWrapExtractorResponse(item))
+}
+
+func handleDeleteStremioWrapExtractor(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if stremio_wrap.IsBuiltInTransformerEntityId(id) {
+		ErrorForbidden(r, errMessageReservedTransformerEntityId).Send(w, r)
+		return
+	}
+
+	existing, err := stremio_wrap.ExtractorStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing == nil {
+		ErrorNotFound(r, "extractor not found").Send(w, r)
+		return
+	}
+
+	if err := stremio_wrap.ExtractorStore.Delete(id); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func handleGetStremioWrapExtractorVersions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	versions, err := stremio_wrap.ExtractorStore.ListVersions(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioWrapExtractorVersionResponse, len(versions))
+	for i := range versions {
+		version := &versions[i]
+		data[i] = StremioWrapExtractorVersionResponse{
+			Version:   version.Version,
+			Value:     string(version.Value),
+			CreatedAt: formatTransformerEntityTime(version.CreatedAt),
+		}
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type StremioWrapTemplateResponse struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsBuiltIn   bool   `json:"is_builtin"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+func toStremioWrapTemplateResponse(item *stremio_wrap.TransformerEntity[stremio_transformer.StreamTemplateBlob]) StremioWrapTemplateResponse {
+	return StremioWrapTemplateResponse{
+		Id:          item.Id,
+		Name:        item.Value.Name,
+		Description: item.Value.Description,
+		IsBuiltIn:   item.IsBuiltIn,
+		CreatedAt:   formatTransformerEntityTime(item.CreatedAt),
+		UpdatedAt:   formatTransformerEntityTime(item.UpdatedAt),
+	}
+}
+
+type StremioWrapTemplateVersionResponse struct {
+	Version     int64  `json:"version"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func handleGetStremioWrapTemplates(w http.ResponseWriter, r *http.Request) {
+	items, err := stremio_wrap.TemplateStore.List()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioWrapTemplateResponse, len(items))
+	for i := range items {
+		data[i] = toStremioWrapTemplateResponse(&items[i])
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type SaveStremioWrapTemplateRequest struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func validateStremioWrapTemplate(value stremio_transformer.StreamTemplateBlob) []Error {
+	errs := []Error{}
+	if value.IsEmpty() {
+		errs = append(errs, Error{
+			Location: "name",
+			Message:  "missing name and description",
+		})
+	} else if t, err := value.Parse(); err != nil {
+		location := "description"
+		if t.Name == nil {
+			location = "name"
+		}
+		errs = append(errs, Error{
+			Location: location,
+			Message:  err.Error(),
+		})
+	}
+	return errs
+}
+
+func handleCreateStremioWrapTemplate(w http.ResponseWriter, r *http.Request) {
+	request := &SaveStremioWrapTemplateRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	errs := []Error{}
+	if request.Id == "" {
+		errs = append(errs, Error{
+			Location: "id",
+			Message:  "missing id",
+		})
+	} else if stremio_wrap.IsBuiltInTransformerEntityId(request.Id) {
+		errs = append(errs, Error{
+			Location: "id",
+			Message:  errMessageReservedTransformerEntityId,
+		})
+	}
+	value := stremio_transformer.StreamTemplateBlob{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	errs = append(errs, validateStremioWrapTemplate(value)...)
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := stremio_wrap.TemplateStore.Get(request.Id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing != nil {
+		ErrorConflict(r, "template already exists").Send(w, r)
+		return
+	}
+
+	if err := stremio_wrap.TemplateStore.Save(request.Id, value); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	item, err := stremio_wrap.TemplateStore.Get(request.Id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 201, toStremioWrapTemplateResponse(item))
+}
+
+func handleGetStremioWrapTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	item, err := stremio_wrap.TemplateStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if item == nil {
+		ErrorNotFound(r, "template not found").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 200, toStremioWrapTemplateResponse(item))
+}
+
+func handleUpdateStremioWrapTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if stremio_wrap.IsBuiltInTransformerEntityId(id) {
+		ErrorForbidden(r, errMessageReservedTransformerEntityId).Send(w, r)
+		return
+	}
+
+	request := &SaveStremioWrapTemplateRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	value := stremio_transformer.StreamTemplateBlob{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	if errs := validateStremioWrapTemplate(value); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := stremio_wrap.TemplateStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing == nil {
+		ErrorNotFound(r, "template not found").Send(w, r)
+		return
+	}
+
+	if !existing.Value.Equal(value) {
+		if err := stremio_wrap.TemplateStore.Save(id, value); err != nil {
+			SendError(w, r, err)
+			return
+		}
+	}
+
+	item, err := stremio_wrap.TemplateStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStremioWrapTemplateResponse(item))
+}
+
+func handleDeleteStremioWrapTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if stremio_wrap.IsBuiltInTransformerEntityId(id) {
+		ErrorForbidden(r, errMessageReservedTransformerEntityId).Send(w, r)
+		return
+	}
+
+	existing, err := stremio_wrap.TemplateStore.Get(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing == nil {
+		ErrorNotFound(r, "template not found").Send(w, r)
+		return
+	}
+
+	if err := stremio_wrap.TemplateStore.Delete(id); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func handleGetStremioWrapTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	versions, err := stremio_wrap.TemplateStore.ListVersions(id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioWrapTemplateVersionResponse, len(versions))
+	for i := range versions {
+		version := &versions[i]
+		data[i] = StremioWrapTemplateVersionResponse{
+			Version:     version.Version,
+			Name:        version.Value.Name,
+			Description: version.Value.Description,
+			CreatedAt:   formatTransformerEntityTime(version.CreatedAt),
+		}
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type StremioWrapDryRunRequest struct {
+	ExtractorId string                                  `json:"extractor_id"`
+	Extractor   string                                  `json:"extractor"`
+	TemplateId  string                                  `json:"template_id"`
+	Template    *stremio_transformer.StreamTemplateBlob `json:"template"`
+	Type        string                                  `json:"type"`
+	Streams     []stremio.Stream                        `json:"streams"`
+}
+
+type StremioWrapDryRunItem struct {
+	Result *stremio_transformer.StreamExtractorResult `json:"result"`
+	Stream *stremio.Stream                            `json:"stream"`
+	Error  string                                     `json:"error,omitempty"`
+}
+
+const maxStremioWrapDryRunStreams = 100
+
+func handleStremioWrapDryRun(w http.ResponseWriter, r *http.Request) {
+	request := &StremioWrapDryRunRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	errs := []Error{}
+	if len(request.Streams) == 0 {
+		errs = append(errs, Error{
+			Location: "streams",
+			Message:  "missing streams",
+		})
+	} else if len(request.Streams) > maxStremioWrapDryRunStreams {
+		errs = append(errs, Error{
+			Location: "streams",
+			Message:  "too many streams",
+		})
+	}
+
+	extractorBlob := stremio_transformer.StreamExtractorBlob(request.Extractor)
+	if extractorBlob == "" && request.ExtractorId != "" {
+		item, err := stremio_wrap.ExtractorStore.Get(request.ExtractorId)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		if item == nil {
+			errs = append(errs, Error{
+				Location: "extractor_id",
+				Message:  "extractor not found",
+			})
+		} else {
+			extractorBlob = item.Value
+		}
+	}
+	if extractorBlob == "" && len(errs) == 0 {
+		errs = append(errs, Error{
+			Location: "extractor",
+			Message:  "missing extractor",
+		})
+	}
+	extractor, err := extractorBlob.Parse()
+	if err != nil {
+		errs = append(errs, Error{
+			Location: "extractor",
+			Message:  err.Error(),
+		})
+	}
+
+	templateBlob := stremio_transformer.StreamTemplateBlob{}
+	if request.Template != nil {
+		templateBlob = *request.Template
+	} else if request.TemplateId != "" {
+		item, err := stremio_wrap.TemplateStore.Get(request.TemplateId)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		if item == nil {
+			errs = append(errs, Error{
+				Location: "template_id",
+				Message:  "template not found",
+			})
+		} else {
+			templateBlob = item.Value
+		}
+	}
+	template, err := templateBlob.Parse()
+	if err != nil {
+		errs = append(errs, Error{
+			Location: "template",
+			Message:  err.Error(),
+		})
+	}
+
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	sType := request.Type
+	if sType == "" {
+		sType = "movie"
+	}
+
+	data := make([]StremioWrapDryRunItem, len(request.Streams))
+	for i := range request.Streams {
+		item := &data[i]
+		stream := request.Streams[i]
+		item.Result = extractor.Parse(&stream, sType)
+		if template.IsEmpty() || item.Result == nil {
+			continue
+		}
+		item.Stream, err = template.Execute(&stream, item.Result)
+		if err != nil {
+			item.Error = err.Error()
+		}
+	}
+
+	SendData(w, r, 200, data)
+}
+
+func AddStremioWrapEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/stremio/wrap/extractors", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioWrapExtractors(w, r)
+		case http.MethodPost:
+			handleCreateStremioWrapExtractor(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/wrap/extractors/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioWrapExtractor(w, r)
+		case http.MethodPut:
+			handleUpdateStremioWrapExtractor(w, r)
+		case http.MethodDelete:
+			handleDeleteStremioWrapExtractor(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/wrap/extractors/{id}/versions", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioWrapExtractorVersions(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+
+	router.HandleFunc("/stremio/wrap/templates", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioWrapTemplates(w, r)
+		case http.MethodPost:
+			handleCreateStremioWrapTemplate(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/wrap/templates/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioWrapTemplate(w, r)
+		case http.MethodPut:
+			handleUpdateStremioWrapTemplate(w, r)
+		case http.MethodDelete:
+			handleDeleteStremioWrapTemplate(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/stremio/wrap/templates/{id}/versions", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioWrapTemplateVersions(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+
+	router.HandleFunc("/stremio/wrap/dry-run", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleStremioWrapDryRun(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
@@ -0,0 +1,274 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	sync_stremio_simkl "github.com/MunifTanjim/stremthru/internal/sync/stremio_simkl"
+)
+
+type StremioSimklLinkResponse struct {
+	StremioAccountId string                        `json:"stremio_account_id"`
+	SimklAccountId   string                        `json:"simkl_account_id"`
+	SyncConfig       sync_stremio_simkl.SyncConfig `json:"sync_config"`
+	SyncState        sync_stremio_simkl.SyncState  `json:"sync_state"`
+	CreatedAt        string                        `json:"created_at"`
+	UpdatedAt        string                        `json:"updated_at"`
+}
+
+func toStremioSimklLinkResponse(item *sync_stremio_simkl.SyncStremioSimklLink) StremioSimklLinkResponse {
+	resp := StremioSimklLinkResponse{
+		StremioAccountId: item.StremioAccountId,
+		SimklAccountId:   item.SimklAccountId,
+		SyncConfig:       item.SyncConfig,
+		SyncState:        item.SyncState,
+		CreatedAt:        item.CAt.Format(time.RFC3339),
+		UpdatedAt:        item.UAt.Format(time.RFC3339),
+	}
+	resp.SyncConfig.Normalize()
+	return resp
+}
+
+func handleGetStremioSimklLinks(w http.ResponseWriter, r *http.Request) {
+	items, err := sync_stremio_simkl.GetAll()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StremioSimklLinkResponse, len(items))
+	for i, item := range items {
+		data[i] = toStremioSimklLinkResponse(&item)
+	}
+
+	SendData(w, r, 200, data)
+}
+
+func validateStremioSimklSyncConfig(sc *sync_stremio_simkl.SyncConfig) []Error {
+	sc.Normalize()
+
+	errs := []Error{}
+	if !sc.Watched.Direction.IsValid() {
+		errs = append(errs, Error{
+			Location: "sync_config.watched.dir",
+			Message:  "invalid sync direction",
+		})
+	}
+	return errs
+}
+
+type CreateStremioSimklLinkRequest struct {
+	StremioAccountId string                        `json:"stremio_account_id"`
+	SimklAccountId   string                        `json:"simkl_account_id"`
+	SyncConfig       sync_stremio_simkl.SyncConfig `json:"sync_config"`
+}
+
+func handleCreateStremioSimklLink(w http.ResponseWriter, r *http.Request) {
+	request := &CreateStremioSimklLinkRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	errs := []Error{}
+	if request.StremioAccountId == "" {
+		errs = append(errs, Error{
+			Location: "stremio_account_id",
+			Message:  "missing stremio_account_id",
+		})
+	}
+	if request.SimklAccountId == "" {
+		errs = append(errs, Error{
+			Location: "simkl_account_id",
+			Message:  "missing simkl_account_id",
+		})
+	}
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := sync_stremio_simkl.GetById(request.StremioAccountId, request.SimklAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing != nil {
+		ErrorBadRequest(r, "link already exists").Send(w, r)
+		return
+	}
+
+	if errs := validateStremioSimklSyncConfig(&request.SyncConfig); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	link, err := sync_stremio_simkl.Link(request.StremioAccountId, request.SimklAccountId, request.SyncConfig)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 201, toStremioSimklLinkResponse(link))
+}
+
+func handleGetStremioSimklLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, simklAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_simkl.GetById(stremioAccountId, simklAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	SendData(w, r, 200, toStremioSimklLinkResponse(link))
+}
+
+type UpdateStremioSimklAccountRequest struct {
+	SyncConfig sync_stremio_simkl.SyncConfig `json:"sync_config"`
+}
+
+func handleUpdateStremioSimklLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, simklAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	request := &UpdateStremioSimklAccountRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	link, err := sync_stremio_simkl.GetById(stremioAccountId, simklAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	if errs := validateStremioSimklSyncConfig(&request.SyncConfig); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	if err := sync_stremio_simkl.SetSyncConfig(stremioAccountId, simklAccountId, request.SyncConfig); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	link.SyncConfig = request.SyncConfig
+	SendData(w, r, 200, toStremioSimklLinkResponse(link))
+}
+
+func handleDeleteStremioSimklLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, simklAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_simkl.GetById(stremioAccountId, simklAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	if err := sync_stremio_simkl.Unlink(stremioAccountId, simklAccountId); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func handleSyncStremioSimklLink(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, simklAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_simkl.GetById(stremioAccountId, simklAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	// TODO: trigger sync immediately
+	SendData(w, r, 202, map[string]string{})
+}
+
+func handleResetStremioSimklLinkSyncState(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, simklAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_simkl.GetById(stremioAccountId, simklAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	link.SyncState.Watched.LastSyncedAt = nil
+
+	if err := sync_stremio_simkl.SetSyncState(
+		link.StremioAccountId,
+		link.SimklAccountId,
+		link.SyncState,
+	); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStremioSimklLinkResponse(link))
+}
+
+func AddSyncStremioSimklEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/sync/stremio-simkl/links", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioSimklLinks(w, r)
+		case http.MethodPost:
+			handleCreateStremioSimklLink(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-simkl/links/{account_id_pair}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioSimklLink(w, r)
+		case http.MethodPatch:
+			handleUpdateStremioSimklLink(w, r)
+		case http.MethodDelete:
+			handleDeleteStremioSimklLink(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-simkl/links/{account_id_pair}/sync", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleSyncStremioSimklLink(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-simkl/links/{account_id_pair}/reset-sync-state", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleResetStremioSimklLinkSyncState(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
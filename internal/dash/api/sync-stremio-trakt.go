@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/db"
 	sync_stremio_trakt "github.com/MunifTanjim/stremthru/internal/sync/stremio_trakt"
 )
 
@@ -26,6 +27,7 @@ func toStremioTraktLinkResponse(item *sync_stremio_trakt.SyncStremioTraktLink) S
 		CreatedAt:        item.CAt.Format(time.RFC3339),
 		UpdatedAt:        item.UAt.Format(time.RFC3339),
 	}
+	resp.SyncConfig.Normalize()
 	return resp
 }
 
@@ -44,6 +46,49 @@ func handleGetStremioTraktLinks(w http.ResponseWriter, r *http.Request) {
 	SendData(w, r, 200, data)
 }
 
+func validateStremioTraktSyncConfig(sc *sync_stremio_trakt.SyncConfig) []Error {
+	sc.Normalize()
+
+	errs := []Error{}
+	if !sc.Watched.Direction.IsValid() {
+		errs = append(errs, Error{
+			Location: "sync_config.watched.dir",
+			Message:  "invalid sync direction",
+		})
+	}
+	if !sc.Watchlist.Direction.IsValid() {
+		errs = append(errs, Error{
+			Location: "sync_config.watchlist.dir",
+			Message:  "invalid sync direction",
+		})
+	}
+	if !sc.Collection.Direction.IsValid() {
+		errs = append(errs, Error{
+			Location: "sync_config.collection.dir",
+			Message:  "invalid sync direction",
+		})
+	}
+	if !sc.Playback.Direction.IsValid() {
+		errs = append(errs, Error{
+			Location: "sync_config.playback.dir",
+			Message:  "invalid sync direction",
+		})
+	}
+	if !sc.Ratings.IsValid() {
+		errs = append(errs, Error{
+			Location: "sync_config.ratings.dir",
+			Message:  "invalid sync direction",
+		})
+	}
+	if !sc.Watchlist.Direction.IsDisabled() && !sc.Collection.Direction.IsDisabled() {
+		errs = append(errs, Error{
+			Location: "sync_config.collection.dir",
+			Message:  "only one of watchlist and collection can be synced with stremio library",
+		})
+	}
+	return errs
+}
+
 type CreateStremioTraktLinkRequest struct {
 	StremioAccountId string                        `json:"stremio_account_id"`
 	TraktAccountId   string                        `json:"trakt_account_id"`
@@ -85,8 +130,8 @@ func handleCreateStremioTraktLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !request.SyncConfig.Watched.Direction.IsValid() {
-		ErrorBadRequest(r, "invalid sync direction").Send(w, r)
+	if errs := validateStremioTraktSyncConfig(&request.SyncConfig); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
 		return
 	}
 
@@ -143,8 +188,8 @@ func handleUpdateStremioTraktLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !request.SyncConfig.Watched.Direction.IsValid() {
-		ErrorBadRequest(r, "invalid sync direction").Send(w, r)
+	if errs := validateStremioTraktSyncConfig(&request.SyncConfig); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
 		return
 	}
 
@@ -153,6 +198,14 @@ func handleUpdateStremioTraktLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if link.SyncConfig.Ratings.Push && !request.SyncConfig.Ratings.Push {
+		// never going to be pushed
+		if err := sync_stremio_trakt.DeletePendingRatings(stremioAccountId, traktAccountId); err != nil {
+			SendError(w, r, err)
+			return
+		}
+	}
+
 	link.SyncConfig = request.SyncConfig
 	SendData(w, r, 200, toStremioTraktLinkResponse(link))
 }
@@ -209,6 +262,10 @@ func handleResetStremioTraktLinkSyncState(w http.ResponseWriter, r *http.Request
 	}
 
 	link.SyncState.Watched.LastSyncedAt = nil
+	link.SyncState.Watchlist = sync_stremio_trakt.SyncStateList{}
+	link.SyncState.Collection = sync_stremio_trakt.SyncStateList{}
+	link.SyncState.Playback.LastSyncedAt = nil
+	link.SyncState.Ratings.LastSyncedAt = nil
 
 	if err := sync_stremio_trakt.SetSyncState(
 		link.StremioAccountId,
@@ -222,6 +279,170 @@ func handleResetStremioTraktLinkSyncState(w http.ResponseWriter, r *http.Request
 	SendData(w, r, 200, toStremioTraktLinkResponse(link))
 }
 
+type StremioTraktRatingResponse struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	Rating    int    `json:"rating"`
+	RatedAt   string `json:"rated_at"`
+	Pending   bool   `json:"pending"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toStremioTraktRatingResponse(item *sync_stremio_trakt.SyncStremioTraktRating) StremioTraktRatingResponse {
+	return StremioTraktRatingResponse{
+		Id:        item.Id,
+		Type:      item.Type,
+		Rating:    item.Rating,
+		RatedAt:   item.RatedAt.Format(time.RFC3339),
+		Pending:   item.Pending,
+		UpdatedAt: item.UAt.Format(time.RFC3339),
+	}
+}
+
+func handleGetStremioTraktLinkRatings(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, traktAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+
+	link, err := sync_stremio_trakt.GetById(stremioAccountId, traktAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	items, err := sync_stremio_trakt.GetRatings(stremioAccountId, traktAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := []StremioTraktRatingResponse{}
+	for i := range items {
+		// pending removal
+		if items[i].Rating == 0 {
+			continue
+		}
+		data = append(data, toStremioTraktRatingResponse(&items[i]))
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type SetStremioTraktLinkRatingRequest struct {
+	Type   string `json:"type"`
+	Rating int    `json:"rating"`
+}
+
+func handleSetStremioTraktLinkRating(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, traktAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+	id := r.PathValue("id")
+
+	request := &SetStremioTraktLinkRatingRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	errs := []Error{}
+	if !strings.HasPrefix(id, "tt") {
+		errs = append(errs, Error{
+			Location: "id",
+			Message:  "invalid imdb id",
+		})
+	}
+	if request.Type != "movie" && request.Type != "series" {
+		errs = append(errs, Error{
+			Location: "type",
+			Message:  "invalid type",
+		})
+	}
+	if request.Rating < 1 || request.Rating > 10 {
+		errs = append(errs, Error{
+			Location: "rating",
+			Message:  "rating must be between 1 and 10",
+		})
+	}
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	link, err := sync_stremio_trakt.GetById(stremioAccountId, traktAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+	if !link.SyncConfig.Ratings.Push {
+		ErrorBadRequest(r, "ratings push is disabled").Send(w, r)
+		return
+	}
+
+	now := time.Now()
+	rating := sync_stremio_trakt.SyncStremioTraktRating{
+		StremioAccountId: stremioAccountId,
+		TraktAccountId:   traktAccountId,
+		Id:               id,
+		Type:             request.Type,
+		Rating:           request.Rating,
+		RatedAt:          db.Timestamp{Time: now},
+		Pending:          true,
+		CAt:              db.Timestamp{Time: now},
+		UAt:              db.Timestamp{Time: now},
+	}
+	if err := sync_stremio_trakt.UpsertRatings([]sync_stremio_trakt.SyncStremioTraktRating{rating}); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStremioTraktRatingResponse(&rating))
+}
+
+func handleDeleteStremioTraktLinkRating(w http.ResponseWriter, r *http.Request) {
+	stremioAccountId, traktAccountId := parseAccountIdPair(r.PathValue("account_id_pair"))
+	id := r.PathValue("id")
+
+	link, err := sync_stremio_trakt.GetById(stremioAccountId, traktAccountId)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if link == nil {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	rating, err := sync_stremio_trakt.GetRating(stremioAccountId, traktAccountId, id)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if rating == nil || rating.Rating == 0 {
+		ErrorNotFound(r, "").Send(w, r)
+		return
+	}
+
+	if link.SyncConfig.Ratings.Push {
+		// removed from trakt on next sync
+		rating.Rating = 0
+		rating.Pending = true
+		err = sync_stremio_trakt.UpsertRatings([]sync_stremio_trakt.SyncStremioTraktRating{*rating})
+	} else {
+		err = sync_stremio_trakt.DeleteRatings(stremioAccountId, traktAccountId, []string{id})
+	}
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
 func AddSyncStremioTraktEndpoints(router *http.ServeMux) {
 	authed := EnsureAuthed
 
@@ -263,4 +484,22 @@ func AddSyncStremioTraktEndpoints(router *http.ServeMux) {
 			ErrorMethodNotAllowed(r).Send(w, r)
 		}
 	}))
+	router.HandleFunc("/sync/stremio-trakt/links/{account_id_pair}/ratings", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStremioTraktLinkRatings(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/sync/stremio-trakt/links/{account_id_pair}/ratings/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			handleSetStremioTraktLinkRating(w, r)
+		case http.MethodDelete:
+			handleDeleteStremioTraktLinkRating(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
 }
@@ -0,0 +1,297 @@
+package dash_api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	store_retention "github.com/MunifTanjim/stremthru/internal/store/retention"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+type StoreRetentionPolicyResponse struct {
+	Id                 string `json:"id"`
+	Username           string `json:"username"`
+	Store              string `json:"store"`
+	Enabled            bool   `json:"enabled"`
+	DryRun             bool   `json:"dry_run"`
+	OlderThanDays      int    `json:"older_than_days"`
+	MaxItems           int    `json:"max_items"`
+	RemoveFailed       bool   `json:"remove_failed"`
+	OnlyAdded          bool   `json:"only_added"`
+	HasStoreCredential bool   `json:"has_store_credential"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+}
+
+func hasStoreRetentionStoreCredential(username string, storeName store.StoreName) bool {
+	return config.StoreAuthToken.GetToken(username, string(storeName)) != ""
+}
+
+func toStoreRetentionPolicyResponse(item *store_retention.Policy) StoreRetentionPolicyResponse {
+	return StoreRetentionPolicyResponse{
+		Id:                 item.GetId(),
+		Username:           item.Username,
+		Store:              string(item.Store),
+		Enabled:            item.Enabled,
+		DryRun:             item.DryRun,
+		OlderThanDays:      item.OlderThanDays,
+		MaxItems:           item.MaxItems,
+		RemoveFailed:       item.RemoveFailed,
+		OnlyAdded:          item.OnlyAdded,
+		HasStoreCredential: hasStoreRetentionStoreCredential(item.Username, item.Store),
+		CreatedAt:          item.CAt.Format(time.RFC3339),
+		UpdatedAt:          item.UAt.Format(time.RFC3339),
+	}
+}
+
+func handleGetStoreRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	items, err := store_retention.GetAllPolicies()
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	data := make([]StoreRetentionPolicyResponse, len(items))
+	for i := range items {
+		data[i] = toStoreRetentionPolicyResponse(&items[i])
+	}
+
+	SendData(w, r, 200, data)
+}
+
+type SaveStoreRetentionPolicyRequest struct {
+	Username      string `json:"username"`
+	Store         string `json:"store"`
+	Enabled       *bool  `json:"enabled"`
+	DryRun        *bool  `json:"dry_run"`
+	OlderThanDays *int   `json:"older_than_days"`
+	MaxItems      *int   `json:"max_items"`
+	RemoveFailed  *bool  `json:"remove_failed"`
+	OnlyAdded     *bool  `json:"only_added"`
+}
+
+func (req *SaveStoreRetentionPolicyRequest) apply(policy *store_retention.Policy) []Error {
+	errs := []Error{}
+	if req.Enabled != nil {
+		policy.Enabled = *req.Enabled
+	}
+	if req.DryRun != nil {
+		policy.DryRun = *req.DryRun
+	}
+	if req.OlderThanDays != nil {
+		if *req.OlderThanDays < 0 {
+			errs = append(errs, Error{
+				Location: "older_than_days",
+				Message:  "must not be negative",
+			})
+		}
+		policy.OlderThanDays = *req.OlderThanDays
+	}
+	if req.MaxItems != nil {
+		if *req.MaxItems < 0 {
+			errs = append(errs, Error{
+				Location: "max_items",
+				Message:  "must not be negative",
+			})
+		}
+		policy.MaxItems = *req.MaxItems
+	}
+	if req.RemoveFailed != nil {
+		policy.RemoveFailed = *req.RemoveFailed
+	}
+	if req.OnlyAdded != nil {
+		policy.OnlyAdded = *req.OnlyAdded
+	}
+	return errs
+}
+
+func handleCreateStoreRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	request := &SaveStoreRetentionPolicyRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	policy := &store_retention.Policy{
+		Username:  request.Username,
+		Store:     store.StoreName(request.Store),
+		DryRun:    true,
+		OnlyAdded: true,
+	}
+
+	errs := []Error{}
+	if policy.Username == "" {
+		errs = append(errs, Error{
+			Location: "username",
+			Message:  "missing username",
+		})
+	}
+	if !policy.Store.IsValid() {
+		errs = append(errs, Error{
+			Location: "store",
+			Message:  "invalid store",
+		})
+	} else if policy.Username != "" && !hasStoreRetentionStoreCredential(policy.Username, policy.Store) {
+		errs = append(errs, Error{
+			Location: "store",
+			Message:  "missing store credential for user",
+		})
+	}
+	errs = append(errs, request.apply(policy)...)
+	if len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	existing, err := store_retention.GetPolicy(policy.Username, policy.Store)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	if existing != nil {
+		ErrorConflict(r, "store retention policy already exists").Send(w, r)
+		return
+	}
+
+	if err := policy.Upsert(); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	policy, err = store_retention.GetPolicy(policy.Username, policy.Store)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 201, toStoreRetentionPolicyResponse(policy))
+}
+
+func getStoreRetentionPolicy(w http.ResponseWriter, r *http.Request) *store_retention.Policy {
+	username, storeName, err := store_retention.ParseId(r.PathValue("id"))
+	if err != nil {
+		ErrorBadRequest(r, err.Error()).Send(w, r)
+		return nil
+	}
+
+	policy, err := store_retention.GetPolicy(username, storeName)
+	if err != nil {
+		SendError(w, r, err)
+		return nil
+	}
+	if policy == nil {
+		ErrorNotFound(r, "store retention policy not found").Send(w, r)
+		return nil
+	}
+	return policy
+}
+
+func handleGetStoreRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	policy := getStoreRetentionPolicy(w, r)
+	if policy == nil {
+		return
+	}
+
+	SendData(w, r, 200, toStoreRetentionPolicyResponse(policy))
+}
+
+func handleUpdateStoreRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	request := &SaveStoreRetentionPolicyRequest{}
+	if err := ReadRequestBodyJSON(r, request); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	policy := getStoreRetentionPolicy(w, r)
+	if policy == nil {
+		return
+	}
+
+	if errs := request.apply(policy); len(errs) > 0 {
+		ErrorBadRequest(r, "").Append(errs...).Send(w, r)
+		return
+	}
+
+	if err := policy.Upsert(); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	policy, err := store_retention.GetPolicy(policy.Username, policy.Store)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 200, toStoreRetentionPolicyResponse(policy))
+}
+
+func handleDeleteStoreRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	policy := getStoreRetentionPolicy(w, r)
+	if policy == nil {
+		return
+	}
+
+	if err := store_retention.DeletePolicy(policy.Username, policy.Store); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	SendData(w, r, 204, nil)
+}
+
+func handlePreviewStoreRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	policy := getStoreRetentionPolicy(w, r)
+	if policy == nil {
+		return
+	}
+
+	storeToken := config.StoreAuthToken.GetToken(policy.Username, string(policy.Store))
+	s := shared.GetStore(string(policy.Store))
+	if storeToken == "" || s == nil {
+		ErrorBadRequest(r, "missing store credential for user").Send(w, r)
+		return
+	}
+
+	policy.DryRun = true
+	result := policy.Apply(s, storeToken)
+
+	SendData(w, r, 200, result)
+}
+
+func AddVaultStoreRetentionEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/vault/store/retention-policies", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStoreRetentionPolicies(w, r)
+		case http.MethodPost:
+			handleCreateStoreRetentionPolicy(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/vault/store/retention-policies/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handleGetStoreRetentionPolicy(w, r)
+		case http.MethodPatch:
+			handleUpdateStoreRetentionPolicy(w, r)
+		case http.MethodDelete:
+			handleDeleteStoreRetentionPolicy(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+	router.HandleFunc("/vault/store/retention-policies/{id}/preview", authed(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handlePreviewStoreRetentionPolicy(w, r)
+		default:
+			ErrorMethodNotAllowed(r).Send(w, r)
+		}
+	}))
+}
@@ -83,6 +83,13 @@ func handleCreateTorznabIndexer(w http.ResponseWriter, r *http.Request) {
 	if indexerType == "" {
 		indexerType = torznab_indexer.IndexerTypeJackett
 	}
+	if !indexerType.IsValid() {
+		ErrorBadRequest(r, "").Append(Error{
+			Location: "type",
+			Message:  "invalid type",
+		}).Send(w, r)
+		return
+	}
 
 	indexer, err := torznab_indexer.NewTorznabIndexer(indexerType, request.URL, request.APIKey)
 	if err != nil {
@@ -0,0 +1,31 @@
+package dash_api
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/internal/worker/worker_queue"
+)
+
+type ReEncryptVaultResponse struct {
+	WorkerId string `json:"worker_id"`
+}
+
+func handleReEncryptVault(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodPost) {
+		ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	worker_queue.VaultReEncrypterQueue.Queue(worker_queue.VaultReEncrypterQueueItem{})
+
+	SendData(w, r, 202, ReEncryptVaultResponse{
+		WorkerId: "reencrypt-vault",
+	})
+}
+
+func AddVaultEndpoints(router *http.ServeMux) {
+	authed := EnsureAuthed
+
+	router.HandleFunc("/vault/reencrypt", authed(handleReEncryptVault))
+}
@@ -35,15 +35,26 @@ func AddEndpoints(mux *http.ServeMux) {
 	dash_api.AddIMDBEndpoints(router)
 	dash_api.AddWorkerEndpoints(router)
 	dash_api.AddTorznabIndexerSyncInfoEndpoints(router)
+	dash_api.AddContentProxyEndpoints(router)
+
+	if config.Feature.IsEnabled(config.FeatureStremioWrap) {
+		dash_api.AddStremioWrapEndpoints(router)
+	}
 
 	if config.Feature.HasVault() {
+		dash_api.AddVaultEndpoints(router)
 		dash_api.AddVaultStremioEndpoints(router)
 		dash_api.AddVaultTraktEndpoints(router)
+		dash_api.AddVaultSimklEndpoints(router)
 		dash_api.AddVaultTorznabEndpoints(router)
+		dash_api.AddVaultStoreRetentionEndpoints(router)
 		dash_api.AddSyncStremioStremioEndpoints(router)
 		if config.Integration.Trakt.IsEnabled() {
 			dash_api.AddSyncStremioTraktEndpoints(router)
 		}
+		if config.Integration.Simkl.IsEnabled() {
+			dash_api.AddSyncStremioSimklEndpoints(router)
+		}
 	}
 
 	mux.Handle("/dash/api/", http.StripPrefix("/dash/api", dash_api.WithMiddleware(commonMiddleware)(router.ServeHTTP)))
@@ -24,11 +24,12 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 type HealthDebugDataIP struct {
-	Client         string            `json:"client"`
-	Exposed        map[string]string `json:"exposed"`
-	Machine        string            `json:"machine"`
-	Tunnel         map[string]string `json:"tunnel"`
-	RequestHeaders map[string]string `json:"request_headers"`
+	Client         string                    `json:"client"`
+	Exposed        map[string]string         `json:"exposed"`
+	Machine        string                    `json:"machine"`
+	Tunnel         map[string]string         `json:"tunnel"`
+	TunnelExits    []config.TunnelExitHealth `json:"tunnel_exits"`
+	RequestHeaders map[string]string         `json:"request_headers"`
 }
 
 type HealthDebugDataStore struct {
@@ -121,6 +122,7 @@ func handleHealthDebug(w http.ResponseWriter, r *http.Request) {
 			Exposed:        exposed,
 			Machine:        machineIp,
 			Tunnel:         tunnel,
+			TunnelExits:    config.Tunnel.GetExitHealths(),
 			RequestHeaders: core.GetRequestIPHeaders(r),
 		}
 	}
@@ -130,4 +132,5 @@ func handleHealthDebug(w http.ResponseWriter, r *http.Request) {
 
 func AddHealthEndpoints(mux *http.ServeMux) {
 	mux.HandleFunc("/resolver/health", handleHealth)
+	mux.HandleFunc("/resolver/health/__debug__", StoreMiddleware(ProxyAuthContext)(handleHealthDebug))
 }
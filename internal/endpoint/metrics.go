@@ -0,0 +1,23 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+)
+
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	metrics.Handler().ServeHTTP(w, r)
+}
+
+func AddMetricsEndpoints(mux *http.ServeMux) {
+	withAdminAuth := shared.Middleware(AdminAuthed)
+
+	mux.HandleFunc("/metrics", withAdminAuth(handleMetrics))
+}
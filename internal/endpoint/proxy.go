@@ -8,9 +8,13 @@ import (
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/content_proxy"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
+	"github.com/MunifTanjim/stremthru/internal/torrent_engine"
 	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
@@ -30,38 +34,53 @@ func handleProxyLinkAccess(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, link, headers, tunnelType, err := shared.UnwrapProxyLinkToken(encodedToken)
+	proxyLink, err := shared.ResolveProxyLinkToken(encodedToken, core.GetRequestIP(r))
 	if err != nil {
 		SendError(w, r, err)
 		return
 	}
+	user := proxyLink.User
 
-	if headers != nil {
-		for k, v := range headers {
+	// path after the token, as requested, for prefix links
+	_, path, _ := strings.Cut(strings.TrimPrefix(r.URL.EscapedPath(), "/v0/proxy/"), "/")
+	link, err := proxyLink.GetURL(path, r.URL.RawQuery)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if proxyLink.Headers != nil {
+		for k, v := range proxyLink.Headers {
 			r.Header.Set(k, v)
 		}
 	}
 
+	if user != "" {
+		r = r.WithContext(request.WithTunnelStickyKey(r.Context(), user))
+	}
 	if isGetReq && user != "" {
-		cpStore := contentProxyConnectionStore.WithScope(user)
-
-		if limit := config.ContentProxyConnectionLimit.Get(user); limit > 0 {
-			activeConnectionCount, err := cpStore.Count()
-			if err != nil {
-				ctx.Log.Error("[proxy] failed to count connections", "error", err)
-			} else if activeConnectionCount >= limit {
-				store_video.Redirect(store_video.StoreVideoNameContentProxyLimitReached, w, r)
-				return
-			}
-		}
-
-		if err := cpStore.Set(ctx.RequestId, contentProxyConnection{IP: core.GetRequestIP(r), Link: link}); err != nil {
+		lease, err := content_proxy.Acquire(r.Context(), content_proxy.Connection{
+			Id:   ctx.RequestId,
+			User: user,
+			IP:   core.GetRequestIP(r),
+			Link: link,
+		}, config.ContentProxyConnectionLimit.Get(user), config.ContentProxyBandwidthLimit.Get(user))
+		if err != nil {
 			ctx.Log.Error("[proxy] failed to record connection", "error", err)
+		} else if lease == nil {
+			store_video.Redirect(store_video.StoreVideoNameContentProxyLimitReached, w, r)
+			return
 		} else {
-			defer cpStore.Del(ctx.RequestId)
+			metrics.IncContentProxyConnection()
+			defer func() {
+				lease.Release()
+				metrics.DecContentProxyConnection()
+			}()
+			r = r.WithContext(lease.Context())
+			w = lease.Writer(w)
 		}
 	}
-	bytesWritten, err := shared.ProxyResponse(w, r, link, tunnelType)
+	bytesWritten, err := shared.ProxyLinkResponse(w, r, proxyLink, link)
 	ctx.Log.Info("[proxy] connection closed", "user", user, "size", util.ToSize(bytesWritten), "error", err)
 }
 
@@ -104,6 +123,13 @@ func handleProxifyLinks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for _, link := range links {
+		if torrent_engine.IsMagnetLink(link) {
+			shared.ErrorBadRequest(r, "magnet links can not be proxified").Send(w, r)
+			return
+		}
+	}
+
 	shouldRedirect := isGetReq && r.Form.Get("redirect") != ""
 
 	if shouldRedirect && count > 1 {
@@ -127,6 +153,15 @@ func handleProxifyLinks(w http.ResponseWriter, r *http.Request) {
 		expiresIn = exp
 	}
 
+	bindIP := r.Form.Get("bind_ip")
+	if bindIP == "auto" {
+		bindIP = core.GetRequestIP(r)
+	}
+	if _, err := shared.ParseProxyLinkIPBinding(bindIP); err != nil {
+		shared.ErrorBadRequest(r, "invalid bind_ip").Send(w, r)
+		return
+	}
+
 	shouldEncrypt := r.URL.Query().Get("token") == ""
 	if !shouldEncrypt {
 		ctx.RedactURLQueryParams(r, "token")
@@ -152,7 +187,7 @@ func handleProxifyLinks(w http.ResponseWriter, r *http.Request) {
 			reqHeadersByBlob[reqHeadersBlob] = reqHeaders
 		}
 		filename := r.Form.Get("filename[" + idx + "]")
-		proxyLink, err := shared.CreateProxyLink(r, link, reqHeaders, config.TUNNEL_TYPE_AUTO, expiresIn, user, password, shouldEncrypt, filename)
+		proxyLink, err := shared.CreateBoundProxyLink(r, link, reqHeaders, config.TUNNEL_TYPE_AUTO, expiresIn, user, password, shouldEncrypt, filename, bindIP)
 		if err != nil {
 			SendError(w, r, err)
 			return
@@ -173,10 +208,89 @@ func handleProxifyLinks(w http.ResponseWriter, r *http.Request) {
 	SendResponse(w, r, 200, data, nil)
 }
 
+type revokeProxyLinksData struct {
+	User              string `json:"user"`
+	Generation        int    `json:"generation"`
+	KilledConnections int    `json:"killed_connections"`
+}
+
+func handleRevokeProxyLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := server.GetReqCtx(r)
+
+	if !shared.IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	token, _ := extractProxyAuthToken(r, false)
+	auth, err := core.ParseBasicAuth(token)
+	if err != nil {
+		w.Header().Add(server.HEADER_STREMTHRU_AUTHENTICATE, "Basic")
+		shared.ErrorUnauthorized(r).Send(w, r)
+		return
+	}
+	isAdmin := auth.Password != "" && config.AdminPassword.GetPassword(auth.Username) == auth.Password
+	isUser := auth.Password != "" && config.ProxyAuthPassword.GetPassword(auth.Username) == auth.Password
+	if !isAdmin && !isUser {
+		w.Header().Add(server.HEADER_STREMTHRU_AUTHENTICATE, "Basic")
+		shared.ErrorForbidden(r).Send(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		shared.ErrorBadRequest(r, "failed to parse data").Send(w, r)
+		return
+	}
+
+	user := auth.Username
+	if targetUser := r.PostForm.Get("user"); targetUser != "" && targetUser != user {
+		if !isAdmin {
+			shared.ErrorForbidden(r).Send(w, r)
+			return
+		}
+		user = targetUser
+	}
+	if config.ProxyAuthPassword.GetPassword(user) == "" {
+		shared.ErrorBadRequest(r, "unknown user").Send(w, r)
+		return
+	}
+
+	gen, err := shared.RevokeProxyLinks(user)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	killedCount := 0
+	if conns, err := content_proxy.List(); err != nil {
+		ctx.Log.Error("[proxy] failed to list connections", "error", err)
+	} else {
+		for i := range conns {
+			if conns[i].User != user {
+				continue
+			}
+			if killed, err := content_proxy.Kill(conns[i].Id); err != nil {
+				ctx.Log.Error("[proxy] failed to kill connection", "error", err, "id", conns[i].Id)
+			} else if killed {
+				killedCount++
+			}
+		}
+	}
+
+	ctx.Log.Info("[proxy] revoked links", "user", user, "generation", gen, "killed_connections", killedCount)
+
+	SendResponse(w, r, 200, revokeProxyLinksData{
+		User:              user,
+		Generation:        gen,
+		KilledConnections: killedCount,
+	}, nil)
+}
+
 func AddProxyEndpoints(mux *http.ServeMux) {
 	withCors := shared.Middleware(shared.EnableCORS)
 
 	mux.HandleFunc("/v0/proxy", withCors(handleProxifyLinks))
+	mux.HandleFunc("/v0/proxy/revoke", withCors(handleRevokeProxyLinks))
 	mux.HandleFunc("/v0/proxy/{token}", withCors(handleProxyLinkAccess))
-	mux.HandleFunc("/v0/proxy/{token}/{filename}", withCors(handleProxyLinkAccess))
+	mux.HandleFunc("/v0/proxy/{token}/{path...}", withCors(handleProxyLinkAccess))
 }
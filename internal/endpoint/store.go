@@ -8,7 +8,6 @@ import (
 
 	"github.com/MunifTanjim/stremthru/internal/buddy"
 	"github.com/MunifTanjim/stremthru/internal/context"
-	"github.com/MunifTanjim/stremthru/internal/kv"
 	"github.com/MunifTanjim/stremthru/internal/peer_token"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
@@ -139,7 +138,14 @@ func handleStoreMagnetsCheck(w http.ResponseWriter, r *http.Request) {
 	sid := queryParams.Get("sid")
 
 	ctx := context.GetStoreContext(r)
-	data, err := checkMagnet(ctx, magnets, sid, queryParams.Get("local_only") != "")
+	localOnly := queryParams.Get("local_only") != ""
+	if ctxs := getMultiStoreContexts(r, ctx); ctxs != nil {
+		data, err := checkMagnetMulti(ctxs, magnets, sid, localOnly, rCtx.Log)
+		SendResponse(w, r, 200, data, err)
+		return
+	}
+
+	data, err := checkMagnet(ctx, magnets, sid, localOnly)
 	if err == nil && data != nil {
 		for _, item := range data.Items {
 			item.Hash = strings.ToLower(item.Hash)
@@ -223,6 +229,22 @@ func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx := context.GetStoreContext(r)
+	ctxs := getMultiStoreContexts(r, ctx)
+
+	var mData *MultiStoreAddMagnetData
+	add := func(magnet string, torrent *multipart.FileHeader) (*store.AddMagnetData, error) {
+		if ctxs == nil {
+			return addMagnet(ctx, magnet, torrent)
+		}
+		var err error
+		mData, err = addMagnetMulti(ctxs, magnet, torrent, server.GetReqCtx(r).Log)
+		if err != nil {
+			return nil, err
+		}
+		return mData.AddMagnetData, nil
+	}
+
 	var data *store.AddMagnetData
 	var err error
 	contentType := r.Header.Get("Content-Type")
@@ -239,17 +261,15 @@ func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		ctx := context.GetStoreContext(r)
-
 		if payload.Magnet != "" {
-			data, err = addMagnet(ctx, payload.Magnet, nil)
+			data, err = add(payload.Magnet, nil)
 		} else if payload.Torrent != "" {
-			fileHeader, err := shared.FetchTorrentFile(payload.Torrent, 1024*1024)
-			if err != nil {
-				shared.ErrorBadRequest(r, "unable to fetch torrent file").WithCause(err).Send(w, r)
+			fileHeader, fetchErr := shared.FetchTorrentFile(payload.Torrent, 1024*1024)
+			if fetchErr != nil {
+				shared.ErrorBadRequest(r, "unable to fetch torrent file").WithCause(fetchErr).Send(w, r)
 				return
 			}
-			data, err = addMagnet(ctx, "", fileHeader)
+			data, err = add("", fileHeader)
 		}
 
 	case strings.Contains(contentType, "multipart/form-data"):
@@ -273,8 +293,7 @@ func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 			fileHeader = fileHeaders[0]
 		}
 
-		ctx := context.GetStoreContext(r)
-		data, err = addMagnet(ctx, "", fileHeader)
+		data, err = add("", fileHeader)
 
 	default:
 		shared.ErrorUnsupportedMediaType(r).Send(w, r)
@@ -287,6 +306,10 @@ func handleStoreMagnetAdd(w http.ResponseWriter, r *http.Request) {
 			data.Files = []store.MagnetFile{}
 		}
 	}
+	if mData != nil {
+		SendResponse(w, r, 201, mData, err)
+		return
+	}
 	SendResponse(w, r, 201, data, err)
 }
 
@@ -377,7 +400,8 @@ func handleStoreMagnet(w http.ResponseWriter, r *http.Request) {
 }
 
 type GenerateLinkPayload struct {
-	Link string `json:"link"`
+	Link  string          `json:"link"`
+	Store store.StoreName `json:"store,omitempty"` // multi store mode
 }
 
 func handleStoreLinkGenerate(w http.ResponseWriter, r *http.Request) {
@@ -394,19 +418,16 @@ func handleStoreLinkGenerate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := context.GetStoreContext(r)
+	if ctxs := getMultiStoreContexts(r, ctx); ctxs != nil {
+		data, err := generateLinkMulti(r, ctxs, payload.Link, payload.Store, server.GetReqCtx(r).Log)
+		SendResponse(w, r, 200, data, err)
+		return
+	}
+
 	link, err := shared.GenerateStremThruLink(r, ctx, payload.Link)
 	SendResponse(w, r, 200, link, err)
 }
 
-type contentProxyConnection struct {
-	IP   string `json:"ip"`
-	Link string `json:"link"`
-}
-
-var contentProxyConnectionStore = kv.NewKVStore[contentProxyConnection](&kv.KVStoreConfig{
-	Type: "cproxyconn",
-})
-
 func handleStatic(w http.ResponseWriter, r *http.Request) {
 	if !shared.IsMethod(r, http.MethodGet) && !shared.IsMethod(r, http.MethodHead) {
 		shared.ErrorMethodNotAllowed(r).Send(w, r)
@@ -430,5 +451,14 @@ func AddStoreEndpoints(mux *http.ServeMux) {
 	mux.HandleFunc("/resolver/magnets/{magnetId}", withStore(handleStoreMagnet))
 	mux.HandleFunc("/resolver/link/generate", withStore(handleStoreLinkGenerate))
 
+	mux.HandleFunc("/resolver/newz", withStore(handleStoreNewz))
+	mux.HandleFunc("/resolver/newz/check", withStore(handleStoreNewzCheck))
+	mux.HandleFunc("/resolver/newz/{newzId}", withStore(handleStoreNewzItem))
+	mux.HandleFunc("/resolver/newz/link/generate", withStore(handleStoreNewzLinkGenerate))
+
+	mux.HandleFunc("/resolver/webdl", withStore(handleStoreWebDL))
+	mux.HandleFunc("/resolver/webdl/{webdlId}", withStore(handleStoreWebDLItem))
+	mux.HandleFunc("/resolver/webdl/link/generate", withStore(handleStoreWebDLLinkGenerate))
+
 	mux.HandleFunc("/resolver/_/static/{video}", withCors(handleStatic))
 }
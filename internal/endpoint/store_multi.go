@@ -0,0 +1,204 @@
+package endpoint
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+type StoreMode string
+
+const (
+	StoreModeSingle StoreMode = "single"
+	StoreModeMulti  StoreMode = "multi"
+)
+
+// Multi store mode is opted in with the `X-StremThru-Store-Mode: multi` header.
+// It is only available for proxy authorized users, and uses every store
+// configured for the user in `STREMTHRU_STORE_AUTH`, starting with the store
+// selected for the request followed by the rest in preference order.
+func getMultiStoreContexts(r *http.Request, ctx *context.StoreContext) []*context.StoreContext {
+	if StoreMode(r.Header.Get(server.HEADER_STREMTHRU_STORE_MODE)) != StoreModeMulti || !ctx.IsProxyAuthorized {
+		return nil
+	}
+
+	ctxs := []*context.StoreContext{ctx}
+	for _, name := range config.StoreAuthToken.ListStores(ctx.ProxyAuthUser) {
+		if name == string(ctx.Store.GetName()) {
+			continue
+		}
+		s := shared.GetStore(name)
+		if s == nil {
+			continue
+		}
+		token := config.StoreAuthToken.GetToken(ctx.ProxyAuthUser, name)
+		if token == "" {
+			continue
+		}
+		sCtx := *ctx
+		sCtx.Store = s
+		sCtx.StoreAuthToken = token
+		sCtx.ClientIP = shared.GetClientIP(r, &sCtx)
+		ctxs = append(ctxs, &sCtx)
+	}
+
+	if len(ctxs) < 2 {
+		return nil
+	}
+	return ctxs
+}
+
+// errors caused by the request itself, trying another store will not help.
+func shouldFailoverStore(err error) bool {
+	var stErr core.StremThruError
+	if !errors.As(err, &stErr) {
+		return true
+	}
+	e := stErr.GetError()
+	if e.Code == core.ErrorCodeStoreMagnetInvalid {
+		return false
+	}
+	if e.StoreName == "" && e.StatusCode == http.StatusBadRequest {
+		return false
+	}
+	return true
+}
+
+type MultiStoreCheckMagnetDataItem struct {
+	store.CheckMagnetDataItem
+	Store  store.StoreName                        `json:"store"`
+	Stores map[store.StoreName]store.MagnetStatus `json:"stores"`
+}
+
+type MultiStoreCheckMagnetData struct {
+	Items []MultiStoreCheckMagnetDataItem `json:"items"`
+}
+
+func checkMagnetMulti(ctxs []*context.StoreContext, magnets []string, sid string, localOnly bool, log *logger.Logger) (*MultiStoreCheckMagnetData, error) {
+	type result struct {
+		data *store.CheckMagnetData
+		err  error
+	}
+
+	results := make([]result, len(ctxs))
+	var wg sync.WaitGroup
+	for i, ctx := range ctxs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := checkMagnet(ctx, magnets, sid, localOnly)
+			results[i] = result{data, err}
+		}()
+	}
+	wg.Wait()
+
+	data := &MultiStoreCheckMagnetData{
+		Items: []MultiStoreCheckMagnetDataItem{},
+	}
+	itemIdxByHash := map[string]int{}
+
+	var firstErr error
+	for i, res := range results {
+		storeName := ctxs[i].Store.GetName()
+		if res.err != nil {
+			log.Warn("multi store: failed to check magnet", "error", res.err, "store.name", storeName)
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			continue
+		}
+		for _, item := range res.data.Items {
+			hash := strings.ToLower(item.Hash)
+			item.Hash = hash
+			idx, found := itemIdxByHash[hash]
+			if !found {
+				itemIdxByHash[hash] = len(data.Items)
+				data.Items = append(data.Items, MultiStoreCheckMagnetDataItem{
+					CheckMagnetDataItem: item,
+					Store:               storeName,
+					Stores:              map[store.StoreName]store.MagnetStatus{storeName: item.Status},
+				})
+				continue
+			}
+			mItem := &data.Items[idx]
+			mItem.Stores[storeName] = item.Status
+			if mItem.Status != store.MagnetStatusCached && item.Status == store.MagnetStatusCached {
+				mItem.CheckMagnetDataItem = item
+				mItem.Store = storeName
+			}
+		}
+	}
+
+	if len(itemIdxByHash) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	return data, nil
+}
+
+type MultiStoreAddMagnetData struct {
+	*store.AddMagnetData
+	Store store.StoreName `json:"store"`
+}
+
+func addMagnetMulti(ctxs []*context.StoreContext, magnet string, torrent *multipart.FileHeader, log *logger.Logger) (*MultiStoreAddMagnetData, error) {
+	var err error
+	for _, ctx := range ctxs {
+		var data *store.AddMagnetData
+		data, err = addMagnet(ctx, magnet, torrent)
+		if err == nil {
+			return &MultiStoreAddMagnetData{data, ctx.Store.GetName()}, nil
+		}
+		if !shouldFailoverStore(err) {
+			return nil, err
+		}
+		log.Warn("multi store: failed to add magnet, trying next store", "error", err, "store.name", ctx.Store.GetName())
+	}
+	return nil, err
+}
+
+type MultiStoreGenerateLinkData struct {
+	*store.GenerateLinkData
+	Store store.StoreName `json:"store"`
+}
+
+// The link is generated on `storeName` first, or the store selected for the
+// request, failing over to the other stores on error.
+func generateLinkMulti(r *http.Request, ctxs []*context.StoreContext, link string, storeName store.StoreName, log *logger.Logger) (*MultiStoreGenerateLinkData, error) {
+	if storeName != "" {
+		idx := slices.IndexFunc(ctxs, func(c *context.StoreContext) bool {
+			return c.Store.GetName() == storeName
+		})
+		if idx == -1 {
+			err := core.NewAPIError("store not configured: " + string(storeName))
+			err.StatusCode = http.StatusBadRequest
+			return nil, err
+		}
+		ctxs = append([]*context.StoreContext{ctxs[idx]}, slices.Delete(slices.Clone(ctxs), idx, idx+1)...)
+	}
+
+	var err error
+	for _, ctx := range ctxs {
+		var data *store.GenerateLinkData
+		data, err = shared.GenerateStremThruLink(r, ctx, link)
+		if err == nil {
+			return &MultiStoreGenerateLinkData{data, ctx.Store.GetName()}, nil
+		}
+		if !shouldFailoverStore(err) {
+			return nil, err
+		}
+		log.Warn("multi store: failed to generate link, trying next store", "error", err, "store.name", ctx.Store.GetName())
+	}
+	return nil, err
+}
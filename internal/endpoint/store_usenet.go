@@ -0,0 +1,270 @@
+package endpoint
+
+import (
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func getUsenetStore(r *http.Request, ctx *context.StoreContext) (store.UsenetStore, error) {
+	us, ok := store.AsUsenetStore(ctx.Store)
+	if !ok {
+		return nil, shared.ErrorNotImplemented(r, "store does not support usenet")
+	}
+	return us, nil
+}
+
+func handleStoreNewzCheck(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodGet) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	us, err := getUsenetStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	queryParams := r.URL.Query()
+	hashes := []string{}
+	for _, h := range queryParams["hash"] {
+		hashes = append(hashes, strings.FieldsFunc(h, func(r rune) bool {
+			return r == ','
+		})...)
+	}
+
+	if len(hashes) == 0 {
+		shared.ErrorBadRequest(r, "missing hash").Send(w, r)
+		return
+	}
+
+	if len(hashes) > 500 {
+		shared.ErrorBadRequest(r, "too many hashes, max allowed 500").Send(w, r)
+		return
+	}
+
+	params := &store.CheckNewzParams{
+		Hashes:   hashes,
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := us.CheckNewz(params)
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreNewzList(w http.ResponseWriter, r *http.Request) {
+	ctx := context.GetStoreContext(r)
+	us, err := getUsenetStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	queryParams := r.URL.Query()
+	limit, err := GetQueryInt(queryParams, "limit", 100)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	offset, err := GetQueryInt(queryParams, "offset", 0)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+
+	params := &store.ListNewzParams{
+		Limit:    limit,
+		Offset:   offset,
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := us.ListNewz(params)
+	if err == nil && data.Items == nil {
+		data.Items = []store.ListNewzDataItem{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+type AddNewzPayload struct {
+	Link     string `json:"link"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func handleStoreNewzAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := context.GetStoreContext(r)
+	us, err := getUsenetStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	params := &store.AddNewzParams{
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		payload := &AddNewzPayload{}
+		if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		if payload.Link == "" {
+			shared.ErrorBadRequest(r, "missing link").Send(w, r)
+			return
+		}
+
+		params.Link = payload.Link
+		params.Name = payload.Name
+		params.Password = payload.Password
+
+	case strings.Contains(contentType, "multipart/form-data"):
+		r.Body = http.MaxBytesReader(w, r.Body, 50<<20)
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		var fileHeader *multipart.FileHeader
+		if r.MultipartForm.File != nil {
+			fileHeaders := r.MultipartForm.File["file"]
+			if len(fileHeaders) > 1 {
+				shared.ErrorBadRequest(r, "multiple nzb files provided").Send(w, r)
+				return
+			}
+			if len(fileHeaders) == 1 {
+				fileHeader = fileHeaders[0]
+			}
+		}
+		if fileHeader == nil {
+			shared.ErrorBadRequest(r, "missing nzb file").Send(w, r)
+			return
+		}
+
+		params.File = fileHeader
+		params.Name = r.FormValue("name")
+		params.Password = r.FormValue("password")
+
+	default:
+		shared.ErrorUnsupportedMediaType(r).Send(w, r)
+		return
+	}
+
+	data, err := us.AddNewz(params)
+	if err == nil && data.Files == nil {
+		data.Files = []store.NewzFile{}
+	}
+	SendResponse(w, r, 201, data, err)
+}
+
+func handleStoreNewz(w http.ResponseWriter, r *http.Request) {
+	if shared.IsMethod(r, http.MethodGet) {
+		handleStoreNewzList(w, r)
+		return
+	}
+
+	if shared.IsMethod(r, http.MethodPost) {
+		handleStoreNewzAdd(w, r)
+		return
+	}
+
+	shared.ErrorMethodNotAllowed(r).Send(w, r)
+}
+
+func handleStoreNewzGet(w http.ResponseWriter, r *http.Request) {
+	newzId := r.PathValue("newzId")
+	if newzId == "" {
+		shared.ErrorBadRequest(r, "missing newzId").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	us, err := getUsenetStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	params := &store.GetNewzParams{
+		Id:          newzId,
+		ClientIP:    ctx.ClientIP,
+		BypassCache: true,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := us.GetNewz(params)
+	if err == nil && data.Files == nil {
+		data.Files = []store.NewzFile{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreNewzRemove(w http.ResponseWriter, r *http.Request) {
+	newzId := r.PathValue("newzId")
+	if newzId == "" {
+		shared.ErrorBadRequest(r, "missing newzId").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	us, err := getUsenetStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	params := &store.RemoveNewzParams{
+		Id: newzId,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := us.RemoveNewz(params)
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreNewzItem(w http.ResponseWriter, r *http.Request) {
+	if shared.IsMethod(r, http.MethodGet) {
+		handleStoreNewzGet(w, r)
+		return
+	}
+
+	if shared.IsMethod(r, http.MethodDelete) {
+		handleStoreNewzRemove(w, r)
+		return
+	}
+
+	shared.ErrorMethodNotAllowed(r).Send(w, r)
+}
+
+func handleStoreNewzLinkGenerate(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	payload := &GenerateLinkPayload{}
+	if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if payload.Link == "" {
+		shared.ErrorBadRequest(r, "missing link").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	link, err := shared.GenerateStremThruNewzLink(r, ctx, payload.Link)
+	SendResponse(w, r, 200, link, err)
+}
@@ -0,0 +1,188 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func getWebDLStore(r *http.Request, ctx *context.StoreContext) (store.WebDLStore, error) {
+	ws, ok := store.AsWebDLStore(ctx.Store)
+	if !ok {
+		return nil, shared.ErrorNotImplemented(r, "store does not support webdl")
+	}
+	return ws, nil
+}
+
+func handleStoreWebDLList(w http.ResponseWriter, r *http.Request) {
+	ctx := context.GetStoreContext(r)
+	ws, err := getWebDLStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	queryParams := r.URL.Query()
+	limit, err := GetQueryInt(queryParams, "limit", 100)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	offset, err := GetQueryInt(queryParams, "offset", 0)
+	if err != nil {
+		shared.ErrorBadRequest(r, err.Error()).Send(w, r)
+		return
+	}
+
+	params := &store.ListWebDLsParams{
+		Limit:    limit,
+		Offset:   offset,
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := ws.ListWebDLs(params)
+	if err == nil && data.Items == nil {
+		data.Items = []store.ListWebDLsDataItem{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+type UnrestrictLinkPayload struct {
+	Link     string `json:"link"`
+	Password string `json:"password"`
+}
+
+func handleStoreWebDLUnrestrict(w http.ResponseWriter, r *http.Request) {
+	ctx := context.GetStoreContext(r)
+	ws, err := getWebDLStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	payload := &UnrestrictLinkPayload{}
+	if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if payload.Link == "" {
+		shared.ErrorBadRequest(r, "missing link").Send(w, r)
+		return
+	}
+
+	params := &store.UnrestrictLinkParams{
+		Link:     payload.Link,
+		Password: payload.Password,
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := ws.UnrestrictLink(params)
+	if err == nil && data.Files == nil {
+		data.Files = []store.WebDLFile{}
+	}
+	SendResponse(w, r, 201, data, err)
+}
+
+func handleStoreWebDL(w http.ResponseWriter, r *http.Request) {
+	if shared.IsMethod(r, http.MethodGet) {
+		handleStoreWebDLList(w, r)
+		return
+	}
+
+	if shared.IsMethod(r, http.MethodPost) {
+		handleStoreWebDLUnrestrict(w, r)
+		return
+	}
+
+	shared.ErrorMethodNotAllowed(r).Send(w, r)
+}
+
+func handleStoreWebDLGet(w http.ResponseWriter, r *http.Request) {
+	webdlId := r.PathValue("webdlId")
+	if webdlId == "" {
+		shared.ErrorBadRequest(r, "missing webdlId").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	ws, err := getWebDLStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	params := &store.GetWebDLParams{
+		Id:       webdlId,
+		ClientIP: ctx.ClientIP,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := ws.GetWebDL(params)
+	if err == nil && data.Files == nil {
+		data.Files = []store.WebDLFile{}
+	}
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreWebDLRemove(w http.ResponseWriter, r *http.Request) {
+	webdlId := r.PathValue("webdlId")
+	if webdlId == "" {
+		shared.ErrorBadRequest(r, "missing webdlId").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	ws, err := getWebDLStore(r, ctx)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	params := &store.RemoveWebDLParams{
+		Id: webdlId,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	data, err := ws.RemoveWebDL(params)
+	SendResponse(w, r, 200, data, err)
+}
+
+func handleStoreWebDLItem(w http.ResponseWriter, r *http.Request) {
+	if shared.IsMethod(r, http.MethodGet) {
+		handleStoreWebDLGet(w, r)
+		return
+	}
+
+	if shared.IsMethod(r, http.MethodDelete) {
+		handleStoreWebDLRemove(w, r)
+		return
+	}
+
+	shared.ErrorMethodNotAllowed(r).Send(w, r)
+}
+
+func handleStoreWebDLLinkGenerate(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	payload := &GenerateLinkPayload{}
+	if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if payload.Link == "" {
+		shared.ErrorBadRequest(r, "missing link").Send(w, r)
+		return
+	}
+
+	ctx := context.GetStoreContext(r)
+	link, err := shared.GenerateStremThruWebDLLink(r, ctx, payload.Link)
+	SendResponse(w, r, 200, link, err)
+}
@@ -21,6 +21,7 @@ type IMDBTitleMap struct {
 	TraktId      string       `json:"trakt"`
 	LetterboxdId string       `json:"lboxd"`
 	MALId        string       `json:"mal"`
+	TVMazeId     string       `json:"tvmaze"`
 	UpdatedAt    db.Timestamp `json:"uat"`
 
 	Type IMDBTitleType `json:"-"`
@@ -33,6 +34,7 @@ type MapColumnStruct struct {
 	TraktId      string
 	LetterboxdId string
 	MALId        string
+	TVMazeId     string
 	UpdatedAt    string
 }
 
@@ -43,6 +45,7 @@ var MapColumn = MapColumnStruct{
 	TraktId:      "trakt",
 	LetterboxdId: "lboxd",
 	MALId:        "mal",
+	TVMazeId:     "tvmaze",
 	UpdatedAt:    "uat",
 }
 
@@ -465,10 +468,11 @@ type BulkRecordMappingInputItem struct {
 	TraktId      string
 	LetterboxdId string
 	MALId        string
+	TVMazeId     string
 }
 
 var query_bulk_record_mapping_before_values = fmt.Sprintf(
-	`INSERT INTO %s AS itm (%s,%s,%s,%s,%s,%s) VALUES `,
+	`INSERT INTO %s AS itm (%s,%s,%s,%s,%s,%s,%s) VALUES `,
 	MapTableName,
 	MapColumn.IMDBId,
 	MapColumn.TMDBId,
@@ -476,8 +480,9 @@ var query_bulk_record_mapping_before_values = fmt.Sprintf(
 	MapColumn.TraktId,
 	MapColumn.LetterboxdId,
 	MapColumn.MALId,
+	MapColumn.TVMazeId,
 )
-var query_bulk_record_mapping_placeholder = `(?,?,?,?,?,?)`
+var query_bulk_record_mapping_placeholder = `(?,?,?,?,?,?,?)`
 var query_bulk_record_mapping_after_values = fmt.Sprintf(
 	` ON CONFLICT (%s) DO UPDATE SET %s, %s = %s`,
 	MapColumn.IMDBId,
@@ -488,6 +493,7 @@ var query_bulk_record_mapping_after_values = fmt.Sprintf(
 			fmt.Sprintf("%s = CASE WHEN itm.%s IN ('','0') THEN EXCLUDED.%s ELSE itm.%s END", MapColumn.TraktId, MapColumn.TraktId, MapColumn.TraktId, MapColumn.TraktId),
 			fmt.Sprintf("%s = CASE WHEN itm.%s IN ('','0') THEN EXCLUDED.%s ELSE itm.%s END", MapColumn.LetterboxdId, MapColumn.LetterboxdId, MapColumn.LetterboxdId, MapColumn.LetterboxdId),
 			fmt.Sprintf("%s = CASE WHEN itm.%s IN ('','0') THEN EXCLUDED.%s ELSE itm.%s END", MapColumn.MALId, MapColumn.MALId, MapColumn.MALId, MapColumn.MALId),
+			fmt.Sprintf("%s = CASE WHEN itm.%s IN ('','0') THEN EXCLUDED.%s ELSE itm.%s END", MapColumn.TVMazeId, MapColumn.TVMazeId, MapColumn.TVMazeId, MapColumn.TVMazeId),
 		},
 		", ",
 	),
@@ -512,14 +518,15 @@ func BulkRecordMapping(tx db.Executor, items []BulkRecordMappingInputItem) error
 		util.RepeatJoin(query_bulk_record_mapping_placeholder, count, ",") +
 		query_bulk_record_mapping_after_values
 
-	args := make([]any, count*6)
+	args := make([]any, count*7)
 	for i, item := range items {
-		args[i*6+0] = item.IMDBId
-		args[i*6+1] = normalizeOptionalId(item.TMDBId)
-		args[i*6+2] = normalizeOptionalId(item.TVDBId)
-		args[i*6+3] = normalizeOptionalId(item.TraktId)
-		args[i*6+4] = normalizeOptionalId(item.LetterboxdId)
-		args[i*6+5] = normalizeOptionalId(item.MALId)
+		args[i*7+0] = item.IMDBId
+		args[i*7+1] = normalizeOptionalId(item.TMDBId)
+		args[i*7+2] = normalizeOptionalId(item.TVDBId)
+		args[i*7+3] = normalizeOptionalId(item.TraktId)
+		args[i*7+4] = normalizeOptionalId(item.LetterboxdId)
+		args[i*7+5] = normalizeOptionalId(item.MALId)
+		args[i*7+6] = normalizeOptionalId(item.TVMazeId)
 	}
 
 	_, err := tx.Exec(query, args...)
@@ -536,6 +543,7 @@ var query_get_id_maps_by_imdb_id = fmt.Sprintf(
 		MapColumn.TraktId,
 		MapColumn.LetterboxdId,
 		MapColumn.MALId,
+		MapColumn.TVMazeId,
 	),
 	Column.Type,
 	MapTableName,
@@ -573,6 +581,7 @@ func GetIdMapsByIMDBId(imdbIds []string) (map[string]IMDBTitleMap, error) {
 			&idMap.TraktId,
 			&idMap.LetterboxdId,
 			&idMap.MALId,
+			&idMap.TVMazeId,
 			&idMap.Type,
 		); err != nil {
 			return nil, err
@@ -640,6 +649,7 @@ var query_get_id_map_by_tvdb_id_before_cond = fmt.Sprintf(
 		MapColumn.TraktId,
 		MapColumn.LetterboxdId,
 		MapColumn.MALId,
+		MapColumn.TVMazeId,
 	),
 	Column.Type,
 	MapTableName,
@@ -670,6 +680,7 @@ func GetIdMapByTVDBId(titleType IMDBTitleSimpleType, tvdbId string) (*IMDBTitleM
 		&idMap.TraktId,
 		&idMap.LetterboxdId,
 		&idMap.MALId,
+		&idMap.TVMazeId,
 		&idMap.Type,
 	)
 	if err != nil {
@@ -691,6 +702,7 @@ var query_get_id_maps_by_letterboxd_id = fmt.Sprintf(
 		MapColumn.TraktId,
 		MapColumn.LetterboxdId,
 		MapColumn.MALId,
+		MapColumn.TVMazeId,
 	),
 	Column.Type,
 	MapTableName,
@@ -728,6 +740,7 @@ func GetIdMapsByLetterboxdId(letterboxdIds []string) (map[string]IMDBTitleMap, e
 			&idMap.TraktId,
 			&idMap.LetterboxdId,
 			&idMap.MALId,
+			&idMap.TVMazeId,
 			&idMap.Type,
 		); err != nil {
 			return nil, err
@@ -765,6 +778,7 @@ var query_get_id_maps_by_trakt_id_before_cond = fmt.Sprintf(
 		MapColumn.TraktId,
 		MapColumn.LetterboxdId,
 		MapColumn.MALId,
+		MapColumn.TVMazeId,
 	),
 	Column.Type,
 	MapTableName,
@@ -812,6 +826,7 @@ func GetIdMapsByTraktIds(titleType IMDBTitleSimpleType, traktIds []string) (map[
 			&idMap.TraktId,
 			&idMap.LetterboxdId,
 			&idMap.MALId,
+			&idMap.TVMazeId,
 			&idMap.Type,
 		); err != nil {
 			return nil, err
@@ -824,3 +839,118 @@ func GetIdMapsByTraktIds(titleType IMDBTitleSimpleType, traktIds []string) (map[
 	}
 	return idMapById, nil
 }
+
+var query_get_id_maps_by_ids_before_cond = fmt.Sprintf(
+	`SELECT %s, coalesce(it.%s, '') AS item_type FROM %s itm LEFT JOIN %s it ON itm.%s = it.%s WHERE `,
+	db.JoinPrefixedColumnNames(
+		"itm.",
+		MapColumn.IMDBId,
+		MapColumn.TMDBId,
+		MapColumn.TVDBId,
+		MapColumn.TraktId,
+		MapColumn.LetterboxdId,
+		MapColumn.MALId,
+		MapColumn.TVMazeId,
+	),
+	Column.Type,
+	MapTableName,
+	TableName,
+	MapColumn.IMDBId,
+	Column.TId,
+)
+
+var query_get_id_maps_by_ids_cond_movie = fmt.Sprintf(
+	`coalesce(it.%s, '') IN (%s,'') AND `,
+	Column.Type,
+	db.ToValues(movieTypes, "'%s'"),
+)
+
+var query_get_id_maps_by_ids_cond_show = fmt.Sprintf(
+	`coalesce(it.%s, '') IN (%s) AND `,
+	Column.Type,
+	db.ToValues(showTypes, "'%s'"),
+)
+
+func (itm *IMDBTitleMap) getId(column string) string {
+	switch column {
+	case MapColumn.IMDBId:
+		return itm.IMDBId
+	case MapColumn.TMDBId:
+		return itm.TMDBId
+	case MapColumn.TVDBId:
+		return itm.TVDBId
+	case MapColumn.TraktId:
+		return itm.TraktId
+	case MapColumn.LetterboxdId:
+		return itm.LetterboxdId
+	case MapColumn.MALId:
+		return itm.MALId
+	case MapColumn.TVMazeId:
+		return itm.TVMazeId
+	default:
+		return ""
+	}
+}
+
+// GetIdMapsByIds returns id maps keyed by the id in the given column. For
+// unknown title type, the first matching id map is used for each id.
+func GetIdMapsByIds(column string, titleType IMDBTitleSimpleType, ids []string) (map[string]IMDBTitleMap, error) {
+	count := len(ids)
+	if count == 0 {
+		return nil, nil
+	}
+
+	var query strings.Builder
+	query.WriteString(query_get_id_maps_by_ids_before_cond)
+	switch titleType {
+	case IMDBTitleSimpleTypeMovie:
+		query.WriteString(query_get_id_maps_by_ids_cond_movie)
+	case IMDBTitleSimpleTypeShow:
+		query.WriteString(query_get_id_maps_by_ids_cond_show)
+	case IMDBTitleSimpleTypeUnknown:
+	default:
+		return nil, ErrUnexpectedTitleType
+	}
+	switch column {
+	case MapColumn.IMDBId, MapColumn.TMDBId, MapColumn.TVDBId, MapColumn.TraktId, MapColumn.LetterboxdId, MapColumn.MALId, MapColumn.TVMazeId:
+		query.WriteString("itm." + column + " IN (" + util.RepeatJoin("?", count, ",") + ")")
+	default:
+		return nil, fmt.Errorf("unexpected column: %s", column)
+	}
+
+	args := make([]any, count)
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := db.Query(query.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	idMapById := make(map[string]IMDBTitleMap, count)
+	for rows.Next() {
+		idMap := IMDBTitleMap{}
+		if err := rows.Scan(
+			&idMap.IMDBId,
+			&idMap.TMDBId,
+			&idMap.TVDBId,
+			&idMap.TraktId,
+			&idMap.LetterboxdId,
+			&idMap.MALId,
+			&idMap.TVMazeId,
+			&idMap.Type,
+		); err != nil {
+			return nil, err
+		}
+
+		id := idMap.getId(column)
+		if _, found := idMapById[id]; !found {
+			idMapById[id] = idMap
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return idMapById, nil
+}
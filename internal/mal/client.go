@@ -0,0 +1,123 @@
+package mal
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type APIClientConfig struct {
+	HTTPClient *http.Client
+	ClientId   string
+}
+
+type APIClient struct {
+	BaseURL    *url.URL
+	httpClient *http.Client
+	clientId   string
+
+	reqHeader func(query *http.Header, params request.Context)
+}
+
+func NewAPIClient(conf *APIClientConfig) *APIClient {
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = config.GetHTTPClient(config.TUNNEL_TYPE_AUTO)
+	}
+
+	c := &APIClient{}
+
+	baseUrl, err := url.Parse("https://api.myanimelist.net/v2")
+	if err != nil {
+		panic(err)
+	}
+
+	c.BaseURL = baseUrl
+	c.httpClient = conf.HTTPClient
+	c.clientId = conf.ClientId
+
+	c.reqHeader = func(header *http.Header, params request.Context) {
+		header.Set("X-MAL-CLIENT-ID", c.clientId)
+	}
+
+	return c
+}
+
+type Ctx = request.Ctx
+
+type ResponseError struct {
+	Err     string `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *ResponseError) Error() string {
+	ret, _ := json.Marshal(e)
+	return string(ret)
+}
+
+type ResponseContainer interface {
+	GetError() error
+}
+
+func (r *ResponseError) GetError() error {
+	if r == nil || r.Err == "" {
+		return nil
+	}
+	return r
+}
+
+func processResponseBody(res *http.Response, err error, v ResponseContainer) error {
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	defer res.Body.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = core.UnmarshalJSON(res.StatusCode, body, v)
+	if err != nil {
+		return err
+	}
+
+	return v.GetError()
+}
+
+func (c APIClient) Request(method, path string, params request.Context, v ResponseContainer) (*http.Response, error) {
+	if params == nil {
+		params = &Ctx{}
+	}
+	req, err := params.NewRequest(c.BaseURL, method, path, c.reqHeader, nil)
+	if err != nil {
+		error := core.NewAPIError("failed to create request")
+		error.Cause = err
+		return nil, error
+	}
+	res, err := params.DoRequest(c.httpClient, req)
+	err = processResponseBody(res, err, v)
+	if err != nil {
+		error := core.NewUpstreamError("")
+		if rerr, ok := err.(*core.Error); ok {
+			error.Msg = rerr.Msg
+			error.Code = rerr.Code
+			error.StatusCode = rerr.StatusCode
+			error.UpstreamCause = rerr
+		} else {
+			error.Cause = err
+		}
+		error.InjectReq(req)
+		return res, err
+	}
+	return res, nil
+}
+
+var client = NewAPIClient(&APIClientConfig{
+	ClientId: config.Integration.MAL.ClientId,
+})
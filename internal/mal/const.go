@@ -0,0 +1,57 @@
+package mal
+
+type Genre = string
+
+const (
+	GenreAction       Genre = "Action"
+	GenreAdventure    Genre = "Adventure"
+	GenreAvantGarde   Genre = "Avant Garde"
+	GenreAwardWinning Genre = "Award Winning"
+	GenreBoysLove     Genre = "Boys Love"
+	GenreComedy       Genre = "Comedy"
+	GenreDrama        Genre = "Drama"
+	GenreEcchi        Genre = "Ecchi"
+	GenreFantasy      Genre = "Fantasy"
+	GenreGirlsLove    Genre = "Girls Love"
+	GenreGourmet      Genre = "Gourmet"
+	GenreHorror       Genre = "Horror"
+	GenreJosei        Genre = "Josei"
+	GenreKids         Genre = "Kids"
+	GenreMystery      Genre = "Mystery"
+	GenreRomance      Genre = "Romance"
+	GenreSciFi        Genre = "Sci-Fi"
+	GenreSeinen       Genre = "Seinen"
+	GenreShoujo       Genre = "Shoujo"
+	GenreShounen      Genre = "Shounen"
+	GenreSliceOfLife  Genre = "Slice of Life"
+	GenreSports       Genre = "Sports"
+	GenreSupernatural Genre = "Supernatural"
+	GenreSuspense     Genre = "Suspense"
+)
+
+var Genres = []Genre{
+	GenreAction,
+	GenreAdventure,
+	GenreAvantGarde,
+	GenreAwardWinning,
+	GenreBoysLove,
+	GenreComedy,
+	GenreDrama,
+	GenreEcchi,
+	GenreFantasy,
+	GenreGirlsLove,
+	GenreGourmet,
+	GenreHorror,
+	GenreJosei,
+	GenreKids,
+	GenreMystery,
+	GenreRomance,
+	GenreSciFi,
+	GenreSeinen,
+	GenreShoujo,
+	GenreShounen,
+	GenreSliceOfLife,
+	GenreSports,
+	GenreSupernatural,
+	GenreSuspense,
+}
@@ -0,0 +1,573 @@
+package mal
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anime"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+const ListTableName = "mal_list"
+
+type MALList struct {
+	Id        string       `json:"id"`
+	UpdatedAt db.Timestamp `json:"uat"`
+
+	Animes []MALAnime `json:"-"`
+}
+
+func (l *MALList) GetURL() string {
+	userName, name, ok := strings.Cut(l.Id, ":")
+	if !ok {
+		return ""
+	}
+	if userName == "~" {
+		if name == string(RankingTypeAll) {
+			return "https://myanimelist.net/topanime.php"
+		}
+		return "https://myanimelist.net/topanime.php?type=" + name
+	}
+	u := "https://myanimelist.net/animelist/" + userName
+	if webStatus := webStatusByListStatus[ListStatus(name)]; webStatus != "" && webStatus != webStatusByListStatus[ListStatusAll] {
+		u += "?status=" + webStatus
+	}
+	return u
+}
+
+func (l *MALList) GetName() string {
+	_, name, _ := strings.Cut(l.Id, ":")
+	return name
+}
+
+func (l *MALList) GetUserName() string {
+	userName, _, _ := strings.Cut(l.Id, ":")
+	return userName
+}
+
+func (l *MALList) GetDisplayName() string {
+	userName, name, _ := strings.Cut(l.Id, ":")
+	if userName == "~" {
+		if displayName, ok := rankingTypeName[RankingType(name)]; ok {
+			return "MyAnimeList / " + displayName
+		}
+	}
+	if displayName, ok := listStatusName[ListStatus(name)]; ok {
+		return userName + " / " + displayName
+	}
+	return userName + " / " + name
+}
+
+func (l *MALList) IsStale() bool {
+	return time.Now().After(l.UpdatedAt.Add(config.Integration.MAL.ListStaleTime + util.GetRandomDuration(5*time.Second, 5*time.Minute)))
+}
+
+type ListColumnStruct struct {
+	Id        string
+	UpdatedAt string
+}
+
+var ListColumn = ListColumnStruct{
+	Id:        "id",
+	UpdatedAt: "uat",
+}
+
+var ListColumns = []string{
+	ListColumn.Id,
+	ListColumn.UpdatedAt,
+}
+
+const AnimeTableName = "mal_anime"
+
+type genreList []string
+
+func (genre genreList) Value() (driver.Value, error) {
+	return json.Marshal(genre)
+}
+
+func (genre *genreList) Scan(value any) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case string:
+		bytes = []byte(v)
+	case []byte:
+		bytes = v
+	default:
+		return errors.New("failed to convert value to []byte")
+	}
+	return json.Unmarshal(bytes, genre)
+}
+
+type MALAnime struct {
+	Id          int          `json:"id"`
+	Type        MediaType    `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Cover       string       `json:"cover"`
+	Duration    int          `json:"duration"`
+	IsAdult     bool         `json:"is_adult"`
+	StartYear   int          `json:"start_year"`
+	UpdatedAt   db.Timestamp `json:"uat"`
+
+	Genres genreList         `json:"-"`
+	Score  int               `json:"-"`
+	IdMap  *anime.AnimeIdMap `json:"-"`
+}
+
+type AnimeColumnStruct struct {
+	Id          string
+	Type        string
+	Title       string
+	Description string
+	Cover       string
+	Duration    string
+	IsAdult     string
+	StartYear   string
+	UpdatedAt   string
+}
+
+var AnimeColumn = AnimeColumnStruct{
+	Id:          "id",
+	Type:        "type",
+	Title:       "title",
+	Description: "description",
+	Cover:       "cover",
+	Duration:    "duration",
+	IsAdult:     "is_adult",
+	StartYear:   "start_year",
+	UpdatedAt:   "uat",
+}
+
+var AnimeColumns = []string{
+	AnimeColumn.Id,
+	AnimeColumn.Type,
+	AnimeColumn.Title,
+	AnimeColumn.Description,
+	AnimeColumn.Cover,
+	AnimeColumn.Duration,
+	AnimeColumn.IsAdult,
+	AnimeColumn.StartYear,
+	AnimeColumn.UpdatedAt,
+}
+
+const ListAnimeTableName = "mal_list_anime"
+
+type MALListAnime struct {
+	ListId  string `json:"list_id"`
+	AnimeId int    `json:"anime_id"`
+	Score   int    `json:"score"`
+}
+
+type ListAnimeColumnStruct struct {
+	ListId  string
+	AnimeId string
+	Score   string
+}
+
+var ListAnimeColumn = ListAnimeColumnStruct{
+	AnimeId: "anime_id",
+	ListId:  "list_id",
+	Score:   "score",
+}
+
+var ListAnimeColumns = []string{
+	ListAnimeColumn.ListId,
+	ListAnimeColumn.AnimeId,
+	ListAnimeColumn.Score,
+}
+
+const AnimeGenreTableName = "mal_anime_genre"
+
+type MALAnimeGenre struct {
+	AnimeId int    `json:"anime_id"`
+	Genre   string `json:"genre"`
+}
+
+type AnimeGenreColumnStruct struct {
+	AnimeId string
+	Genre   string
+}
+
+var AnimeGenreColumn = AnimeGenreColumnStruct{
+	AnimeId: "anime_id",
+	Genre:   "genre",
+}
+
+var query_get_list_by_id = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ?`,
+	db.JoinColumnNames(ListColumns...),
+	ListTableName,
+	ListColumn.Id,
+)
+
+func GetListById(id string) (*MALList, error) {
+	var list MALList
+	row := db.QueryRow(query_get_list_by_id, id)
+	if err := row.Scan(&list.Id, &list.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	items, err := getListAnimes(list.Id)
+	if err != nil {
+		return nil, err
+	}
+	list.Animes = items
+	return &list, nil
+}
+
+var query_get_list_anime_ids = fmt.Sprintf(
+	`SELECT %s, %s FROM %s WHERE %s = ? ORDER BY %s DESC`,
+	ListAnimeColumn.AnimeId,
+	ListAnimeColumn.Score,
+	ListAnimeTableName,
+	ListAnimeColumn.ListId,
+	ListAnimeColumn.Score,
+)
+
+func getListAnimeIds(listId string) ([]int, map[int]int, error) {
+	rows, err := db.Query(query_get_list_anime_ids, listId)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	animeIds := []int{}
+	scoreByAnimeId := map[int]int{}
+
+	for rows.Next() {
+		var animeId int
+		var score int
+		if err := rows.Scan(&animeId, &score); err != nil {
+			return nil, nil, err
+		}
+		animeIds = append(animeIds, animeId)
+		scoreByAnimeId[animeId] = score
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return animeIds, scoreByAnimeId, nil
+}
+
+var query_get_animes = fmt.Sprintf(
+	`SELECT %s, %s(mg.%s) AS genre FROM %s m LEFT JOIN %s mg ON m.%s = mg.%s WHERE m.%s IN `,
+	db.JoinPrefixedColumnNames("m.", AnimeColumns...),
+	db.FnJSONGroupArray,
+	AnimeGenreColumn.Genre,
+	AnimeTableName,
+	AnimeGenreTableName,
+	AnimeColumn.Id,
+	AnimeGenreColumn.AnimeId,
+	AnimeColumn.Id,
+)
+var query_get_animes_group_by = fmt.Sprintf(
+	` GROUP BY m.%s`,
+	AnimeColumn.Id,
+)
+
+func getAnimes(animeIds []int, scoreByAnimeId map[int]int) ([]MALAnime, error) {
+	count := len(animeIds)
+	if count == 0 {
+		return nil, nil
+	}
+
+	query := query_get_animes + "(" + util.RepeatJoin("?", count, ",") + ")" + query_get_animes_group_by
+	args := make([]any, count)
+	for i := range animeIds {
+		args[i] = animeIds[i]
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []MALAnime
+	for rows.Next() {
+		var item MALAnime
+		if err := rows.Scan(
+			&item.Id,
+			&item.Type,
+			&item.Title,
+			&item.Description,
+			&item.Cover,
+			&item.Duration,
+			&item.IsAdult,
+			&item.StartYear,
+			&item.UpdatedAt,
+			&item.Genres,
+		); err != nil {
+			return nil, err
+		}
+		if score, ok := scoreByAnimeId[item.Id]; ok {
+			item.Score = score
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(items, func(a, b MALAnime) int {
+		return b.Score - a.Score
+	})
+
+	idMaps, err := anime.GetIdMapsForMAL(animeIds)
+	if err != nil {
+		return nil, err
+	}
+	idMapById := map[string]*anime.AnimeIdMap{}
+	for i := range idMaps {
+		idMap := &idMaps[i]
+		idMapById[idMap.MAL] = idMap
+	}
+	for i := range items {
+		item := &items[i]
+		if idMap, ok := idMapById[strconv.Itoa(item.Id)]; ok {
+			item.IdMap = idMap
+		}
+	}
+
+	return items, nil
+}
+
+func getListAnimes(listId string) ([]MALAnime, error) {
+	animeIds, scoreByAnimeId, err := getListAnimeIds(listId)
+	if err != nil {
+		return nil, err
+	}
+	return getAnimes(animeIds, scoreByAnimeId)
+}
+
+var query_upsert_list = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?) ON CONFLICT (%s) DO UPDATE SET %s = %s`,
+	ListTableName,
+	ListColumn.Id,
+	ListColumn.Id,
+	ListColumn.UpdatedAt,
+	db.CurrentTimestamp,
+)
+
+func UpsertList(list *MALList) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		tErr := tx.Rollback()
+		err = errors.Join(tErr, err)
+	}()
+
+	_, err = tx.Exec(query_upsert_list, list.Id)
+	if err != nil {
+		return err
+	}
+
+	list.UpdatedAt = db.Timestamp{Time: time.Now()}
+
+	err = upsertAnimes(tx, list.Animes)
+	if err != nil {
+		return err
+	}
+
+	err = setListAnimes(tx, list.Id, list.Animes)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var query_upsert_animes = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES `,
+	AnimeTableName,
+	strings.Join(AnimeColumns[0:len(AnimeColumns)-1], ","),
+)
+var query_upsert_animes_values_placeholder = "(" + util.RepeatJoin("?", len(AnimeColumns)-1, ",") + ")"
+var query_upsert_animes_on_conflict = fmt.Sprintf(
+	" ON CONFLICT (%s) DO UPDATE SET %s, %s = %s",
+	AnimeColumn.Id,
+	strings.Join(
+		[]string{
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.Type, AnimeColumn.Type),
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.Title, AnimeColumn.Title),
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.Description, AnimeColumn.Description),
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.Cover, AnimeColumn.Cover),
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.Duration, AnimeColumn.Duration),
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.IsAdult, AnimeColumn.IsAdult),
+			fmt.Sprintf("%s = EXCLUDED.%s", AnimeColumn.StartYear, AnimeColumn.StartYear),
+		},
+		", ",
+	),
+	AnimeColumn.UpdatedAt,
+	db.CurrentTimestamp,
+)
+
+func upsertAnimes(tx db.Executor, animes []MALAnime) error {
+	if len(animes) == 0 {
+		return nil
+	}
+
+	for cAnimes := range slices.Chunk(animes, 500) {
+		count := len(cAnimes)
+
+		query := query_upsert_animes +
+			util.RepeatJoin(query_upsert_animes_values_placeholder, count, ",") +
+			query_upsert_animes_on_conflict
+
+		columnCount := len(AnimeColumns) - 1
+		args := make([]any, count*columnCount)
+		for i := range cAnimes {
+			item := &cAnimes[i]
+			args[i*columnCount+0] = item.Id
+			args[i*columnCount+1] = item.Type
+			args[i*columnCount+2] = item.Title
+			args[i*columnCount+3] = item.Description
+			args[i*columnCount+4] = item.Cover
+			args[i*columnCount+5] = item.Duration
+			args[i*columnCount+6] = item.IsAdult
+			args[i*columnCount+7] = item.StartYear
+		}
+
+		_, err := tx.Exec(query, args...)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range cAnimes {
+			err = setAnimeGenre(tx, item.Id, item.Genres)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var query_set_anime_genre_before_values = fmt.Sprintf(
+	`INSERT INTO %s (%s, %s) VALUES `,
+	AnimeGenreTableName,
+	AnimeGenreColumn.AnimeId,
+	AnimeGenreColumn.Genre,
+)
+var query_set_anime_genre_values_placeholder = "(?, ?)"
+var query_set_anime_genre_after_values = fmt.Sprintf(
+	` ON CONFLICT DO NOTHING`,
+)
+var query_cleanup_anime_genre = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s NOT IN `,
+	AnimeGenreTableName,
+	AnimeGenreColumn.AnimeId,
+	AnimeGenreColumn.Genre,
+)
+
+func setAnimeGenre(tx db.Executor, animeId int, genres []string) error {
+	count := len(genres)
+	if count == 0 {
+		return nil
+	}
+
+	cleanupArgs := make([]any, 1+count)
+	cleanupArgs[0] = animeId
+	for i, genre := range genres {
+		cleanupArgs[1+i] = genre
+	}
+	cleanupQuery := query_cleanup_anime_genre + "(" + util.RepeatJoin("?", count, ",") + ")"
+	if _, err := tx.Exec(cleanupQuery, cleanupArgs...); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	query := query_set_anime_genre_before_values +
+		util.RepeatJoin(query_set_anime_genre_values_placeholder, count, ",") +
+		query_set_anime_genre_after_values
+	args := make([]any, count*2)
+	for i, genre := range genres {
+		args[i*2] = animeId
+		args[i*2+1] = genre
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var query_set_list_animes_before_values = fmt.Sprintf(
+	`INSERT INTO %s (%s, %s, %s) VALUES `,
+	ListAnimeTableName,
+	ListAnimeColumn.ListId,
+	ListAnimeColumn.AnimeId,
+	ListAnimeColumn.Score,
+)
+var query_set_list_animes_values_placeholder = "(?,?,?)"
+var query_set_list_animes_after_values = fmt.Sprintf(
+	` ON CONFLICT (%s, %s) DO UPDATE SET %s = EXCLUDED.%s`,
+	ListAnimeColumn.ListId,
+	ListAnimeColumn.AnimeId,
+	ListAnimeColumn.Score,
+	ListAnimeColumn.Score,
+)
+var query_cleanup_list_animes = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s NOT IN `,
+	ListAnimeTableName,
+	ListAnimeColumn.ListId,
+	ListAnimeColumn.AnimeId,
+)
+
+func setListAnimes(tx *db.Tx, listId string, animes []MALAnime) error {
+	count := len(animes)
+
+	cleanupArgs := make([]any, 1+count)
+	cleanupArgs[0] = listId
+	for i := range animes {
+		cleanupArgs[1+i] = animes[i].Id
+	}
+	cleanupQuery := query_cleanup_list_animes + "(" + util.RepeatJoin("?", count, ",") + ")"
+	if _, err := tx.Exec(cleanupQuery, cleanupArgs...); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	query := query_set_list_animes_before_values +
+		util.RepeatJoin(query_set_list_animes_values_placeholder, count, ",") +
+		query_set_list_animes_after_values
+	args := make([]any, count*3)
+	for i := range animes {
+		item := &animes[i]
+		args[i*3+0] = listId
+		args[i*3+1] = item.Id
+		args[i*3+2] = item.Score
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
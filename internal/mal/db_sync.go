@@ -0,0 +1,235 @@
+package mal
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anime"
+	"github.com/MunifTanjim/stremthru/internal/anizip"
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/worker/worker_queue"
+)
+
+var listCache = cache.NewCache[MALList](&cache.CacheConfig{
+	Lifetime:      6 * time.Hour,
+	Name:          "mal:list",
+	LocalCapacity: 1024,
+})
+
+var anizipClient = anizip.NewAPIClient(&anizip.APIClientConfig{})
+
+func EnsureIdMap(animes []MALAnime, listId string) error {
+	idMapGroup := anizip.GetMappingsPool().NewGroup()
+
+	missingIdMapMALIds := []int{}
+	for i := range animes {
+		item := &animes[i]
+		if item.IdMap == nil {
+			missingIdMapMALIds = append(missingIdMapMALIds, item.Id)
+			continue
+		}
+		if item.IdMap.IsStale() {
+			idMapGroup.SubmitErr(func() (*anizip.GetMappingsData, error) {
+				log.Debug("fetching stale idMap for anime", "id", item.Id, "title", item.Title)
+				return anizipClient.GetMappings(&anizip.GetMappingsParams{
+					Service: anime.IdMapColumn.MAL,
+					Id:      strconv.Itoa(item.Id),
+				})
+			})
+		}
+	}
+
+	idMapByMALId := map[string]*anime.AnimeIdMap{}
+
+	if len(missingIdMapMALIds) > 0 {
+		idMaps, err := anime.GetIdMapsForMAL(missingIdMapMALIds)
+		if err != nil {
+			return err
+		}
+		for i := range idMaps {
+			idMap := &idMaps[i]
+			idMapByMALId[idMap.MAL] = idMap
+		}
+		for _, malId := range missingIdMapMALIds {
+			if idMap, ok := idMapByMALId[strconv.Itoa(malId)]; !ok || idMap.IsStale() {
+				idMapGroup.SubmitErr(func() (*anizip.GetMappingsData, error) {
+					log.Debug("fetching missing idMap for anime", "id", malId)
+					return anizipClient.GetMappings(&anizip.GetMappingsParams{
+						Service: anime.IdMapColumn.MAL,
+						Id:      strconv.Itoa(malId),
+					})
+				})
+			}
+		}
+	}
+
+	results, err := idMapGroup.Wait()
+	if err != nil {
+		return err
+	}
+
+	if len(results) > 0 {
+		idMapItems := make([]anime.AnimeIdMap, 0, len(results))
+		for i := range results {
+			m := results[i].Mappings
+			idMap := anime.AnimeIdMap{
+				Type:        m.Type,
+				AniDB:       strconv.Itoa(m.AniDB),
+				AniList:     strconv.Itoa(m.AniList),
+				AniSearch:   strconv.Itoa(m.AniSearch),
+				AnimePlanet: m.AnimePlanet,
+				IMDB:        m.IMDB,
+				Kitsu:       strconv.Itoa(m.Kitsu),
+				LiveChart:   strconv.Itoa(m.LiveChart),
+				MAL:         strconv.Itoa(m.MAL),
+				NotifyMoe:   m.NotifyMoe,
+				TMDB:        m.TMDB,
+				TVDB:        strconv.Itoa(m.TVDB),
+				UpdatedAt:   db.Timestamp{Time: time.Now()},
+			}
+			idMapByMALId[strconv.Itoa(m.MAL)] = &idMap
+			idMapItems = append(idMapItems, idMap)
+		}
+		if err := anime.BulkRecordIdMaps(idMapItems, anime.IdMapColumn.MAL); err != nil {
+			log.Error("failed to record idMaps", "error", err)
+		}
+	}
+
+	for i := range animes {
+		item := &animes[i]
+		if idMap, ok := idMapByMALId[strconv.Itoa(item.Id)]; ok {
+			item.IdMap = idMap
+		}
+	}
+	if len(idMapByMALId) > 0 {
+		listCache.Remove(getListCacheKey(&MALList{Id: listId}))
+	}
+
+	return nil
+}
+
+func ScheduleIdMapSync(animes []MALAnime) {
+	for i := range animes {
+		item := &animes[i]
+		if item.IdMap == nil || item.IdMap.IsStale() {
+			worker_queue.AnimeIdMapperQueue.Queue(worker_queue.AnimeIdMapperQueueItem{
+				Service: anime.IdMapColumn.MAL,
+				Id:      strconv.Itoa(item.Id),
+			})
+		}
+	}
+}
+
+func getListCacheKey(l *MALList) string {
+	return l.Id
+}
+
+var syncListMutex sync.Mutex
+
+func syncList(l *MALList) error {
+	syncListMutex.Lock()
+	defer syncListMutex.Unlock()
+
+	var list *List
+	var err error
+	log.Debug("fetching list by id", "id", l.Id)
+	if l.GetUserName() == "~" {
+		list, err = FetchRankingList(RankingType(l.GetName()))
+	} else {
+		list, err = FetchUserList(l.GetUserName(), ListStatus(l.GetName()))
+	}
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		return errors.New("list not found")
+	}
+
+	l.Id = list.GetId()
+	l.Animes = nil
+
+	animeIds := make([]int, len(list.Items))
+	for i := range list.Items {
+		animeIds[i] = list.Items[i].Anime.Id
+	}
+	idMaps, err := anime.GetIdMapsForMAL(animeIds)
+	if err != nil {
+		return err
+	}
+	idMapByMALId := make(map[string]*anime.AnimeIdMap, len(idMaps))
+	for i := range idMaps {
+		idMap := &idMaps[i]
+		idMapByMALId[idMap.MAL] = idMap
+	}
+
+	now := time.Now()
+	for i := range list.Items {
+		item := &list.Items[i]
+		l.Animes = append(l.Animes, MALAnime{
+			Id:          item.Anime.Id,
+			Type:        item.Anime.MediaType,
+			Title:       item.Anime.Title,
+			Description: item.Anime.Synopsis,
+			Cover:       item.Anime.GetPoster(),
+			Duration:    item.Anime.AverageEpisodeDuration / 60,
+			IsAdult:     item.Anime.IsAdult(),
+			StartYear:   item.Anime.GetStartYear(),
+			UpdatedAt:   db.Timestamp{Time: now},
+			Genres:      item.Anime.GenreNames(),
+			Score:       item.Score,
+			IdMap:       idMapByMALId[strconv.Itoa(item.Anime.Id)],
+		})
+	}
+
+	if err := UpsertList(l); err != nil {
+		return err
+	}
+
+	if err := listCache.Add(getListCacheKey(l), *l); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *MALList) Fetch() error {
+	isMissing := false
+
+	listCacheKey := getListCacheKey(l)
+	var cachedL MALList
+	if !listCache.Get(listCacheKey, &cachedL) {
+		if list, err := GetListById(l.Id); err != nil {
+			return err
+		} else if list == nil {
+			isMissing = true
+		} else {
+			*l = *list
+			log.Debug("found list by id", "id", l.Id, "is_stale", l.IsStale())
+			listCache.Add(listCacheKey, *l)
+		}
+	} else {
+		*l = cachedL
+	}
+
+	if !isMissing {
+		if l.IsStale() {
+			staleList := *l
+			go func() {
+				if err := syncList(&staleList); err != nil {
+					log.Error("failed to sync stale list", "id", l.Id, "error", err)
+				}
+			}()
+		}
+		return nil
+	}
+
+	if err := syncList(l); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package mal
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type MediaType string
+
+const (
+	MediaTypeUnknown   MediaType = "unknown"
+	MediaTypeTV        MediaType = "tv"
+	MediaTypeOVA       MediaType = "ova"
+	MediaTypeMovie     MediaType = "movie"
+	MediaTypeSpecial   MediaType = "special"
+	MediaTypeONA       MediaType = "ona"
+	MediaTypeMusic     MediaType = "music"
+	MediaTypeCM        MediaType = "cm"
+	MediaTypePV        MediaType = "pv"
+	MediaTypeTVSpecial MediaType = "tv_special"
+)
+
+func (mt MediaType) ToSimple() string {
+	switch mt {
+	case MediaTypeMovie:
+		return "movie"
+	case MediaTypeTV, MediaTypeONA:
+		return "series"
+	default:
+		return ""
+	}
+}
+
+type ListStatus string
+
+const (
+	ListStatusAll         ListStatus = "all"
+	ListStatusWatching    ListStatus = "watching"
+	ListStatusCompleted   ListStatus = "completed"
+	ListStatusOnHold      ListStatus = "on_hold"
+	ListStatusDropped     ListStatus = "dropped"
+	ListStatusPlanToWatch ListStatus = "plan_to_watch"
+)
+
+// status query param used on https://myanimelist.net/animelist/{user_name}
+var listStatusByWebStatus = map[string]ListStatus{
+	"7": ListStatusAll,
+	"1": ListStatusWatching,
+	"2": ListStatusCompleted,
+	"3": ListStatusOnHold,
+	"4": ListStatusDropped,
+	"6": ListStatusPlanToWatch,
+}
+
+var webStatusByListStatus = func() map[ListStatus]string {
+	m := make(map[ListStatus]string, len(listStatusByWebStatus))
+	for webStatus, status := range listStatusByWebStatus {
+		m[status] = webStatus
+	}
+	return m
+}()
+
+func ParseListStatus(webStatus string) (ListStatus, bool) {
+	if webStatus == "" {
+		return ListStatusAll, true
+	}
+	status, ok := listStatusByWebStatus[webStatus]
+	return status, ok
+}
+
+var listStatusName = map[ListStatus]string{
+	ListStatusAll:         "All Anime",
+	ListStatusWatching:    "Currently Watching",
+	ListStatusCompleted:   "Completed",
+	ListStatusOnHold:      "On Hold",
+	ListStatusDropped:     "Dropped",
+	ListStatusPlanToWatch: "Plan to Watch",
+}
+
+type RankingType string
+
+const (
+	RankingTypeAll          RankingType = "all"
+	RankingTypeAiring       RankingType = "airing"
+	RankingTypeUpcoming     RankingType = "upcoming"
+	RankingTypeTV           RankingType = "tv"
+	RankingTypeOVA          RankingType = "ova"
+	RankingTypeMovie        RankingType = "movie"
+	RankingTypeSpecial      RankingType = "special"
+	RankingTypeByPopularity RankingType = "bypopularity"
+	RankingTypeFavorite     RankingType = "favorite"
+)
+
+var rankingTypeName = map[RankingType]string{
+	RankingTypeAll:          "Top Anime",
+	RankingTypeAiring:       "Top Airing Anime",
+	RankingTypeUpcoming:     "Top Upcoming Anime",
+	RankingTypeTV:           "Top Anime Series",
+	RankingTypeOVA:          "Top OVA Anime",
+	RankingTypeMovie:        "Top Anime Movies",
+	RankingTypeSpecial:      "Top Anime Specials",
+	RankingTypeByPopularity: "Most Popular Anime",
+	RankingTypeFavorite:     "Most Favorited Anime",
+}
+
+// type query param used on https://myanimelist.net/topanime.php
+func ParseRankingType(webType string) (RankingType, bool) {
+	if webType == "" {
+		return RankingTypeAll, true
+	}
+	rankingType := RankingType(webType)
+	_, ok := rankingTypeName[rankingType]
+	return rankingType, ok
+}
+
+type AnimeGenre struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Picture struct {
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+}
+
+type Anime struct {
+	Id                     int          `json:"id"`
+	Title                  string       `json:"title"`
+	MainPicture            *Picture     `json:"main_picture,omitempty"`
+	Synopsis               string       `json:"synopsis"`
+	MediaType              MediaType    `json:"media_type"`
+	StartDate              string       `json:"start_date"`
+	Genres                 []AnimeGenre `json:"genres"`
+	AverageEpisodeDuration int          `json:"average_episode_duration"`
+	NSFW                   string       `json:"nsfw"`
+}
+
+func (a *Anime) GetStartYear() int {
+	year, _, _ := strings.Cut(a.StartDate, "-")
+	y, _ := strconv.Atoi(year)
+	return y
+}
+
+func (a *Anime) GetPoster() string {
+	if a.MainPicture == nil {
+		return ""
+	}
+	if a.MainPicture.Large != "" {
+		return a.MainPicture.Large
+	}
+	return a.MainPicture.Medium
+}
+
+func (a *Anime) IsAdult() bool {
+	return a.NSFW == "black"
+}
+
+func (a *Anime) GenreNames() []string {
+	names := make([]string, len(a.Genres))
+	for i := range a.Genres {
+		names[i] = a.Genres[i].Name
+	}
+	return names
+}
+
+const animeFields = "id,title,main_picture,synopsis,media_type,start_date,genres,average_episode_duration,nsfw"
+
+type Paging struct {
+	Next string `json:"next,omitempty"`
+}
+
+type GetUserAnimeListData struct {
+	ResponseError
+	Data []struct {
+		Node       Anime `json:"node"`
+		ListStatus struct {
+			Status ListStatus `json:"status"`
+			Score  int        `json:"score"`
+		} `json:"list_status"`
+	} `json:"data"`
+	Paging Paging `json:"paging"`
+}
+
+type GetUserAnimeListParams struct {
+	Ctx
+	UserName string
+	Status   ListStatus
+	next     string
+}
+
+func (c APIClient) GetUserAnimeList(params *GetUserAnimeListParams) (request.APIResponse[GetUserAnimeListData], error) {
+	path := params.next
+	if path == "" {
+		path = "/users/" + url.PathEscape(params.UserName) + "/animelist"
+		params.Query = &url.Values{}
+		params.Query.Set("fields", "list_status,"+animeFields)
+		params.Query.Set("limit", "1000")
+		params.Query.Set("nsfw", "true")
+		params.Query.Set("sort", "list_score")
+		if params.Status != "" && params.Status != ListStatusAll {
+			params.Query.Set("status", string(params.Status))
+		}
+	} else {
+		params.Query = nil
+	}
+
+	response := GetUserAnimeListData{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type GetAnimeRankingData struct {
+	ResponseError
+	Data []struct {
+		Node    Anime `json:"node"`
+		Ranking struct {
+			Rank int `json:"rank"`
+		} `json:"ranking"`
+	} `json:"data"`
+	Paging Paging `json:"paging"`
+}
+
+type GetAnimeRankingParams struct {
+	Ctx
+	RankingType RankingType
+	Limit       int
+}
+
+func (c APIClient) GetAnimeRanking(params *GetAnimeRankingParams) (request.APIResponse[GetAnimeRankingData], error) {
+	params.Query = &url.Values{}
+	params.Query.Set("ranking_type", string(params.RankingType))
+	params.Query.Set("fields", animeFields)
+	params.Query.Set("nsfw", "true")
+	if params.Limit > 0 {
+		params.Query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := GetAnimeRankingData{}
+	res, err := c.Request("GET", "/anime/ranking", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type ListItem struct {
+	Anime Anime
+	Score int
+}
+
+type List struct {
+	UserName string
+	Name     string
+	Items    []ListItem
+}
+
+func (l List) GetId() string {
+	return l.UserName + ":" + l.Name
+}
+
+const maxUserListPages = 20
+
+func FetchUserList(userName string, status ListStatus) (*List, error) {
+	list := &List{
+		UserName: userName,
+		Name:     string(status),
+		Items:    []ListItem{},
+	}
+	params := &GetUserAnimeListParams{
+		UserName: userName,
+		Status:   status,
+	}
+	for range maxUserListPages {
+		res, err := client.GetUserAnimeList(params)
+		if err != nil {
+			return nil, err
+		}
+		for i := range res.Data.Data {
+			item := &res.Data.Data[i]
+			list.Items = append(list.Items, ListItem{
+				Anime: item.Node,
+				Score: item.ListStatus.Score,
+			})
+		}
+		if res.Data.Paging.Next == "" {
+			break
+		}
+		params.next = res.Data.Paging.Next
+	}
+	return list, nil
+}
+
+func FetchRankingList(rankingType RankingType) (*List, error) {
+	res, err := client.GetAnimeRanking(&GetAnimeRankingParams{
+		RankingType: rankingType,
+		Limit:       500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := &List{
+		UserName: "~",
+		Name:     string(rankingType),
+		Items:    make([]ListItem, len(res.Data.Data)),
+	}
+	count := len(res.Data.Data)
+	for i := range res.Data.Data {
+		item := &res.Data.Data[i]
+		list.Items[i] = ListItem{
+			Anime: item.Node,
+			Score: count - i,
+		}
+	}
+	return list, nil
+}
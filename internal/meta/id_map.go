@@ -1,14 +1,17 @@
 package meta
 
 import (
+	"cmp"
 	"errors"
 	"strings"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/anime"
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/imdb_title"
 	meta_type "github.com/MunifTanjim/stremthru/internal/meta/type"
+	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
 type IdType = meta_type.IdType
@@ -41,15 +44,16 @@ const (
 type IdMapAnime = meta_type.IdMapAnime
 type IdMap = meta_type.IdMap
 
+// ParseId parses ids in `{provider}:{id}` format, IMDB ids can also be
+// without the provider prefix.
 func ParseId(idStr string) (provider IdProvider, id string) {
 	if strings.HasPrefix(idStr, "tt") {
 		return IdProviderIMDB, idStr
 	}
-	if traktId, ok := strings.CutPrefix(idStr, "trakt:"); ok {
-		return IdProviderTrakt, traktId
-	}
-	if tvdbId, ok := strings.CutPrefix(idStr, "tvdb:"); ok {
-		return IdProviderTVDB, tvdbId
+	if p, id, ok := strings.Cut(idStr, ":"); ok && id != "" {
+		if provider := IdProvider(p); provider.IsValid() {
+			return provider, id
+		}
 	}
 	return "", ""
 }
@@ -63,58 +67,190 @@ var idMapCache = cache.NewCache[IdMap](&cache.CacheConfig{
 	LocalCapacity: 2048,
 })
 
-func GetIdMap(idType IdType, idStr string) (*IdMap, error) {
-	idProvider, id := ParseId(idStr)
+var imdbTitleMapColumnByIdProvider = map[IdProvider]string{
+	IdProviderIMDB:       imdb_title.MapColumn.IMDBId,
+	IdProviderTMDB:       imdb_title.MapColumn.TMDBId,
+	IdProviderTVDB:       imdb_title.MapColumn.TVDBId,
+	IdProviderTVMaze:     imdb_title.MapColumn.TVMazeId,
+	IdProviderTrakt:      imdb_title.MapColumn.TraktId,
+	IdProviderLetterboxd: imdb_title.MapColumn.LetterboxdId,
+}
 
-	idMap := IdMap{IMDB: id}
+var animeIdMapColumnByIdProvider = map[IdProvider]string{
+	IdProviderAniDB:       anime.IdMapColumn.AniDB,
+	IdProviderAniList:     anime.IdMapColumn.AniList,
+	IdProviderAniSearch:   anime.IdMapColumn.AniSearch,
+	IdProviderAnimePlanet: anime.IdMapColumn.AnimePlanet,
+	IdProviderKitsu:       anime.IdMapColumn.Kitsu,
+	IdProviderLiveChart:   anime.IdMapColumn.LiveChart,
+	IdProviderMAL:         anime.IdMapColumn.MAL,
+	IdProviderNotifyMoe:   anime.IdMapColumn.NotifyMoe,
+}
 
-	cacheKey := meta_type.GetIdProviderCacheKey(idProvider, idType, id)
-	if !idMapCache.Get(cacheKey, &idMap) {
-		switch idProvider {
-		case IdProviderIMDB:
-			idm, err := imdb_title.GetIdMapByIMDBId(id)
-			if err != nil || idm == nil {
-				return &idMap, err
-			}
-			idMap.Type = IdType(idm.Type.ToSimple())
-			idMap.IMDB = id
-			idMap.TMDB = idm.TMDBId
-			idMap.TVDB = idm.TVDBId
-			idMap.Trakt = idm.TraktId
-			idMap.Letterboxd = idm.LetterboxdId
-		case IdProviderTVDB:
-			idm, err := imdb_title.GetIdMapByTVDBId(imdb_title.IMDBTitleSimpleType(idType), id)
-			if err != nil || idm == nil {
-				return &idMap, err
-			}
-			idMap.Type = IdType(idm.Type.ToSimple())
-			idMap.IMDB = idm.IMDBId
-			idMap.TMDB = idm.TMDBId
-			idMap.TVDB = id
-			idMap.Trakt = idm.TraktId
-			idMap.Letterboxd = idm.LetterboxdId
-		case IdProviderTrakt:
-			idMaps, err := imdb_title.GetIdMapsByTraktIds(imdb_title.IMDBTitleSimpleType(idType), []string{id})
-			if err != nil {
-				return &idMap, err
-			}
-			idm := idMaps[id]
+func fromIMDBTitleMap(idType IdType, idm *imdb_title.IMDBTitleMap) IdMap {
+	idMap := IdMap{
+		Type:       IdType(idm.Type.ToSimple()),
+		IMDB:       idm.IMDBId,
+		TMDB:       idm.TMDBId,
+		TVDB:       idm.TVDBId,
+		TVMaze:     idm.TVMazeId,
+		Trakt:      idm.TraktId,
+		Letterboxd: idm.LetterboxdId,
+	}
+	if idMap.Type == IdTypeUnknown {
+		idMap.Type = idType
+	}
+	if idm.MALId != "" {
+		idMap.Anime = &IdMapAnime{MAL: idm.MALId}
+	}
+	return idMap
+}
+
+func fromAnimeIdMap(idType IdType, aim *anime.AnimeIdMap) IdMap {
+	idMap := IdMap{
+		Type:       idType,
+		IMDB:       aim.IMDB,
+		TMDB:       aim.TMDB,
+		TVDB:       aim.TVDB,
+		Trakt:      aim.Trakt,
+		Letterboxd: aim.Letterboxd,
+		Anime: &IdMapAnime{
+			AniDB:       aim.AniDB,
+			AniList:     aim.AniList,
+			AniSearch:   aim.AniSearch,
+			AnimePlanet: aim.AnimePlanet,
+			Kitsu:       aim.Kitsu,
+			LiveChart:   aim.LiveChart,
+			MAL:         aim.MAL,
+			NotifyMoe:   aim.NotifyMoe,
+		},
+	}
+	switch aim.Type {
+	case anime.AnimeIdMapTypeMovie:
+		idMap.Type = IdTypeMovie
+	case anime.AnimeIdMapTypeTV, anime.AnimeIdMapTypeTVShort:
+		idMap.Type = IdTypeShow
+	}
+	return idMap
+}
+
+func fetchIdMaps(idProvider IdProvider, idType IdType, ids []string) (map[string]IdMap, error) {
+	idMapById := make(map[string]IdMap, len(ids))
+
+	if column, ok := imdbTitleMapColumnByIdProvider[idProvider]; ok {
+		var idms map[string]imdb_title.IMDBTitleMap
+		var err error
+		if idProvider == IdProviderIMDB {
+			idms, err = imdb_title.GetIdMapsByIMDBId(ids)
+		} else {
+			idms, err = imdb_title.GetIdMapsByIds(column, imdb_title.IMDBTitleSimpleType(idType), ids)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for id, idm := range idms {
+			idMapById[id] = fromIMDBTitleMap(idType, &idm)
+		}
+		return idMapById, nil
+	}
+
+	column, ok := animeIdMapColumnByIdProvider[idProvider]
+	if !ok {
+		return nil, ErrorUnsupportedId
+	}
+	aims, err := anime.GetIdMapsByColumn(column, ids)
+	if err != nil {
+		return nil, err
+	}
+	imdbIds := []string{}
+	for i := range aims {
+		idMap := fromAnimeIdMap(idType, &aims[i])
+		idMapById[idMap.GetId(idProvider)] = idMap
+		if idMap.IMDB != "" {
+			imdbIds = append(imdbIds, idMap.IMDB)
+		}
+	}
+
+	idms, err := imdb_title.GetIdMapsByIMDBId(imdbIds)
+	if err != nil {
+		return nil, err
+	}
+	for id, idMap := range idMapById {
+		idm, ok := idms[idMap.IMDB]
+		if !ok {
+			continue
+		}
+		if idMap.Type == IdTypeUnknown {
 			idMap.Type = IdType(idm.Type.ToSimple())
-			idMap.IMDB = idm.IMDBId
-			idMap.TMDB = idm.TMDBId
-			idMap.TVDB = idm.TVDBId
-			idMap.Trakt = id
-			idMap.Letterboxd = idm.LetterboxdId
-		default:
+		}
+		idMap.TMDB = cmp.Or(idMap.TMDB, idm.TMDBId)
+		idMap.TVDB = cmp.Or(idMap.TVDB, idm.TVDBId)
+		idMap.TVMaze = cmp.Or(idMap.TVMaze, idm.TVMazeId)
+		idMap.Trakt = cmp.Or(idMap.Trakt, idm.TraktId)
+		idMap.Letterboxd = cmp.Or(idMap.Letterboxd, idm.LetterboxdId)
+		idMapById[id] = idMap
+	}
+
+	return idMapById, nil
+}
+
+// GetIdMaps resolves the id maps for ids from any supported provider, in the
+// same order as the ids. Unresolved ids get an id map with only that id.
+func GetIdMaps(idType IdType, idStrs []string) ([]IdMap, error) {
+	idMaps := make([]IdMap, len(idStrs))
+	missingIdxsByProvider := map[IdProvider][]int{}
+	for i, idStr := range idStrs {
+		idProvider, id := ParseId(idStr)
+		if idProvider == "" {
 			return nil, ErrorUnsupportedId
 		}
+		cacheKey := meta_type.GetIdProviderCacheKey(idProvider, idType, id)
+		if idMapCache.Get(cacheKey, &idMaps[i]) {
+			continue
+		}
+		idMaps[i] = IdMap{Type: idType}
+		idMaps[i].SetId(idProvider, id)
+		missingIdxsByProvider[idProvider] = append(missingIdxsByProvider[idProvider], i)
+	}
 
-		if err := idMapCache.Add(cacheKey, idMap); err != nil {
+	for idProvider, idxs := range missingIdxsByProvider {
+		ids := make([]string, 0, len(idxs))
+		seenIds := util.NewSet[string]()
+		for _, idx := range idxs {
+			id := idMaps[idx].GetId(idProvider)
+			if !seenIds.Has(id) {
+				seenIds.Add(id)
+				ids = append(ids, id)
+			}
+		}
+
+		idMapById, err := fetchIdMaps(idProvider, idType, ids)
+		if err != nil {
 			return nil, err
 		}
+		for _, idx := range idxs {
+			id := idMaps[idx].GetId(idProvider)
+			idMap, ok := idMapById[id]
+			if !ok {
+				continue
+			}
+			idMaps[idx] = idMap
+			cacheKey := meta_type.GetIdProviderCacheKey(idProvider, idType, id)
+			if err := idMapCache.Add(cacheKey, idMap); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return &idMap, nil
+	return idMaps, nil
+}
+
+func GetIdMap(idType IdType, idStr string) (*IdMap, error) {
+	idMaps, err := GetIdMaps(idType, []string{idStr})
+	if err != nil {
+		return nil, err
+	}
+	return &idMaps[0], nil
 }
 
 func SetIdMapsInTrx(tx db.Executor, idMaps []IdMap, anchor IdProvider) error {
@@ -135,6 +271,7 @@ func SetIdMapsInTrx(tx db.Executor, idMaps []IdMap, anchor IdProvider) error {
 			TVDBId:       idMap.TVDB,
 			TraktId:      idMap.Trakt,
 			LetterboxdId: idMap.Letterboxd,
+			TVMazeId:     idMap.TVMaze,
 		}
 		if idMap.Anime != nil && idMap.Anime.MAL != "" {
 			imdbMap.MALId = idMap.Anime.MAL
@@ -42,6 +42,71 @@ func handleIdMap(w http.ResponseWriter, r *http.Request) {
 	SendResponse(w, r, 200, idMap, nil)
 }
 
+const maxBatchIdMapItemCount = 500
+
+type BatchIdMapRequestItem struct {
+	Type meta.IdType `json:"type"`
+	Id   string      `json:"id"`
+}
+
+type BatchIdMapRequest struct {
+	Items []BatchIdMapRequestItem `json:"items"`
+}
+
+func handleBatchIdMap(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodPost) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	payload := &BatchIdMapRequest{}
+	if err := shared.ReadRequestBodyJSON(r, payload); err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	if len(payload.Items) == 0 {
+		shared.ErrorBadRequest(r, "missing items").Send(w, r)
+		return
+	}
+	if len(payload.Items) > maxBatchIdMapItemCount {
+		shared.ErrorBadRequest(r, "too many items, max "+strconv.Itoa(maxBatchIdMapItemCount)).Send(w, r)
+		return
+	}
+
+	idxsByType := map[meta.IdType][]int{}
+	for i := range payload.Items {
+		item := &payload.Items[i]
+		if !item.Type.IsValid() {
+			shared.ErrorBadRequest(r, "invalid type for items["+strconv.Itoa(i)+"]").Send(w, r)
+			return
+		}
+		if provider, _ := meta.ParseId(item.Id); provider == "" {
+			shared.ErrorBadRequest(r, "unsupported id for items["+strconv.Itoa(i)+"]").Send(w, r)
+			return
+		}
+		idxsByType[item.Type] = append(idxsByType[item.Type], i)
+	}
+
+	idMaps := make([]meta.IdMap, len(payload.Items))
+	for idType, idxs := range idxsByType {
+		ids := make([]string, len(idxs))
+		for i, idx := range idxs {
+			ids[i] = payload.Items[idx].Id
+		}
+		items, err := meta.GetIdMaps(idType, ids)
+		if err != nil {
+			shared.ErrorInternalServerError(r, "").WithCause(err).Send(w, r)
+			return
+		}
+		for i, idx := range idxs {
+			idMaps[idx] = items[i]
+		}
+	}
+
+	SendResponse(w, r, 200, idMaps, nil)
+}
+
 func commonMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := server.GetReqCtx(r)
@@ -54,6 +119,7 @@ func AddEndpoints(mux *http.ServeMux) {
 	router := http.NewServeMux()
 
 	router.HandleFunc("/{idType}/{id}", handleIdMap)
+	router.HandleFunc("/batch", handleBatchIdMap)
 
 	mux.Handle("/v0/meta/id-map/", http.StripPrefix("/v0/meta/id-map", commonMiddleware(router)))
 }
@@ -0,0 +1,62 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseId(t *testing.T) {
+	for _, tc := range []struct {
+		idStr    string
+		provider IdProvider
+		id       string
+	}{
+		{"tt0110912", IdProviderIMDB, "tt0110912"},
+		{"imdb:tt0110912", IdProviderIMDB, "tt0110912"},
+		{"tmdb:680", IdProviderTMDB, "680"},
+		{"tvdb:81189", IdProviderTVDB, "81189"},
+		{"tvmaze:169", IdProviderTVMaze, "169"},
+		{"trakt:1388", IdProviderTrakt, "1388"},
+		{"lboxd:2bbs", IdProviderLetterboxd, "2bbs"},
+		{"anidb:69", IdProviderAniDB, "69"},
+		{"anilist:21", IdProviderAniList, "21"},
+		{"animeplanet:one-piece", IdProviderAnimePlanet, "one-piece"},
+		{"kitsu:12", IdProviderKitsu, "12"},
+		{"mal:21", IdProviderMAL, "21"},
+		{"notifymoe:jdZp5KmiR", IdProviderNotifyMoe, "jdZp5KmiR"},
+		{"kitsu:", "", ""},
+		{"unknown:1", "", ""},
+		{"680", "", ""},
+	} {
+		t.Run(tc.idStr, func(t *testing.T) {
+			provider, id := ParseId(tc.idStr)
+			assert.Equal(t, tc.provider, provider)
+			assert.Equal(t, tc.id, id)
+		})
+	}
+}
+
+func TestIdMapGetSetId(t *testing.T) {
+	idMap := IdMap{}
+	for _, provider := range []IdProvider{
+		IdProviderIMDB,
+		IdProviderTMDB,
+		IdProviderTVDB,
+		IdProviderTVMaze,
+		IdProviderTrakt,
+		IdProviderLetterboxd,
+		IdProviderAniDB,
+		IdProviderAniList,
+		IdProviderAniSearch,
+		IdProviderAnimePlanet,
+		IdProviderKitsu,
+		IdProviderLiveChart,
+		IdProviderMAL,
+		IdProviderNotifyMoe,
+	} {
+		assert.Equal(t, "", idMap.GetId(provider))
+		idMap.SetId(provider, string(provider)+"-id")
+		assert.Equal(t, string(provider)+"-id", idMap.GetId(provider))
+	}
+}
@@ -33,3 +33,83 @@ type IdMap struct {
 	Letterboxd string      `json:"lboxd,omitempty"`
 	Anime      *IdMapAnime `json:"anime,omitempty"`
 }
+
+func (idMap *IdMap) GetId(provider IdProvider) string {
+	switch provider {
+	case IdProviderIMDB:
+		return idMap.IMDB
+	case IdProviderTMDB:
+		return idMap.TMDB
+	case IdProviderTVDB:
+		return idMap.TVDB
+	case IdProviderTVMaze:
+		return idMap.TVMaze
+	case IdProviderTrakt:
+		return idMap.Trakt
+	case IdProviderLetterboxd:
+		return idMap.Letterboxd
+	}
+	if idMap.Anime == nil {
+		return ""
+	}
+	switch provider {
+	case IdProviderAniDB:
+		return idMap.Anime.AniDB
+	case IdProviderAniList:
+		return idMap.Anime.AniList
+	case IdProviderAniSearch:
+		return idMap.Anime.AniSearch
+	case IdProviderAnimePlanet:
+		return idMap.Anime.AnimePlanet
+	case IdProviderKitsu:
+		return idMap.Anime.Kitsu
+	case IdProviderLiveChart:
+		return idMap.Anime.LiveChart
+	case IdProviderMAL:
+		return idMap.Anime.MAL
+	case IdProviderNotifyMoe:
+		return idMap.Anime.NotifyMoe
+	}
+	return ""
+}
+
+func (idMap *IdMap) SetId(provider IdProvider, id string) {
+	switch provider {
+	case IdProviderIMDB:
+		idMap.IMDB = id
+	case IdProviderTMDB:
+		idMap.TMDB = id
+	case IdProviderTVDB:
+		idMap.TVDB = id
+	case IdProviderTVMaze:
+		idMap.TVMaze = id
+	case IdProviderTrakt:
+		idMap.Trakt = id
+	case IdProviderLetterboxd:
+		idMap.Letterboxd = id
+	}
+	if !provider.IsAnime() {
+		return
+	}
+	if idMap.Anime == nil {
+		idMap.Anime = &IdMapAnime{}
+	}
+	switch provider {
+	case IdProviderAniDB:
+		idMap.Anime.AniDB = id
+	case IdProviderAniList:
+		idMap.Anime.AniList = id
+	case IdProviderAniSearch:
+		idMap.Anime.AniSearch = id
+	case IdProviderAnimePlanet:
+		idMap.Anime.AnimePlanet = id
+	case IdProviderKitsu:
+		idMap.Anime.Kitsu = id
+	case IdProviderLiveChart:
+		idMap.Anime.LiveChart = id
+	case IdProviderMAL:
+		idMap.Anime.MAL = id
+	case IdProviderNotifyMoe:
+		idMap.Anime.NotifyMoe = id
+	}
+}
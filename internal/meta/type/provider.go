@@ -1,5 +1,7 @@
 package meta_type
 
+import "slices"
+
 type Provider string
 
 const (
@@ -27,6 +29,27 @@ const (
 	IdProviderNotifyMoe   IdProvider = "notifymoe"
 )
 
+var idProviders = []IdProvider{
+	IdProviderIMDB,
+	IdProviderTMDB,
+	IdProviderTVDB,
+	IdProviderTVMaze,
+	IdProviderTrakt,
+	IdProviderLetterboxd,
+	IdProviderAniDB,
+	IdProviderAniList,
+	IdProviderAniSearch,
+	IdProviderAnimePlanet,
+	IdProviderKitsu,
+	IdProviderLiveChart,
+	IdProviderMAL,
+	IdProviderNotifyMoe,
+}
+
+func (ip IdProvider) IsValid() bool {
+	return slices.Contains(idProviders, ip)
+}
+
 func (ip IdProvider) IsAnime() bool {
 	return ip == IdProviderAniDB ||
 		ip == IdProviderAniList ||
@@ -42,13 +65,11 @@ func GetIdProviderCacheKey(idProvider IdProvider, idType IdType, id string) stri
 	switch idProvider {
 	case IdProviderIMDB:
 		return id
-	case IdProviderTrakt, IdProviderTVDB:
-		return string(idProvider) + ":" + string(idType) + ":" + id
 	default:
-		panic("unsupported id provider: " + string(idProvider))
+		return string(idProvider) + ":" + string(idType) + ":" + id
 	}
 }
 
 func (ip IdProvider) GetCacheKey(idMap IdMap) string {
-	return GetIdProviderCacheKey(ip, idMap.Type, idMap.IMDB)
+	return GetIdProviderCacheKey(ip, idMap.Type, idMap.GetId(ip))
 }
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "stremthru"
+
+var registry = prometheus.NewRegistry()
+
+var storeRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "store",
+	Name:      "request_duration_seconds",
+	Help:      "Latency of store API requests.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"store", "method", "status"})
+
+var storeRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "store",
+	Name:      "request_errors_total",
+	Help:      "Failed store API requests, by error code.",
+}, []string{"store", "code"})
+
+var contentProxyActiveConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "content_proxy",
+	Name:      "active_connections",
+	Help:      "Number of active content proxy connections.",
+})
+
+var contentProxyBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "content_proxy",
+	Name:      "bytes_total",
+	Help:      "Bytes written by the content proxy.",
+}, []string{"tunnel"})
+
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "requests_total",
+	Help:      "Cache lookups, by cache name and result.",
+}, []string{"cache", "result"})
+
+var workerRunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "worker",
+	Name:      "run_duration_seconds",
+	Help:      "Duration of worker runs.",
+	Buckets:   []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 3 * 3600, 6 * 3600},
+}, []string{"worker", "status"})
+
+var workerFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "worker",
+	Name:      "failures_total",
+	Help:      "Failed worker runs.",
+}, []string{"worker"})
+
+var torznabQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "torznab",
+	Name:      "query_duration_seconds",
+	Help:      "Latency of torznab indexer queries.",
+	Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60},
+}, []string{"indexer", "status"})
+
+var newznabQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "newznab",
+	Name:      "query_duration_seconds",
+	Help:      "Latency of newznab indexer queries.",
+	Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60},
+}, []string{"indexer", "status"})
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		storeRequestDuration,
+		storeRequestErrors,
+		contentProxyActiveConnections,
+		contentProxyBytes,
+		cacheRequests,
+		workerRunDuration,
+		workerFailures,
+		torznabQueryDuration,
+		newznabQueryDuration,
+	)
+}
+
+var handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+func Handler() http.Handler {
+	return handler
+}
+
+func getStatusLabel(res *http.Response) string {
+	if res == nil {
+		return "none"
+	}
+	return strconv.Itoa(res.StatusCode)
+}
+
+func ObserveStoreRequest(store, method string, start time.Time, res *http.Response, err error) {
+	storeRequestDuration.WithLabelValues(store, method, getStatusLabel(res)).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return
+	}
+	code := core.ErrorCodeUnknown
+	var sterr core.StremThruError
+	if errors.As(err, &sterr) {
+		if e := sterr.GetError(); e != nil && e.Code != "" {
+			code = e.Code
+		}
+	}
+	storeRequestErrors.WithLabelValues(store, string(code)).Inc()
+}
+
+func IncContentProxyConnection() {
+	contentProxyActiveConnections.Inc()
+}
+
+func DecContentProxyConnection() {
+	contentProxyActiveConnections.Dec()
+}
+
+func AddContentProxyBytes(tunnel string, bytes int64) {
+	if tunnel == "" {
+		tunnel = "none"
+	}
+	if bytes > 0 {
+		contentProxyBytes.WithLabelValues(tunnel).Add(float64(bytes))
+	}
+}
+
+func ObserveCacheGet(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+func ObserveWorkerRun(worker string, start time.Time, err error) {
+	status := "done"
+	if err != nil {
+		status = "failed"
+		workerFailures.WithLabelValues(worker).Inc()
+	}
+	workerRunDuration.WithLabelValues(worker, status).Observe(time.Since(start).Seconds())
+}
+
+func ObserveTorznabQuery(indexer string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	torznabQueryDuration.WithLabelValues(indexer, status).Observe(time.Since(start).Seconds())
+}
+
+func ObserveNewznabQuery(indexer string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	newznabQueryDuration.WithLabelValues(indexer, status).Observe(time.Since(start).Seconds())
+}
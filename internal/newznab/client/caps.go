@@ -0,0 +1,40 @@
+package newznab_client
+
+import (
+	"net/url"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+)
+
+// newznab caps follow the same schema as torznab caps
+type Caps = torznab_client.Caps
+
+type Function = torznab_client.Function
+
+const (
+	FunctionCaps        = torznab_client.FunctionCaps
+	FunctionSearch      = torznab_client.FunctionSearch
+	FunctionSearchTV    = torznab_client.FunctionSearchTV
+	FunctionSearchMovie = torznab_client.FunctionSearchMovie
+)
+
+type Error = torznab_client.Error
+
+type GetCapsParams struct {
+	Ctx
+}
+
+func (c *Client) getCaps(params *GetCapsParams) (request.APIResponse[Caps], error) {
+	params.Query = &url.Values{
+		"t": {string(FunctionCaps)},
+	}
+
+	var resp Response[Caps]
+	res, err := c.Request("GET", "/api", params, &resp)
+	return request.NewAPIResponse(res, resp.Data), err
+}
+
+func (c *Client) GetCaps() (Caps, error) {
+	return c.caps.Get()
+}
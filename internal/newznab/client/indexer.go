@@ -0,0 +1,154 @@
+package newznab_client
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type Newz struct {
+	Indexer string
+
+	Hash     string
+	Title    string
+	Size     int64
+	Grabs    int
+	Password bool
+	PubDate  time.Time
+
+	Link string // nzb url
+}
+
+// same as the hash used by torbox for usenet downloads added by link
+func HashNZBLink(link string) string {
+	sum := md5.Sum([]byte(link))
+	return hex.EncodeToString(sum[:])
+}
+
+type ChannelItem struct {
+	Title        string        `xml:"title"`
+	GUID         string        `xml:"guid"`
+	Link         string        `xml:"link"`
+	PubDate      string        `xml:"pubDate"` // Mon, 02 Jan 2006 15:04:05 -0700
+	Size         int64         `xml:"size"`
+	Enclosure    ItemEnclosure `xml:"enclosure"`
+	NewznabAttrs NewznabAttrs  `xml:"http://www.newznab.com/DTD/2010/feeds/attributes/ attr"`
+}
+
+func (o ChannelItem) ToNewz(indexer string) *Newz {
+	n := &Newz{}
+	n.Indexer = indexer
+	n.Title = o.Title
+	n.Size = o.Size
+	if n.Size == 0 {
+		n.Size = util.SafeParseInt64(o.NewznabAttrs.Get(NewznabAttrNameSize), 0)
+	}
+	if n.Size == 0 {
+		n.Size = o.Enclosure.Length
+	}
+	n.Grabs = util.SafeParseInt(o.NewznabAttrs.Get(NewznabAttrNameGrabs), 0)
+	n.Password = o.NewznabAttrs.Get(NewznabAttrNamePassword) == "1"
+	if pubDate, err := time.Parse(time.RFC1123Z, o.PubDate); err == nil {
+		n.PubDate = pubDate
+	}
+	n.Link = o.Enclosure.URL
+	if n.Link == "" {
+		n.Link = o.Link
+	}
+	if n.Link != "" {
+		n.Hash = HashNZBLink(n.Link)
+	}
+	return n
+}
+
+type SearchResponse struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Items []ChannelItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type Indexer interface {
+	GetId() string
+	NewSearchQuery(fn func(caps Caps) Function) (*Query, error)
+	Search(query *Query) ([]Newz, error)
+	FetchNZB(link string) ([]byte, error)
+}
+
+func (c *Client) GetId() string {
+	return "newznab/" + c.BaseURL.Host
+}
+
+func (c *Client) Search(query *Query) ([]Newz, error) {
+	params := &Ctx{}
+	q := url.Values{}
+	for key, values := range query.Values() {
+		q[key] = values
+	}
+	// newznab expects imdb id without the `tt` prefix
+	if imdbId := q.Get(SearchParamIMDBId); imdbId != "" {
+		q.Set(SearchParamIMDBId, strings.TrimPrefix(imdbId, "tt"))
+	}
+	params.Query = &q
+	var resp Response[SearchResponse]
+	start := time.Now()
+	_, err := c.Request("GET", "/api", params, &resp)
+	metrics.ObserveNewznabQuery(c.GetId(), start, err)
+	if err != nil {
+		return nil, err
+	}
+	items := resp.Data.Channel.Items
+	result := make([]Newz, 0, len(items))
+	for i := range items {
+		newz := items[i].ToNewz(c.GetId())
+		if newz.Link == "" || newz.Password {
+			continue
+		}
+		result = append(result, *newz)
+	}
+	return result, nil
+}
+
+const maxNZBFileSize = 20 * 1024 * 1024
+
+// FetchNZB downloads the nzb file, only for links served by the indexer.
+func (c *Client) FetchNZB(link string) ([]byte, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host != c.BaseURL.Host {
+		return nil, errors.New("nzb link does not belong to indexer")
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.reqHeader(&req.Header, nil)
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch nzb file: %s", res.Status)
+	}
+	blob, err := io.ReadAll(io.LimitReader(res.Body, maxNZBFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) > maxNZBFileSize {
+		return nil, fmt.Errorf("nzb file too large (max %d bytes)", maxNZBFileSize)
+	}
+	return blob, nil
+}
@@ -0,0 +1,52 @@
+package newznab_client
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelItemToNewz(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:newznab="http://www.newznab.com/DTD/2010/feeds/attributes/">
+  <channel>
+    <item>
+      <title>Movie.Title.2020.1080p.WEB-DL.DDP5.1.H.264-GRP</title>
+      <guid isPermaLink="true">https://indexer.example.com/details/abc</guid>
+      <link>https://indexer.example.com/getnzb/abc.nzb&amp;apikey=key</link>
+      <pubDate>Mon, 02 Jan 2006 15:04:05 -0700</pubDate>
+      <enclosure url="https://indexer.example.com/getnzb/abc.nzb&amp;apikey=key" length="4294967296" type="application/x-nzb"/>
+      <newznab:attr name="category" value="2000"/>
+      <newznab:attr name="size" value="4294967296"/>
+      <newznab:attr name="grabs" value="42"/>
+      <newznab:attr name="password" value="0"/>
+    </item>
+    <item>
+      <title>Protected.Release</title>
+      <enclosure url="https://indexer.example.com/getnzb/def.nzb" length="0" type="application/x-nzb"/>
+      <newznab:attr name="size" value="1024"/>
+      <newznab:attr name="password" value="1"/>
+    </item>
+  </channel>
+</rss>`
+
+	var resp SearchResponse
+	err := xml.Unmarshal([]byte(body), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Channel.Items, 2)
+
+	newz := resp.Channel.Items[0].ToNewz("newznab/indexer.example.com")
+	assert.Equal(t, "newznab/indexer.example.com", newz.Indexer)
+	assert.Equal(t, "Movie.Title.2020.1080p.WEB-DL.DDP5.1.H.264-GRP", newz.Title)
+	assert.Equal(t, int64(4294967296), newz.Size)
+	assert.Equal(t, 42, newz.Grabs)
+	assert.False(t, newz.Password)
+	assert.Equal(t, "https://indexer.example.com/getnzb/abc.nzb&apikey=key", newz.Link)
+	assert.Equal(t, HashNZBLink(newz.Link), newz.Hash)
+	assert.Equal(t, 2006, newz.PubDate.Year())
+
+	newz = resp.Channel.Items[1].ToNewz("")
+	assert.Equal(t, int64(1024), newz.Size)
+	assert.True(t, newz.Password)
+}
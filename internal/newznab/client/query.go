@@ -0,0 +1,18 @@
+package newznab_client
+
+import (
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+)
+
+type Query = torznab_client.Query
+
+type SearchParam = torznab_client.SearchParam
+
+const (
+	SearchParamQ      = torznab_client.SearchParamQ
+	SearchParamEp     = torznab_client.SearchParamEp
+	SearchParamSeason = torznab_client.SearchParamSeason
+	SearchParamYear   = torznab_client.SearchParamYear
+	SearchParamIMDBId = torznab_client.SearchParamIMDBId
+	SearchParamTVDBId = torznab_client.SearchParamTVDBId
+)
@@ -0,0 +1,38 @@
+package newznab_client
+
+type NewznabAttrName string
+
+const (
+	NewznabAttrNameCategory   NewznabAttrName = "category"
+	NewznabAttrNameSize       NewznabAttrName = "size"
+	NewznabAttrNameGUID       NewznabAttrName = "guid"
+	NewznabAttrNameGrabs      NewznabAttrName = "grabs"
+	NewznabAttrNamePassword   NewznabAttrName = "password"
+	NewznabAttrNameUsenetDate NewznabAttrName = "usenetdate"
+	NewznabAttrNameIMDB       NewznabAttrName = "imdb"
+	NewznabAttrNameTVDBId     NewznabAttrName = "tvdbid"
+	NewznabAttrNameSeason     NewznabAttrName = "season"
+	NewznabAttrNameEpisode    NewznabAttrName = "episode"
+)
+
+type NewznabAttr struct {
+	Name  NewznabAttrName `xml:"name,attr"`
+	Value string          `xml:"value,attr"`
+}
+
+type NewznabAttrs []NewznabAttr
+
+func (attrs NewznabAttrs) Get(name NewznabAttrName) string {
+	for _, attr := range attrs {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+type ItemEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"` // application/x-nzb
+}
@@ -155,7 +155,12 @@ func (ctx *Ctx) NewRequest(baseURL *url.URL, method, path string, header func(he
 
 	reqUrl.RawQuery = q.Encode()
 
-	req, err = http.NewRequestWithContext(ctx.GetContext(), method, reqUrl.String(), body)
+	reqCtx := ctx.GetContext()
+	if ctx.APIKey != "" {
+		reqCtx = WithTunnelStickyKey(reqCtx, ctx.APIKey)
+	}
+
+	req, err = http.NewRequestWithContext(reqCtx, method, reqUrl.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -186,3 +191,18 @@ func (ctx *Ctx) DoRequest(client *http.Client, req *http.Request) (*http.Respons
 	}
 	return client.Do(req)
 }
+
+type tunnelStickyKeyContextKey struct{}
+
+// WithTunnelStickyKey sets the key used for sticky selection from tunnel
+// proxy pools.
+func WithTunnelStickyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, tunnelStickyKeyContextKey{}, key)
+}
+
+func GetTunnelStickyKey(ctx context.Context) string {
+	if key, ok := ctx.Value(tunnelStickyKeyContextKey{}).(string); ok {
+		return key
+	}
+	return ""
+}
@@ -7,4 +7,5 @@ const (
 	HEADER_PROXY_AUTHORIZATION     = "Proxy-Authorization"
 	HEADER_STREMTHRU_AUTHORIZATION = "X-StremThru-Authorization"
 	HEADER_STREMTHRU_AUTHENTICATE  = "X-StremThru-Authenticate"
+	HEADER_STREMTHRU_STORE_MODE    = "X-StremThru-Store-Mode"
 )
@@ -89,3 +89,15 @@ var ErrorBadGateway = func(r *http.Request, msg string) *core.APIError {
 	err.StatusCode = http.StatusBadGateway
 	return err
 }
+
+var ErrorNotImplemented = func(r *http.Request, msg string) *core.APIError {
+	if msg == "" {
+		msg = "not implemented"
+	}
+
+	err := core.NewAPIError(msg)
+	err.InjectReq(r)
+	err.Code = core.ErrorCodeNotImplemented
+	err.StatusCode = http.StatusNotImplemented
+	return err
+}
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -11,11 +12,14 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/torrent_engine"
 )
 
 func IsMethod(r *http.Request, method string) bool {
@@ -160,8 +164,101 @@ var proxyHttpClientByTunnelType = map[config.TunnelType]*http.Client{
 	}(),
 }
 
+type countingResponseWriter struct {
+	http.ResponseWriter
+	bytesWritten int64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.bytesWritten += int64(n)
+	return n, err
+}
+
+func proxyTorrentResponse(w http.ResponseWriter, r *http.Request, link string) (bytesWritten int64, err error) {
+	stream, err := torrent_engine.Open(r.Context(), link)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+	defer stream.Close()
+
+	cw := &countingResponseWriter{ResponseWriter: w}
+	http.ServeContent(cw, r, stream.Name, time.Time{}, stream)
+	metrics.AddContentProxyBytes("torrent", cw.bytesWritten)
+	return cw.bytesWritten, nil
+}
+
 func ProxyResponse(w http.ResponseWriter, r *http.Request, url string, tunnelType config.TunnelType) (bytesWritten int64, err error) {
-	request, err := http.NewRequest(r.Method, url, nil)
+	return proxyResponse(w, r, url, tunnelType, nil)
+}
+
+func proxyManifestResponse(w http.ResponseWriter, r *http.Request, response *http.Response, mType manifestType, rewriter *manifestRewriter) (bytesWritten int64, err error) {
+	var body io.Reader = response.Body
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		gr, err := gzip.NewReader(response.Body)
+		if err != nil {
+			e := ErrorBadGateway(r, "failed to read manifest")
+			e.Cause = err
+			SendError(w, r, e)
+			return 0, err
+		}
+		defer gr.Close()
+		body = gr
+	}
+
+	blob, err := io.ReadAll(io.LimitReader(body, maxManifestSize+1))
+	if err != nil {
+		e := ErrorBadGateway(r, "failed to read manifest")
+		e.Cause = err
+		SendError(w, r, e)
+		return 0, err
+	}
+
+	isTooLarge := len(blob) > maxManifestSize
+	if !isTooLarge {
+		rewritten, err := rewriter.rewrite(mType, blob, response.Request.URL)
+		if err != nil && !errors.Is(err, errInvalidManifest) {
+			e := ErrorBadGateway(r, "failed to rewrite manifest")
+			e.Cause = err
+			SendError(w, r, e)
+			return 0, err
+		}
+		// not a manifest after all, the original body is returned
+		if err == nil {
+			blob = rewritten
+		}
+	}
+
+	copyHeaders(response.Header, w.Header(), false)
+	for _, key := range []string{"Content-Length", "Content-Encoding", "Content-Range", "Accept-Ranges", "Content-Md5", "Etag"} {
+		w.Header().Del(key)
+	}
+
+	// too large to be rewritten, passed through as is
+	if isTooLarge {
+		w.WriteHeader(response.StatusCode)
+		return io.Copy(w, io.MultiReader(bytes.NewReader(blob), body))
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(blob)))
+
+	w.WriteHeader(response.StatusCode)
+
+	n, err := w.Write(blob)
+	return int64(n), err
+}
+
+// proxyResponse proxies the url. If `createPrefixLink` is present, the uris
+// inside HLS playlists and DASH manifests are rewritten into proxy links.
+func proxyResponse(w http.ResponseWriter, r *http.Request, url string, tunnelType config.TunnelType, createPrefixLink func(dir string) (string, error)) (bytesWritten int64, err error) {
+	if torrent_engine.IsMagnetLink(url) {
+		e := ErrorBadRequest(r, "unsupported link")
+		SendError(w, r, e)
+		return 0, e
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, url, nil)
 	if err != nil {
 		e := ErrorInternalServerError(r, "failed to create request")
 		e.Cause = err
@@ -169,11 +266,16 @@ func ProxyResponse(w http.ResponseWriter, r *http.Request, url string, tunnelTyp
 		return
 	}
 
-	copyHeaders(r.Header, request.Header, true)
+	copyHeaders(r.Header, req.Header, true)
+
+	shouldRewriteManifest := createPrefixLink != nil && r.Method == http.MethodGet
+	if shouldRewriteManifest && isManifestLink(url) {
+		req.Header.Del("Accept-Encoding")
+	}
 
 	proxyHttpClient := proxyHttpClientByTunnelType[tunnelType]
 
-	response, err := proxyHttpClient.Do(request)
+	response, err := proxyHttpClient.Do(req)
 	if err != nil {
 		e := ErrorBadGateway(r, "failed to request url")
 		e.Cause = err
@@ -182,11 +284,21 @@ func ProxyResponse(w http.ResponseWriter, r *http.Request, url string, tunnelTyp
 	}
 	defer response.Body.Close()
 
+	if shouldRewriteManifest && response.StatusCode == http.StatusOK && isManifestEncodingSupported(response) {
+		if mType := getManifestType(response); mType != "" {
+			bytesWritten, err = proxyManifestResponse(w, r, response, mType, newManifestRewriter(createPrefixLink))
+			metrics.AddContentProxyBytes(string(tunnelType), bytesWritten)
+			return bytesWritten, err
+		}
+	}
+
 	copyHeaders(response.Header, w.Header(), false)
 
 	w.WriteHeader(response.StatusCode)
 
-	return io.Copy(w, response.Body)
+	bytesWritten, err = io.Copy(w, response.Body)
+	metrics.AddContentProxyBytes(string(tunnelType), bytesWritten)
+	return bytesWritten, err
 }
 
 func extractRequestScheme(r *http.Request) string {
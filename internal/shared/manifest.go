@@ -0,0 +1,373 @@
+package shared
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type manifestType string
+
+const (
+	manifestTypeHLS  manifestType = "hls"
+	manifestTypeDASH manifestType = "dash"
+)
+
+const maxManifestSize = 16 * 1024 * 1024
+
+var errInvalidManifest = errors.New("invalid manifest")
+
+func isManifestLink(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(path.Ext(u.Path)) {
+	case ".m3u8", ".m3u", ".mpd":
+		return true
+	}
+	return false
+}
+
+func getManifestType(res *http.Response) manifestType {
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	switch strings.ToLower(mediaType) {
+	case "application/vnd.apple.mpegurl", "application/x-mpegurl", "audio/mpegurl", "audio/x-mpegurl":
+		return manifestTypeHLS
+	case "application/dash+xml":
+		return manifestTypeDASH
+	case "", "application/octet-stream", "binary/octet-stream", "text/plain", "application/xml", "text/xml":
+		switch strings.ToLower(path.Ext(res.Request.URL.Path)) {
+		case ".m3u8", ".m3u":
+			return manifestTypeHLS
+		case ".mpd":
+			return manifestTypeDASH
+		}
+	}
+	return ""
+}
+
+func isManifestEncodingSupported(res *http.Response) bool {
+	switch strings.ToLower(res.Header.Get("Content-Encoding")) {
+	case "", "identity", "gzip":
+		return true
+	}
+	return false
+}
+
+// manifestRewriter turns the uris inside a manifest into proxy links. Uris
+// are proxied through a prefix link for their directory, so the same token
+// is shared by the segments in a directory and relative uris keep working.
+type manifestRewriter struct {
+	createPrefixLink func(dir string) (string, error)
+	prefixLinkByDir  map[string]string
+}
+
+func newManifestRewriter(createPrefixLink func(dir string) (string, error)) *manifestRewriter {
+	return &manifestRewriter{
+		createPrefixLink: createPrefixLink,
+		prefixLinkByDir:  map[string]string{},
+	}
+}
+
+// splitManifestLink splits the link at the last `/` before the query or
+// the first DASH template identifier.
+func splitManifestLink(link string) (dir, rest string) {
+	end := len(link)
+	if idx := strings.IndexAny(link, "?#$"); idx != -1 {
+		end = idx
+	}
+	idx := strings.LastIndex(link[:end], "/")
+	return link[:idx+1], link[idx+1:]
+}
+
+func (mr *manifestRewriter) proxify(link string) (string, error) {
+	dir, rest := splitManifestLink(link)
+	prefixLink, ok := mr.prefixLinkByDir[dir]
+	if !ok {
+		pLink, err := mr.createPrefixLink(dir)
+		if err != nil {
+			return "", err
+		}
+		prefixLink = pLink
+		mr.prefixLinkByDir[dir] = prefixLink
+	}
+	return prefixLink + rest, nil
+}
+
+func isHTTPLink(u *url.URL) bool {
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// resolve proxifies the uri relative to the base url. Non-http uris, e.g.
+// `data:` or `skd:`, are returned as is.
+func (mr *manifestRewriter) resolve(base *url.URL, uri string) (string, error) {
+	u, err := base.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if !isHTTPLink(u) {
+		return uri, nil
+	}
+	return mr.proxify(u.String())
+}
+
+var hlsURIAttributeRegex = regexp.MustCompile(`URI="([^"]*)"`)
+
+func (mr *manifestRewriter) rewriteHLS(body []byte, base *url.URL) ([]byte, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n"), []byte("#EXTM3U")) {
+		return nil, fmt.Errorf("%w: missing #EXTM3U", errInvalidManifest)
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(body))
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 64*1024), maxManifestSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		trimmedLine := strings.TrimSpace(line)
+		switch {
+		case trimmedLine == "":
+		case strings.HasPrefix(trimmedLine, "#"):
+			if strings.HasPrefix(trimmedLine, "#EXT") && strings.Contains(line, `URI="`) {
+				var rerr error
+				line = hlsURIAttributeRegex.ReplaceAllStringFunc(line, func(attr string) string {
+					uri := hlsURIAttributeRegex.FindStringSubmatch(attr)[1]
+					pUri, err := mr.resolve(base, uri)
+					if err != nil {
+						rerr = err
+						return attr
+					}
+					return `URI="` + pUri + `"`
+				})
+				if rerr != nil {
+					return nil, rerr
+				}
+			}
+		default:
+			pUri, err := mr.resolve(base, trimmedLine)
+			if err != nil {
+				return nil, err
+			}
+			line = pUri
+		}
+
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidManifest, err)
+	}
+
+	return out.Bytes(), nil
+}
+
+type dashEdit struct {
+	start int64
+	end   int64
+	value string
+}
+
+type dashFrame struct {
+	name    string
+	base    *url.URL
+	hasBase bool
+}
+
+// attributes holding segment uris, which may be DASH templates.
+var dashURIAttributesByElement = map[string][]string{
+	"SegmentTemplate":     {"media", "initialization", "index", "bitstreamSwitching"},
+	"SegmentURL":          {"media", "index"},
+	"Initialization":      {"sourceURL"},
+	"RepresentationIndex": {"sourceURL"},
+	"BitstreamSwitching":  {"sourceURL"},
+}
+
+var dashURIElements = []string{"BaseURL", "Location", "PatchLocation"}
+
+func escapeXML(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+func replaceXMLAttribute(tag, name, value string) string {
+	re := regexp.MustCompile(`(\s` + regexp.QuoteMeta(name) + `\s*=\s*)(?:"[^"]*"|'[^']*')`)
+	return re.ReplaceAllString(tag, "${1}"+strings.ReplaceAll(`"`+escapeXML(value)+`"`, "$", "$$"))
+}
+
+// isDASHTemplate checks if the uri has template identifiers, e.g. `$Number$`.
+func isDASHTemplate(uri string) bool {
+	return strings.Contains(uri, "$")
+}
+
+// isDASHURIResolvedByClient checks if the relative segment uri can be left to
+// the client. Only templates are left, as they resolve against the nearest
+// `BaseURL`, which may come later in the representations. Templates escaping
+// the `BaseURL`'s directory would escape the proxy link too.
+func isDASHURIResolvedByClient(uri string) bool {
+	if !isDASHTemplate(uri) || strings.HasPrefix(uri, "/") {
+		return false
+	}
+	p, _, _ := strings.Cut(uri, "?")
+	return !slices.Contains(strings.Split(p, "/"), "..")
+}
+
+// rewriteDASH rewrites the `BaseURL` elements and segment uris. Relative
+// segment uris are resolved against the effective base url, except simple
+// templates, which resolve against the rewritten `BaseURL` on the client. If
+// the MPD has no `BaseURL`, one is added for the manifest's location.
+func (mr *manifestRewriter) rewriteDASH(body []byte, base *url.URL) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.Strict = false
+
+	edits := []dashEdit{}
+	frames := []dashFrame{{base: base}}
+
+	var rootEnd int64 = -1
+	rootHasBase := false
+
+	for {
+		start := dec.InputOffset()
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidManifest, err)
+		}
+		end := dec.InputOffset()
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := &frames[len(frames)-1]
+			frames = append(frames, dashFrame{name: t.Name.Local, base: parent.base})
+			if len(frames) == 2 && t.Name.Local == "MPD" {
+				rootEnd = end
+			}
+
+			attrNames, ok := dashURIAttributesByElement[t.Name.Local]
+			if !ok {
+				continue
+			}
+			tag := string(body[start:end])
+			changed := false
+			for _, attr := range t.Attr {
+				if attr.Name.Space != "" || !slices.Contains(attrNames, attr.Name.Local) {
+					continue
+				}
+				uri := strings.TrimSpace(attr.Value)
+				u, err := url.Parse(uri)
+				if err != nil || uri == "" {
+					continue
+				}
+				if !u.IsAbs() {
+					if isDASHURIResolvedByClient(uri) {
+						continue
+					}
+					u = frames[len(frames)-1].base.ResolveReference(u)
+				}
+				if !isHTTPLink(u) {
+					continue
+				}
+				pUri, err := mr.proxify(u.String())
+				if err != nil {
+					return nil, err
+				}
+				tag = replaceXMLAttribute(tag, attr.Name.Local, pUri)
+				changed = true
+			}
+			if changed {
+				edits = append(edits, dashEdit{start: start, end: end, value: tag})
+			}
+		case xml.EndElement:
+			frames = frames[:len(frames)-1]
+		case xml.CharData:
+			if len(frames) < 3 {
+				continue
+			}
+			frame := &frames[len(frames)-1]
+			if !slices.Contains(dashURIElements, frame.name) {
+				continue
+			}
+			uri := strings.TrimSpace(string(t))
+			if uri == "" {
+				continue
+			}
+			parent := &frames[len(frames)-2]
+			u, err := parent.base.Parse(uri)
+			if err != nil {
+				return nil, err
+			}
+			if !isHTTPLink(u) {
+				continue
+			}
+			pUri, err := mr.proxify(u.String())
+			if err != nil {
+				return nil, err
+			}
+			edits = append(edits, dashEdit{start: start, end: end, value: escapeXML(pUri)})
+
+			if frame.name == "BaseURL" {
+				if len(frames) == 3 {
+					rootHasBase = true
+				}
+				if !parent.hasBase {
+					parent.base = u
+					parent.hasBase = true
+				}
+			}
+		}
+	}
+
+	if rootEnd == -1 {
+		return nil, fmt.Errorf("%w: missing MPD element", errInvalidManifest)
+	}
+
+	if !rootHasBase {
+		pUri, err := mr.proxify(base.String())
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, dashEdit{start: rootEnd, end: rootEnd, value: "<BaseURL>" + escapeXML(pUri) + "</BaseURL>"})
+	}
+
+	slices.SortStableFunc(edits, func(a, b dashEdit) int {
+		return int(a.start - b.start)
+	})
+
+	var out bytes.Buffer
+	out.Grow(len(body))
+	var offset int64
+	for _, edit := range edits {
+		out.Write(body[offset:edit.start])
+		out.WriteString(edit.value)
+		offset = edit.end
+	}
+	out.Write(body[offset:])
+
+	return out.Bytes(), nil
+}
+
+func (mr *manifestRewriter) rewrite(mType manifestType, body []byte, base *url.URL) ([]byte, error) {
+	switch mType {
+	case manifestTypeHLS:
+		return mr.rewriteHLS(body, base)
+	case manifestTypeDASH:
+		return mr.rewriteDASH(body, base)
+	default:
+		return nil, errors.New("unsupported manifest type")
+	}
+}
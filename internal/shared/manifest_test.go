@@ -0,0 +1,216 @@
+package shared
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManifestRewriter() *manifestRewriter {
+	return newManifestRewriter(func(dir string) (string, error) {
+		return "https://st.local/v0/proxy/" + strings.NewReplacer("https://", "", "/", "_").Replace(dir) + "/", nil
+	})
+}
+
+func TestSplitManifestLink(t *testing.T) {
+	for _, tc := range []struct {
+		link string
+		dir  string
+		rest string
+	}{
+		{"https://cdn.test/a/b/seg-1.ts", "https://cdn.test/a/b/", "seg-1.ts"},
+		{"https://cdn.test/a/seg.ts?sig=x/y", "https://cdn.test/a/", "seg.ts?sig=x/y"},
+		{"https://cdn.test/a/$RepresentationID$/$Number$.m4s", "https://cdn.test/a/", "$RepresentationID$/$Number$.m4s"},
+		{"https://cdn.test/a/", "https://cdn.test/a/", ""},
+	} {
+		t.Run(tc.link, func(t *testing.T) {
+			dir, rest := splitManifestLink(tc.link)
+			assert.Equal(t, tc.dir, dir)
+			assert.Equal(t, tc.rest, rest)
+		})
+	}
+}
+
+func TestManifestRewriterHLS(t *testing.T) {
+	base, _ := url.Parse("https://cdn.test/live/master.m3u8")
+
+	t.Run("master playlist", func(t *testing.T) {
+		body := strings.Join([]string{
+			"#EXTM3U",
+			`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aud",NAME="en",URI="audio/en.m3u8"`,
+			`#EXT-X-STREAM-INF:BANDWIDTH=1280000,AUDIO="aud"`,
+			"720p/index.m3u8?token=abc",
+			`#EXT-X-I-FRAME-STREAM-INF:BANDWIDTH=86000,URI="https://other.test/iframe.m3u8"`,
+			"",
+		}, "\n")
+		out, err := newTestManifestRewriter().rewriteHLS([]byte(body), base)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"#EXTM3U",
+			`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aud",NAME="en",URI="https://st.local/v0/proxy/cdn.test_live_audio_/en.m3u8"`,
+			`#EXT-X-STREAM-INF:BANDWIDTH=1280000,AUDIO="aud"`,
+			"https://st.local/v0/proxy/cdn.test_live_720p_/index.m3u8?token=abc",
+			`#EXT-X-I-FRAME-STREAM-INF:BANDWIDTH=86000,URI="https://st.local/v0/proxy/other.test_/iframe.m3u8"`,
+			"",
+		}, "\n"), string(out))
+	})
+
+	t.Run("media playlist", func(t *testing.T) {
+		body := strings.Join([]string{
+			"#EXTM3U",
+			"#EXT-X-TARGETDURATION:10",
+			`#EXT-X-KEY:METHOD=AES-128,URI="/keys/1.key"`,
+			`#EXT-X-MAP:URI="init.mp4"`,
+			"#EXTINF:10.0,",
+			"seg-1.m4s",
+			"#EXTINF:10.0,",
+			"seg-2.m4s",
+			`#EXT-X-KEY:METHOD=SAMPLE-AES,URI="skd://key"`,
+			"#EXT-X-ENDLIST",
+		}, "\r\n")
+		out, err := newTestManifestRewriter().rewriteHLS([]byte(body), base)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"#EXTM3U",
+			"#EXT-X-TARGETDURATION:10",
+			`#EXT-X-KEY:METHOD=AES-128,URI="https://st.local/v0/proxy/cdn.test_keys_/1.key"`,
+			`#EXT-X-MAP:URI="https://st.local/v0/proxy/cdn.test_live_/init.mp4"`,
+			"#EXTINF:10.0,",
+			"https://st.local/v0/proxy/cdn.test_live_/seg-1.m4s",
+			"#EXTINF:10.0,",
+			"https://st.local/v0/proxy/cdn.test_live_/seg-2.m4s",
+			`#EXT-X-KEY:METHOD=SAMPLE-AES,URI="skd://key"`,
+			"#EXT-X-ENDLIST",
+		}, "\n")+"\n", string(out))
+	})
+
+	t.Run("invalid playlist", func(t *testing.T) {
+		_, err := newTestManifestRewriter().rewriteHLS([]byte("<html></html>"), base)
+		assert.ErrorIs(t, err, errInvalidManifest)
+	})
+}
+
+func TestManifestRewriterDASH(t *testing.T) {
+	base, _ := url.Parse("https://cdn.test/vod/manifest.mpd")
+
+	t.Run("without base url", func(t *testing.T) {
+		body := `<?xml version="1.0"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static">
+  <Period>
+    <AdaptationSet>
+      <SegmentTemplate media="$RepresentationID$/$Number$.m4s" initialization="https://abs.test/init/$RepresentationID$.mp4?a=1&amp;b=2"/>
+      <Representation id="v1"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+		out, err := newTestManifestRewriter().rewriteDASH([]byte(body), base)
+		require.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static"><BaseURL>https://st.local/v0/proxy/cdn.test_vod_/manifest.mpd</BaseURL>
+  <Period>
+    <AdaptationSet>
+      <SegmentTemplate media="$RepresentationID$/$Number$.m4s" initialization="https://st.local/v0/proxy/abs.test_init_/$RepresentationID$.mp4?a=1&amp;b=2"/>
+      <Representation id="v1"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`, string(out))
+	})
+
+	t.Run("with base url", func(t *testing.T) {
+		body := `<MPD type="static">
+  <BaseURL>https://media.test/content/</BaseURL>
+  <Period>
+    <AdaptationSet>
+      <Representation id="v1">
+        <BaseURL>v1/video.mp4</BaseURL>
+        <SegmentBase indexRange="0-100"/>
+      </Representation>
+      <Representation id="v2">
+        <SegmentList>
+          <SegmentURL media="https://seg.test/v2/1.m4s"/>
+        </SegmentList>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+		out, err := newTestManifestRewriter().rewriteDASH([]byte(body), base)
+		require.NoError(t, err)
+		assert.Equal(t, `<MPD type="static">
+  <BaseURL>https://st.local/v0/proxy/media.test_content_/</BaseURL>
+  <Period>
+    <AdaptationSet>
+      <Representation id="v1">
+        <BaseURL>https://st.local/v0/proxy/media.test_content_v1_/video.mp4</BaseURL>
+        <SegmentBase indexRange="0-100"/>
+      </Representation>
+      <Representation id="v2">
+        <SegmentList>
+          <SegmentURL media="https://st.local/v0/proxy/seg.test_v2_/1.m4s"/>
+        </SegmentList>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>`, string(out))
+	})
+
+	t.Run("relative segment uris", func(t *testing.T) {
+		body := `<MPD type="static">
+  <Period>
+    <AdaptationSet contentType="video">
+      <SegmentList>
+        <Initialization sourceURL="init.mp4"/>
+        <SegmentURL media="/abs/seg.m4s"/>
+      </SegmentList>
+    </AdaptationSet>
+    <AdaptationSet contentType="audio">
+      <SegmentTemplate media="../audio/$Number$.m4s" initialization="$RepresentationID$/init.mp4"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+		out, err := newTestManifestRewriter().rewriteDASH([]byte(body), base)
+		require.NoError(t, err)
+		assert.Equal(t, `<MPD type="static"><BaseURL>https://st.local/v0/proxy/cdn.test_vod_/manifest.mpd</BaseURL>
+  <Period>
+    <AdaptationSet contentType="video">
+      <SegmentList>
+        <Initialization sourceURL="https://st.local/v0/proxy/cdn.test_vod_/init.mp4"/>
+        <SegmentURL media="https://st.local/v0/proxy/cdn.test_abs_/seg.m4s"/>
+      </SegmentList>
+    </AdaptationSet>
+    <AdaptationSet contentType="audio">
+      <SegmentTemplate media="https://st.local/v0/proxy/cdn.test_audio_/$Number$.m4s" initialization="$RepresentationID$/init.mp4"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`, string(out))
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		_, err := newTestManifestRewriter().rewriteDASH([]byte("#EXTM3U\n"), base)
+		assert.ErrorIs(t, err, errInvalidManifest)
+	})
+}
+
+func TestProxyManifestResponse(t *testing.T) {
+	t.Run("invalid manifest", func(t *testing.T) {
+		body := "#EXTINF:-1,Channel\nhttp://cdn.test/channel.ts\n"
+		req := httptest.NewRequest(http.MethodGet, "/v0/proxy/token", nil)
+		upstreamReq := httptest.NewRequest(http.MethodGet, "https://cdn.test/list.m3u", nil)
+		res := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"audio/x-mpegurl"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    upstreamReq,
+		}
+		w := httptest.NewRecorder()
+		_, err := proxyManifestResponse(w, req, res, manifestTypeHLS, newTestManifestRewriter())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, body, w.Body.String())
+	})
+}
@@ -0,0 +1,254 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/kv"
+)
+
+// Every proxy link carries the generation of its user at the time of
+// creation. Bumping the generation revokes every outstanding link.
+var proxyLinkGenerationStore = kv.NewKVStore[int](&kv.KVStoreConfig{
+	Type: "proxylinkgen",
+})
+
+// kept short, other instances accept the revoked links until it expires.
+var proxyLinkGenerationCache = func() cache.Cache[int] {
+	return cache.NewCache[int](&cache.CacheConfig{
+		Name:     "proxy:linkGeneration",
+		Lifetime: 5 * time.Second,
+	})
+}()
+
+func getProxyLinkGeneration(user string) (int, error) {
+	var gen int
+	if found := proxyLinkGenerationCache.Get(user, &gen); found {
+		return gen, nil
+	}
+	if err := proxyLinkGenerationStore.GetValue(user, &gen); err != nil {
+		return 0, err
+	}
+	proxyLinkGenerationCache.Add(user, gen)
+	return gen, nil
+}
+
+// RevokeProxyLinks invalidates every proxy link created for the user so far,
+// and returns the new generation.
+func RevokeProxyLinks(user string) (int, error) {
+	var gen int
+	if err := proxyLinkGenerationStore.GetValue(user, &gen); err != nil {
+		return 0, err
+	}
+	gen++
+	if err := proxyLinkGenerationStore.Set(user, gen); err != nil {
+		return 0, err
+	}
+	proxyLinkGenerationCache.Add(user, gen)
+	return gen, nil
+}
+
+// ParseProxyLinkIPBinding normalizes an IP address or CIDR subnet into the
+// subnet a proxy link is bound to.
+func ParseProxyLinkIPBinding(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return "", err
+		}
+		if prefix.Addr().Is4In6() {
+			if prefix.Bits() < 96 {
+				return "", errors.New("invalid ipv4-mapped subnet")
+			}
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked().String(), nil
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return "", err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+}
+
+func isProxyLinkIPAllowed(binding, clientIP string) bool {
+	if binding == "" {
+		return true
+	}
+	prefix, err := netip.ParsePrefix(binding)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return false
+	}
+	return prefix.Contains(addr.Unmap())
+}
+
+func verifyProxyLink(proxyLink *proxyLinkData, clientIP string) error {
+	if proxyLink.Exp != 0 && time.Now().Unix() >= proxyLink.Exp {
+		err := core.NewAPIError("unauthorized")
+		err.StatusCode = http.StatusUnauthorized
+		return err
+	}
+
+	if !isProxyLinkIPAllowed(proxyLink.IP, clientIP) {
+		err := core.NewAPIError("ip not allowed")
+		err.StatusCode = http.StatusForbidden
+		return err
+	}
+
+	gen, err := getProxyLinkGeneration(proxyLink.User)
+	if err != nil {
+		return err
+	}
+	if proxyLink.Gen != gen {
+		err := core.NewAPIError("revoked link")
+		err.StatusCode = http.StatusUnauthorized
+		return err
+	}
+
+	return nil
+}
+
+// resolveProxyLink verifies the links. Unsigned links can be edited by anyone
+// holding them, so those are never ip bound or expiring, only revocable.
+func resolveProxyLink(data *proxyLinkData, clientIP string, isUnsigned bool) (*ProxyLink, error) {
+	if isUnsigned {
+		data.IP = ""
+		data.Exp = 0
+		data.Torrent = false
+	}
+	if err := verifyProxyLink(data, clientIP); err != nil {
+		return nil, err
+	}
+	return newProxyLink(data), nil
+}
+
+type ProxyLink struct {
+	User       string
+	Link       string
+	Headers    map[string]string
+	TunnelType config.TunnelType
+	// IsPrefix is set for links that are used as a base, the rest of the
+	// request path is appended to the link.
+	IsPrefix bool
+	// IsTorrent is set for magnet links created for the torrent engine.
+	IsTorrent bool
+
+	gen       int
+	ip        string
+	expiresAt time.Time
+}
+
+func newProxyLink(data *proxyLinkData) *ProxyLink {
+	pl := &ProxyLink{
+		User:       data.User,
+		Link:       data.Value,
+		Headers:    data.Headers,
+		TunnelType: data.TunT,
+		IsPrefix:   data.Prefix,
+		IsTorrent:  data.Torrent,
+		gen:        data.Gen,
+		ip:         data.IP,
+	}
+	if data.Exp != 0 {
+		pl.expiresAt = time.Unix(data.Exp, 0)
+	}
+	return pl
+}
+
+// extensions of the manifests and segments reachable through prefix links
+var proxyLinkPrefixPathExtensions = map[string]struct{}{
+	".m3u8": {}, ".m3u": {}, ".mpd": {},
+	".ts": {}, ".mts": {}, ".m2ts": {}, ".m4s": {}, ".mp4": {}, ".m4v": {}, ".m4a": {},
+	".cmfv": {}, ".cmfa": {}, ".cmft": {}, ".aac": {}, ".ac3": {}, ".ec3": {}, ".mp3": {}, ".webm": {},
+	".vtt": {}, ".webvtt": {}, ".ttml": {},
+	".key": {},
+}
+
+// checkProxyLinkPrefixPath allows only the manifests and segments inside the
+// directory of the prefix link.
+func checkProxyLinkPrefixPath(escapedPath string) error {
+	name := ""
+	for segment := range strings.SplitSeq(escapedPath, "/") {
+		s, err := url.PathUnescape(segment)
+		if err != nil || s == "" || s == "." || s == ".." || strings.ContainsAny(s, "/\\") {
+			err := core.NewAPIError("invalid path")
+			err.StatusCode = http.StatusBadRequest
+			return err
+		}
+		name = s
+	}
+	if _, ok := proxyLinkPrefixPathExtensions[strings.ToLower(path.Ext(name))]; !ok {
+		err := core.NewAPIError("unsupported path")
+		err.StatusCode = http.StatusForbidden
+		return err
+	}
+	return nil
+}
+
+// GetURL returns the upstream url for the (escaped) request path after the
+// token.
+func (pl *ProxyLink) GetURL(escapedPath, rawQuery string) (string, error) {
+	if !pl.IsPrefix {
+		return pl.Link, nil
+	}
+	if err := checkProxyLinkPrefixPath(escapedPath); err != nil {
+		return "", err
+	}
+	link := pl.Link + escapedPath
+	if rawQuery != "" {
+		if strings.Contains(link, "?") {
+			link += "&" + rawQuery
+		} else {
+			link += "?" + rawQuery
+		}
+	}
+	return link, nil
+}
+
+// derive creates a proxy link for `link` with the same user, headers, tunnel
+// type, ip binding and expiration.
+func (pl *ProxyLink) derive(r *http.Request, link string, isPrefix bool) (string, error) {
+	expiresIn := time.Duration(0)
+	if !pl.expiresAt.IsZero() {
+		expiresIn = max(time.Until(pl.expiresAt), time.Second)
+	}
+	return createProxyLink(r, &createProxyLinkParams{
+		link:          link,
+		headers:       pl.Headers,
+		tunnelType:    pl.TunnelType,
+		expiresIn:     expiresIn,
+		user:          pl.User,
+		password:      config.ProxyAuthPassword.GetPassword(pl.User),
+		shouldEncrypt: true,
+		bindIP:        pl.ip,
+		gen:           pl.gen,
+		isPrefix:      isPrefix,
+	})
+}
+
+// ProxyLinkResponse proxies the link, as returned by `GetURL`, rewriting the
+// uris inside HLS playlists and DASH manifests into proxy links.
+func ProxyLinkResponse(w http.ResponseWriter, r *http.Request, pl *ProxyLink, link string) (bytesWritten int64, err error) {
+	if pl.IsTorrent {
+		return proxyTorrentResponse(w, r, pl.Link)
+	}
+	return proxyResponse(w, r, link, pl.TunnelType, func(dir string) (string, error) {
+		return pl.derive(r, dir, true)
+	})
+}
@@ -0,0 +1,155 @@
+package shared
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProxyLinkIPBinding(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		binding string
+		isErr   bool
+	}{
+		{"", "", false},
+		{"203.0.113.7", "203.0.113.7/32", false},
+		{"::ffff:203.0.113.7", "203.0.113.7/32", false},
+		{"2001:db8::1", "2001:db8::1/128", false},
+		{"203.0.113.7/24", "203.0.113.0/24", false},
+		{"::ffff:203.0.113.7/120", "203.0.113.0/24", false},
+		{"2001:db8::1/48", "2001:db8::/48", false},
+		{"::ffff:0.0.0.0/64", "", true},
+		{"203.0.113", "", true},
+		{"203.0.113.7/33", "", true},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			binding, err := ParseProxyLinkIPBinding(tc.value)
+			if tc.isErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.binding, binding)
+		})
+	}
+}
+
+func TestIsProxyLinkIPAllowed(t *testing.T) {
+	for _, tc := range []struct {
+		binding  string
+		clientIP string
+		allowed  bool
+	}{
+		{"", "203.0.113.7", true},
+		{"203.0.113.7/32", "203.0.113.7", true},
+		{"203.0.113.7/32", "203.0.113.8", false},
+		{"203.0.113.0/24", "203.0.113.200", true},
+		{"203.0.113.0/24", "::ffff:203.0.113.200", true},
+		{"203.0.113.0/24", "198.51.100.1", false},
+		{"2001:db8::/48", "2001:db8::42", true},
+		{"2001:db8::/48", "2001:db9::42", false},
+		{"203.0.113.0/24", "", false},
+		{"invalid", "203.0.113.7", false},
+	} {
+		t.Run(tc.binding+"|"+tc.clientIP, func(t *testing.T) {
+			assert.Equal(t, tc.allowed, isProxyLinkIPAllowed(tc.binding, tc.clientIP))
+		})
+	}
+}
+
+func requireProxyLinkErrorStatus(t *testing.T, err error, statusCode int) {
+	t.Helper()
+	var apiErr *core.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, statusCode, apiErr.StatusCode)
+}
+
+func TestVerifyProxyLink(t *testing.T) {
+	user := "test-verify-proxy-link"
+	proxyLinkGenerationCache.Add(user, 2)
+
+	t.Run("valid", func(t *testing.T) {
+		err := verifyProxyLink(&proxyLinkData{User: user, Gen: 2, IP: "203.0.113.0/24"}, "203.0.113.7")
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		err := verifyProxyLink(&proxyLinkData{User: user, Gen: 2, Exp: time.Now().Add(-time.Minute).Unix()}, "203.0.113.7")
+		requireProxyLinkErrorStatus(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("ip not allowed", func(t *testing.T) {
+		err := verifyProxyLink(&proxyLinkData{User: user, Gen: 2, IP: "203.0.113.0/24"}, "198.51.100.1")
+		requireProxyLinkErrorStatus(t, err, http.StatusForbidden)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		err := verifyProxyLink(&proxyLinkData{User: user, Gen: 1}, "203.0.113.7")
+		requireProxyLinkErrorStatus(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("future generation", func(t *testing.T) {
+		err := verifyProxyLink(&proxyLinkData{User: user, Gen: 3}, "203.0.113.7")
+		requireProxyLinkErrorStatus(t, err, http.StatusUnauthorized)
+	})
+}
+
+func TestResolveProxyLinkUnsigned(t *testing.T) {
+	user := "test-resolve-proxy-link-unsigned"
+	proxyLinkGenerationCache.Add(user, 4)
+
+	pl, err := resolveProxyLink(&proxyLinkData{User: user, Value: "https://cdn.test/a.mp4", Gen: 4, IP: "198.51.100.1/32", Exp: 1, Torrent: true}, "203.0.113.7", true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, pl.gen)
+	assert.Equal(t, "", pl.ip)
+	assert.True(t, pl.expiresAt.IsZero())
+	assert.False(t, pl.IsTorrent)
+
+	t.Run("revoked", func(t *testing.T) {
+		_, err := resolveProxyLink(&proxyLinkData{User: user, Value: "https://cdn.test/a.mp4", Gen: 3}, "203.0.113.7", true)
+		requireProxyLinkErrorStatus(t, err, http.StatusUnauthorized)
+	})
+}
+
+func TestProxyLinkGetURL(t *testing.T) {
+	pl := &ProxyLink{Link: "https://cdn.test/hls/", IsPrefix: true}
+
+	for _, tc := range []struct {
+		path     string
+		rawQuery string
+		link     string
+		status   int
+	}{
+		{"seg-1.ts", "", "https://cdn.test/hls/seg-1.ts", 0},
+		{"720p/index.m3u8", "t=1", "https://cdn.test/hls/720p/index.m3u8?t=1", 0},
+		{"video/init.MP4", "", "https://cdn.test/hls/video/init.MP4", 0},
+		{"", "", "", http.StatusBadRequest},
+		{"../secret.ts", "", "", http.StatusBadRequest},
+		{"a/%2e%2e/seg-1.ts", "", "", http.StatusBadRequest},
+		{"a%2F..%2Fseg-1.ts", "", "", http.StatusBadRequest},
+		{"a//seg-1.ts", "", "", http.StatusBadRequest},
+		{"secret.json", "", "", http.StatusForbidden},
+		{"720p/", "", "", http.StatusBadRequest},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			link, err := pl.GetURL(tc.path, tc.rawQuery)
+			if tc.status != 0 {
+				requireProxyLinkErrorStatus(t, err, tc.status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.link, link)
+		})
+	}
+
+	t.Run("not prefix", func(t *testing.T) {
+		link, err := (&ProxyLink{Link: "https://cdn.test/a.json"}).GetURL("../b.json", "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://cdn.test/a.json", link)
+	})
+}
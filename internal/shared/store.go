@@ -12,6 +12,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/context"
+	"github.com/MunifTanjim/stremthru/internal/torrent_engine"
 	"github.com/MunifTanjim/stremthru/store"
 	"github.com/MunifTanjim/stremthru/store/alldebrid"
 	"github.com/MunifTanjim/stremthru/store/debrider"
@@ -116,6 +117,10 @@ type proxyLinkTokenData struct {
 	EncLink    string            `json:"enc_link"`
 	EncFormat  string            `json:"enc_format"`
 	TunnelType config.TunnelType `json:"tunt,omitempty"`
+	Gen        int               `json:"gen,omitempty"`
+	IP         string            `json:"ip,omitempty"`
+	Prefix     bool              `json:"pfx,omitempty"`
+	Torrent    bool              `json:"tor,omitempty"`
 }
 
 type proxyLinkData struct {
@@ -123,17 +128,101 @@ type proxyLinkData struct {
 	Value   string            `json:"v"`
 	Headers map[string]string `json:"reqh,omitempty"`
 	TunT    config.TunnelType `json:"tunt,omitempty"`
+	Gen     int               `json:"gen,omitempty"`
+	IP      string            `json:"ip,omitempty"`
+	Prefix  bool              `json:"pfx,omitempty"`
+	Torrent bool              `json:"tor,omitempty"`
+	Exp     int64             `json:"exp,omitempty"`
+}
+
+type createProxyLinkParams struct {
+	link          string
+	headers       map[string]string
+	tunnelType    config.TunnelType
+	expiresIn     time.Duration
+	user          string
+	password      string
+	shouldEncrypt bool
+	filename      string
+	bindIP        string
+	gen           int
+	isPrefix      bool
+	isTorrent     bool
 }
 
 func CreateProxyLink(r *http.Request, link string, headers map[string]string, tunnelType config.TunnelType, expiresIn time.Duration, user, password string, shouldEncrypt bool, filename string) (string, error) {
+	return CreateBoundProxyLink(r, link, headers, tunnelType, expiresIn, user, password, shouldEncrypt, filename, "")
+}
+
+// CreateBoundProxyLink creates a proxy link that can only be accessed from
+// `bindIP`, an IP address or CIDR subnet.
+func CreateBoundProxyLink(r *http.Request, link string, headers map[string]string, tunnelType config.TunnelType, expiresIn time.Duration, user, password string, shouldEncrypt bool, filename string, bindIP string) (string, error) {
+	bindIP, err := ParseProxyLinkIPBinding(bindIP)
+	if err != nil {
+		return "", err
+	}
+
+	gen, err := getProxyLinkGeneration(user)
+	if err != nil {
+		return "", err
+	}
+
+	return createProxyLink(r, &createProxyLinkParams{
+		link:          link,
+		headers:       headers,
+		tunnelType:    tunnelType,
+		expiresIn:     expiresIn,
+		user:          user,
+		password:      password,
+		shouldEncrypt: shouldEncrypt,
+		filename:      filename,
+		bindIP:        bindIP,
+		gen:           gen,
+	})
+}
+
+// CreateTorrentProxyLink creates a proxy link that streams the magnet link
+// through the torrent engine.
+func CreateTorrentProxyLink(r *http.Request, link string, expiresIn time.Duration, user, password string, filename string) (string, error) {
+	gen, err := getProxyLinkGeneration(user)
+	if err != nil {
+		return "", err
+	}
+
+	return createProxyLink(r, &createProxyLinkParams{
+		link:          link,
+		tunnelType:    config.TUNNEL_TYPE_NONE,
+		expiresIn:     expiresIn,
+		user:          user,
+		password:      password,
+		shouldEncrypt: true,
+		filename:      filename,
+		gen:           gen,
+		isTorrent:     true,
+	})
+}
+
+func createProxyLink(r *http.Request, params *createProxyLinkParams) (string, error) {
 	var encodedToken string
 
-	if !shouldEncrypt && expiresIn == 0 {
+	link := params.link
+	headers := params.headers
+	password := params.password
+
+	if params.isTorrent != torrent_engine.IsMagnetLink(link) {
+		return "", ErrorBadRequest(r, "unsupported link")
+	}
+
+	// unsigned tokens can be edited, so ip bound and torrent links are
+	// always signed.
+	if !params.shouldEncrypt && params.expiresIn == 0 && params.bindIP == "" && !params.isTorrent {
 		blob, err := json.Marshal(proxyLinkData{
-			User:    user + ":" + password,
+			User:    params.user + ":" + password,
 			Value:   link,
 			Headers: headers,
-			TunT:    tunnelType,
+			TunT:    params.tunnelType,
+			Gen:     params.gen,
+			Prefix:  params.isPrefix,
 		})
 		if err != nil {
 			return "", err
@@ -150,7 +239,7 @@ func CreateProxyLink(r *http.Request, link string, headers map[string]string, tu
 		var encLink string
 		var encFormat string
 
-		if shouldEncrypt {
+		if params.shouldEncrypt {
 			encryptedLink, err := core.Encrypt(password, linkBlob)
 			if err != nil {
 				return "", err
@@ -165,16 +254,20 @@ func CreateProxyLink(r *http.Request, link string, headers map[string]string, tu
 		claims := core.JWTClaims[proxyLinkTokenData]{
 			RegisteredClaims: jwt.RegisteredClaims{
 				Issuer:  "stremthru",
-				Subject: user,
+				Subject: params.user,
 			},
 			Data: &proxyLinkTokenData{
 				EncLink:    encLink,
 				EncFormat:  encFormat,
-				TunnelType: tunnelType,
+				TunnelType: params.tunnelType,
+				Gen:        params.gen,
+				IP:         params.bindIP,
+				Prefix:     params.isPrefix,
+				Torrent:    params.isTorrent,
 			},
 		}
-		if expiresIn != 0 {
-			claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(expiresIn))
+		if params.expiresIn != 0 {
+			claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(params.expiresIn))
 		}
 		token, err := core.CreateJWT(password, claims)
 		if err != nil {
@@ -185,6 +278,13 @@ func CreateProxyLink(r *http.Request, link string, headers map[string]string, tu
 
 	pLink := ExtractRequestBaseURL(r).JoinPath("/v0/proxy", encodedToken)
 
+	// path after the prefix link is appended to the link, so it can not
+	// carry a filename.
+	if params.isPrefix {
+		return pLink.String() + "/", nil
+	}
+
+	filename := params.filename
 	if filename == "" {
 		filename, _, _ = strings.Cut(filepath.Base(link), "?")
 	}
@@ -208,6 +308,52 @@ func GenerateStremThruLink(r *http.Request, ctx *context.StoreContext, link stri
 		return nil, err
 	}
 
+	return wrapStremThruLink(r, ctx, data)
+}
+
+func GenerateStremThruNewzLink(r *http.Request, ctx *context.StoreContext, link string) (*store.GenerateLinkData, error) {
+	us, ok := store.AsUsenetStore(ctx.Store)
+	if !ok {
+		return nil, ErrorNotImplemented(r, "store does not support usenet")
+	}
+
+	params := &store.GenerateLinkParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.Link = link
+	if ctx.ClientIP != "" {
+		params.ClientIP = ctx.ClientIP
+	}
+
+	data, err := us.GenerateNewzLink(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapStremThruLink(r, ctx, data)
+}
+
+func GenerateStremThruWebDLLink(r *http.Request, ctx *context.StoreContext, link string) (*store.GenerateLinkData, error) {
+	ws, ok := store.AsWebDLStore(ctx.Store)
+	if !ok {
+		return nil, ErrorNotImplemented(r, "store does not support webdl")
+	}
+
+	params := &store.GenerateLinkParams{}
+	params.APIKey = ctx.StoreAuthToken
+	params.Link = link
+	if ctx.ClientIP != "" {
+		params.ClientIP = ctx.ClientIP
+	}
+
+	data, err := ws.GenerateWebDLLink(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapStremThruLink(r, ctx, data)
+}
+
+func wrapStremThruLink(r *http.Request, ctx *context.StoreContext, data *store.GenerateLinkData) (*store.GenerateLinkData, error) {
 	storeName := string(ctx.Store.GetName())
 	if config.StoreContentProxy.IsEnabled(storeName) && ctx.StoreAuthToken == config.StoreAuthToken.GetToken(ctx.ProxyAuthUser, storeName) {
 		if ctx.IsProxyAuthorized {
@@ -240,31 +386,42 @@ func getUserCredsFromJWT(t *jwt.Token) (user, password string, err error) {
 	return user, password, nil
 }
 
-func UnwrapProxyLinkToken(encodedToken string) (user string, link string, headers map[string]string, tunnelType config.TunnelType, err error) {
+func UnwrapProxyLinkToken(encodedToken string, clientIP string) (user string, link string, headers map[string]string, tunnelType config.TunnelType, err error) {
+	proxyLink, err := ResolveProxyLinkToken(encodedToken, clientIP)
+	if err != nil {
+		return "", "", nil, "", err
+	}
+	return proxyLink.User, proxyLink.Link, proxyLink.Headers, proxyLink.TunnelType, nil
+}
+
+func ResolveProxyLinkToken(encodedToken string, clientIP string) (*ProxyLink, error) {
+	encodedBlob, isUnsigned := strings.CutPrefix(encodedToken, "base64.")
+
 	proxyLink := &proxyLinkData{}
 	if found := proxyLinkTokenCache.Get(encodedToken, proxyLink); found {
-		return proxyLink.User, proxyLink.Value, proxyLink.Headers, proxyLink.TunT, nil
+		return resolveProxyLink(proxyLink, clientIP, isUnsigned)
 	}
 
-	if encodedBlob, ok := strings.CutPrefix(encodedToken, "base64."); ok {
+	if isUnsigned {
 		blob, err := core.Base64DecodeToByte(encodedBlob)
 		if err != nil {
-			return "", "", nil, "", err
+			return nil, err
 		}
 		if err := json.Unmarshal(blob, proxyLink); err != nil {
-			return "", "", nil, "", err
+			return nil, err
 		}
 		user, pass, _ := strings.Cut(proxyLink.User, ":")
 		if pass != config.ProxyAuthPassword.GetPassword(user) {
 			err := core.NewAPIError("unauthorized")
 			err.StatusCode = http.StatusUnauthorized
-			return "", "", nil, "", err
+			return nil, err
 		}
 		proxyLink.User = user
 	} else {
 		claims := &core.JWTClaims[proxyLinkTokenData]{}
-		password := ""
-		_, err = core.ParseJWT(func(t *jwt.Token) (any, error) {
+		user, password := "", ""
+		_, err := core.ParseJWT(func(t *jwt.Token) (any, error) {
+			var err error
 			user, password, err = getUserCredsFromJWT(t)
 			return []byte(password), err
 		}, encodedToken, claims)
@@ -277,20 +434,20 @@ func UnwrapProxyLinkToken(encodedToken string) (user string, link string, header
 				err = rerr
 			}
 
-			return "", "", nil, "", err
+			return nil, err
 		}
 
 		var linkBlob string
 		if claims.Data.EncFormat == "base64" {
 			blob, err := core.Base64Decode(claims.Data.EncLink)
 			if err != nil {
-				return "", "", nil, "", err
+				return nil, err
 			}
 			linkBlob = blob
 		} else {
 			blob, err := core.Decrypt(password, claims.Data.EncLink)
 			if err != nil {
-				return "", "", nil, "", err
+				return nil, err
 			}
 			linkBlob = blob
 		}
@@ -299,7 +456,14 @@ func UnwrapProxyLinkToken(encodedToken string) (user string, link string, header
 
 		proxyLink.User = user
 		proxyLink.TunT = claims.Data.TunnelType
+		proxyLink.Gen = claims.Data.Gen
+		proxyLink.IP = claims.Data.IP
+		proxyLink.Prefix = claims.Data.Prefix
+		proxyLink.Torrent = claims.Data.Torrent
 		proxyLink.Value = link
+		if claims.ExpiresAt != nil {
+			proxyLink.Exp = claims.ExpiresAt.Unix()
+		}
 
 		if hasHeaders {
 			proxyLink.Headers = map[string]string{}
@@ -313,5 +477,5 @@ func UnwrapProxyLinkToken(encodedToken string) (user string, link string, header
 
 	proxyLinkTokenCache.Add(encodedToken, *proxyLink)
 
-	return proxyLink.User, proxyLink.Value, proxyLink.Headers, proxyLink.TunT, nil
+	return resolveProxyLink(proxyLink, clientIP, isUnsigned)
 }
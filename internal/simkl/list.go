@@ -0,0 +1,414 @@
+package simkl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+var webItemTypeByItemType = map[ItemType]string{
+	ItemTypeMovies: "movies",
+	ItemTypeShows:  "tv",
+	ItemTypeAnime:  "anime",
+}
+
+var itemTypeByWebItemType = map[string]ItemType{
+	"movies": ItemTypeMovies,
+	"tv":     ItemTypeShows,
+	"anime":  ItemTypeAnime,
+}
+
+// e.g. `plantowatch` or `plan-to-watch`
+func ParseItemStatus(webStatus string) (ItemStatus, bool) {
+	status := ItemStatus(strings.ReplaceAll(webStatus, "-", ""))
+	switch status {
+	case ItemStatusWatching, ItemStatusPlanToWatch, ItemStatusCompleted, ItemStatusHold, ItemStatusDropped:
+		return status, true
+	case "onhold":
+		return ItemStatusHold, true
+	}
+	return "", false
+}
+
+func ParseItemType(webItemType string) (ItemType, bool) {
+	itemType, ok := itemTypeByWebItemType[webItemType]
+	return itemType, ok
+}
+
+var itemStatusName = map[ItemStatus]string{
+	ItemStatusWatching:    "Watching",
+	ItemStatusPlanToWatch: "Plan to Watch",
+	ItemStatusCompleted:   "Completed",
+	ItemStatusHold:        "On Hold",
+	ItemStatusDropped:     "Dropped",
+}
+
+var itemTypeName = map[ItemType]string{
+	ItemTypeMovies: "Movies",
+	ItemTypeShows:  "TV Shows",
+	ItemTypeAnime:  "Anime",
+}
+
+const ListTableName = "simkl_list"
+
+type SimklList struct {
+	Id        string       `json:"id"`
+	UpdatedAt db.Timestamp `json:"uat"`
+
+	Items []SimklItem `json:"-"`
+}
+
+func NewUserListId(userId string, itemType ItemType, status ItemStatus) string {
+	return userId + ":" + string(itemType) + ":" + string(status)
+}
+
+func (l *SimklList) parseId() (userId string, itemType ItemType, status ItemStatus) {
+	parts := strings.SplitN(l.Id, ":", 3)
+	if len(parts) != 3 {
+		return "", "", ""
+	}
+	return parts[0], ItemType(parts[1]), ItemStatus(parts[2])
+}
+
+func (l *SimklList) GetUserId() string {
+	userId, _, _ := l.parseId()
+	return userId
+}
+
+func (l *SimklList) GetItemType() ItemType {
+	_, itemType, _ := l.parseId()
+	return itemType
+}
+
+func (l *SimklList) GetURL() string {
+	userId, itemType, status := l.parseId()
+	if userId == "" {
+		return ""
+	}
+	return "https://simkl.com/" + userId + "/" + webItemTypeByItemType[itemType] + "/" + string(status)
+}
+
+func (l *SimklList) GetDisplayName() string {
+	_, itemType, status := l.parseId()
+	return "Simkl / " + itemTypeName[itemType] + " / " + itemStatusName[status]
+}
+
+func (l *SimklList) IsStale() bool {
+	return time.Now().After(l.UpdatedAt.Add(config.Integration.Simkl.ListStaleTime + util.GetRandomDuration(5*time.Second, 5*time.Minute)))
+}
+
+type ListColumnStruct struct {
+	Id        string
+	UpdatedAt string
+}
+
+var ListColumn = ListColumnStruct{
+	Id:        "id",
+	UpdatedAt: "uat",
+}
+
+var ListColumns = []string{
+	ListColumn.Id,
+	ListColumn.UpdatedAt,
+}
+
+const ItemTableName = "simkl_item"
+
+type SimklItemIds ItemIds
+
+func (ids SimklItemIds) Value() (driver.Value, error) {
+	return db.JSONValue(ids)
+}
+
+func (ids *SimklItemIds) Scan(value any) error {
+	return db.JSONScan(value, ids)
+}
+
+type SimklItem struct {
+	Id        int          `json:"id"`
+	Type      ItemType     `json:"type"`
+	Title     string       `json:"title"`
+	Year      int          `json:"year"`
+	Poster    string       `json:"poster"`
+	AnimeType string       `json:"anime_type"`
+	Ids       SimklItemIds `json:"ids"`
+	UpdatedAt db.Timestamp `json:"uat"`
+
+	Idx int `json:"-"`
+}
+
+func (i *SimklItem) PosterURL() string {
+	if i.Poster == "" {
+		return ""
+	}
+	return "https://simkl.in/posters/" + i.Poster + "_m.jpg"
+}
+
+type ItemColumnStruct struct {
+	Id        string
+	Type      string
+	Title     string
+	Year      string
+	Poster    string
+	AnimeType string
+	Ids       string
+	UpdatedAt string
+}
+
+var ItemColumn = ItemColumnStruct{
+	Id:        "id",
+	Type:      "type",
+	Title:     "title",
+	Year:      "year",
+	Poster:    "poster",
+	AnimeType: "anime_type",
+	Ids:       "ids",
+	UpdatedAt: "uat",
+}
+
+var ItemColumns = []string{
+	ItemColumn.Id,
+	ItemColumn.Type,
+	ItemColumn.Title,
+	ItemColumn.Year,
+	ItemColumn.Poster,
+	ItemColumn.AnimeType,
+	ItemColumn.Ids,
+	ItemColumn.UpdatedAt,
+}
+
+const ListItemTableName = "simkl_list_item"
+
+type ListItemColumnStruct struct {
+	ListId string
+	ItemId string
+	Idx    string
+}
+
+var ListItemColumn = ListItemColumnStruct{
+	ListId: "list_id",
+	ItemId: "item_id",
+	Idx:    "idx",
+}
+
+var query_get_list_by_id = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ?`,
+	db.JoinColumnNames(ListColumns...),
+	ListTableName,
+	ListColumn.Id,
+)
+
+func GetListById(id string) (*SimklList, error) {
+	var list SimklList
+	row := db.QueryRow(query_get_list_by_id, id)
+	if err := row.Scan(&list.Id, &list.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	items, err := getListItems(list.Id)
+	if err != nil {
+		return nil, err
+	}
+	list.Items = items
+	return &list, nil
+}
+
+var query_get_list_items = fmt.Sprintf(
+	`SELECT %s, li.%s FROM %s li JOIN %s i ON i.%s = li.%s WHERE li.%s = ? ORDER BY li.%s ASC`,
+	db.JoinPrefixedColumnNames("i.", ItemColumns...),
+	ListItemColumn.Idx,
+	ListItemTableName,
+	ItemTableName,
+	ItemColumn.Id,
+	ListItemColumn.ItemId,
+	ListItemColumn.ListId,
+	ListItemColumn.Idx,
+)
+
+func getListItems(listId string) ([]SimklItem, error) {
+	rows, err := db.Query(query_get_list_items, listId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []SimklItem{}
+	for rows.Next() {
+		var item SimklItem
+		if err := rows.Scan(
+			&item.Id,
+			&item.Type,
+			&item.Title,
+			&item.Year,
+			&item.Poster,
+			&item.AnimeType,
+			&item.Ids,
+			&item.UpdatedAt,
+			&item.Idx,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var query_upsert_list = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?) ON CONFLICT (%s) DO UPDATE SET %s = %s`,
+	ListTableName,
+	ListColumn.Id,
+	ListColumn.Id,
+	ListColumn.UpdatedAt,
+	db.CurrentTimestamp,
+)
+
+func UpsertList(list *SimklList) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		tErr := tx.Rollback()
+		err = errors.Join(tErr, err)
+	}()
+
+	_, err = tx.Exec(query_upsert_list, list.Id)
+	if err != nil {
+		return err
+	}
+
+	list.UpdatedAt = db.Timestamp{Time: time.Now()}
+
+	err = upsertItems(tx, list.Items)
+	if err != nil {
+		return err
+	}
+
+	err = setListItems(tx, list.Id, list.Items)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var query_upsert_items = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES `,
+	ItemTableName,
+	strings.Join(ItemColumns[0:len(ItemColumns)-1], ","),
+)
+var query_upsert_items_values_placeholder = "(" + util.RepeatJoin("?", len(ItemColumns)-1, ",") + ")"
+var query_upsert_items_on_conflict = fmt.Sprintf(
+	" ON CONFLICT (%s) DO UPDATE SET %s, %s = %s",
+	ItemColumn.Id,
+	strings.Join(
+		[]string{
+			fmt.Sprintf("%s = EXCLUDED.%s", ItemColumn.Type, ItemColumn.Type),
+			fmt.Sprintf("%s = EXCLUDED.%s", ItemColumn.Title, ItemColumn.Title),
+			fmt.Sprintf("%s = EXCLUDED.%s", ItemColumn.Year, ItemColumn.Year),
+			fmt.Sprintf("%s = EXCLUDED.%s", ItemColumn.Poster, ItemColumn.Poster),
+			fmt.Sprintf("%s = EXCLUDED.%s", ItemColumn.AnimeType, ItemColumn.AnimeType),
+			fmt.Sprintf("%s = EXCLUDED.%s", ItemColumn.Ids, ItemColumn.Ids),
+		},
+		", ",
+	),
+	ItemColumn.UpdatedAt,
+	db.CurrentTimestamp,
+)
+
+func upsertItems(tx db.Executor, items []SimklItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	for cItems := range slices.Chunk(items, 500) {
+		count := len(cItems)
+
+		query := query_upsert_items +
+			util.RepeatJoin(query_upsert_items_values_placeholder, count, ",") +
+			query_upsert_items_on_conflict
+
+		columnCount := len(ItemColumns) - 1
+		args := make([]any, count*columnCount)
+		for i := range cItems {
+			item := &cItems[i]
+			args[i*columnCount+0] = item.Id
+			args[i*columnCount+1] = item.Type
+			args[i*columnCount+2] = item.Title
+			args[i*columnCount+3] = item.Year
+			args[i*columnCount+4] = item.Poster
+			args[i*columnCount+5] = item.AnimeType
+			args[i*columnCount+6] = item.Ids
+		}
+
+		_, err := tx.Exec(query, args...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var query_delete_list_items = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	ListItemTableName,
+	ListItemColumn.ListId,
+)
+var query_set_list_items_before_values = fmt.Sprintf(
+	`INSERT INTO %s (%s, %s, %s) VALUES `,
+	ListItemTableName,
+	ListItemColumn.ListId,
+	ListItemColumn.ItemId,
+	ListItemColumn.Idx,
+)
+var query_set_list_items_values_placeholder = "(?,?,?)"
+var query_set_list_items_after_values = fmt.Sprintf(
+	` ON CONFLICT (%s, %s) DO UPDATE SET %s = EXCLUDED.%s`,
+	ListItemColumn.ListId,
+	ListItemColumn.ItemId,
+	ListItemColumn.Idx,
+	ListItemColumn.Idx,
+)
+
+func setListItems(tx *db.Tx, listId string, items []SimklItem) error {
+	if _, err := tx.Exec(query_delete_list_items, listId); err != nil {
+		return err
+	}
+
+	for cItems := range slices.Chunk(items, 500) {
+		count := len(cItems)
+		query := query_set_list_items_before_values +
+			util.RepeatJoin(query_set_list_items_values_placeholder, count, ",") +
+			query_set_list_items_after_values
+		args := make([]any, count*3)
+		for i := range cItems {
+			item := &cItems[i]
+			args[i*3+0] = listId
+			args[i*3+1] = item.Id
+			args[i*3+2] = item.Idx
+		}
+		if _, err := tx.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package simkl
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/anime"
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/worker/worker_queue"
+)
+
+var listCache = cache.NewCache[SimklList](&cache.CacheConfig{
+	Lifetime:      6 * time.Hour,
+	Name:          "simkl:list",
+	LocalCapacity: 1024,
+})
+
+func getAnimeIdMapType(animeType string) anime.AnimeIdMapType {
+	switch strings.ToLower(animeType) {
+	case "tv":
+		return anime.AnimeIdMapTypeTV
+	case "movie":
+		return anime.AnimeIdMapTypeMovie
+	case "ova":
+		return anime.AnimeIdMapTypeOVA
+	case "ona":
+		return anime.AnimeIdMapTypeONA
+	case "special":
+		return anime.AnimeIdMapTypeSpecial
+	case "music video":
+		return anime.AnimeIdMapTypeMusic
+	default:
+		return anime.AnimeIdMapTypeUnknown
+	}
+}
+
+func recordAnimeIdMaps(items []SimklItem) error {
+	idMaps := []anime.AnimeIdMap{}
+	for i := range items {
+		item := &items[i]
+		if item.Type != ItemTypeAnime || item.Ids.MAL == "" {
+			continue
+		}
+		idMaps = append(idMaps, anime.AnimeIdMap{
+			Type:    getAnimeIdMapType(item.AnimeType),
+			AniDB:   item.Ids.AniDB,
+			AniList: item.Ids.AniList,
+			IMDB:    item.Ids.IMDB,
+			Kitsu:   item.Ids.Kitsu,
+			MAL:     item.Ids.MAL,
+			TMDB:    item.Ids.TMDB,
+			TVDB:    item.Ids.TVDB,
+		})
+	}
+	if len(idMaps) == 0 {
+		return nil
+	}
+	return anime.BulkRecordIdMaps(idMaps, anime.IdMapColumn.MAL)
+}
+
+func ScheduleIdMapSync(items []SimklItem, idMapByMALId map[string]*anime.AnimeIdMap) {
+	for i := range items {
+		item := &items[i]
+		if item.Type != ItemTypeAnime || item.Ids.MAL == "" {
+			continue
+		}
+		if idMap, ok := idMapByMALId[item.Ids.MAL]; !ok || idMap.IsStale() {
+			worker_queue.AnimeIdMapperQueue.Queue(worker_queue.AnimeIdMapperQueueItem{
+				Service: anime.IdMapColumn.MAL,
+				Id:      item.Ids.MAL,
+			})
+		}
+	}
+}
+
+func getListCacheKey(l *SimklList) string {
+	return l.Id
+}
+
+var syncListMutex sync.Mutex
+
+func syncList(l *SimklList, tokenId string) error {
+	syncListMutex.Lock()
+	defer syncListMutex.Unlock()
+
+	userId, itemType, status := l.parseId()
+	if userId == "" {
+		return errors.New("invalid list id")
+	}
+
+	log.Debug("fetching list by id", "id", l.Id)
+	res, err := GetAPIClient(tokenId).GetAllItems(&GetAllItemsParams{
+		Type:   itemType,
+		Status: status,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := db.Timestamp{Time: time.Now()}
+	l.Items = nil
+	addItem := func(item *Item) {
+		if item.Ids.Simkl == 0 {
+			return
+		}
+		l.Items = append(l.Items, SimklItem{
+			Id:        item.Ids.Simkl,
+			Type:      itemType,
+			Title:     item.Title,
+			Year:      item.Year,
+			Poster:    item.Poster,
+			AnimeType: item.AnimeType,
+			Ids:       SimklItemIds(item.Ids),
+			UpdatedAt: now,
+			Idx:       len(l.Items),
+		})
+	}
+	switch itemType {
+	case ItemTypeMovies:
+		for i := range res.Data.Movies {
+			addItem(&res.Data.Movies[i].Movie)
+		}
+	case ItemTypeShows:
+		for i := range res.Data.Shows {
+			addItem(&res.Data.Shows[i].Show)
+		}
+	case ItemTypeAnime:
+		for i := range res.Data.Anime {
+			addItem(&res.Data.Anime[i].Show)
+		}
+	}
+
+	if err := recordAnimeIdMaps(l.Items); err != nil {
+		log.Error("failed to record idMaps", "error", err, "id", l.Id)
+	}
+
+	if err := UpsertList(l); err != nil {
+		return err
+	}
+
+	if err := listCache.Add(getListCacheKey(l), *l); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *SimklList) Fetch(tokenId string) error {
+	isMissing := false
+
+	listCacheKey := getListCacheKey(l)
+	var cachedL SimklList
+	if !listCache.Get(listCacheKey, &cachedL) {
+		if list, err := GetListById(l.Id); err != nil {
+			return err
+		} else if list == nil {
+			isMissing = true
+		} else {
+			*l = *list
+			log.Debug("found list by id", "id", l.Id, "is_stale", l.IsStale())
+			listCache.Add(listCacheKey, *l)
+		}
+	} else {
+		*l = cachedL
+	}
+
+	if !isMissing {
+		if l.IsStale() {
+			staleList := *l
+			go func() {
+				if err := syncList(&staleList, tokenId); err != nil {
+					log.Error("failed to sync stale list", "id", l.Id, "error", err)
+				}
+			}()
+		}
+		return nil
+	}
+
+	if err := syncList(l, tokenId); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func GetAnimeIdMaps(items []SimklItem) (map[string]*anime.AnimeIdMap, error) {
+	malIds := []int{}
+	for i := range items {
+		item := &items[i]
+		if item.Type != ItemTypeAnime || item.Ids.MAL == "" {
+			continue
+		}
+		if malId, err := strconv.Atoi(item.Ids.MAL); err == nil {
+			malIds = append(malIds, malId)
+		}
+	}
+	idMaps, err := anime.GetIdMapsForMAL(malIds)
+	if err != nil {
+		return nil, err
+	}
+	idMapByMALId := make(map[string]*anime.AnimeIdMap, len(idMaps))
+	for i := range idMaps {
+		idMap := &idMaps[i]
+		idMapByMALId[idMap.MAL] = idMap
+	}
+	return idMapByMALId, nil
+}
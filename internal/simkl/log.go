@@ -0,0 +1,7 @@
+package simkl
+
+import (
+	"github.com/MunifTanjim/stremthru/internal/logger"
+)
+
+var log = logger.Scoped("simkl")
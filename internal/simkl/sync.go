@@ -0,0 +1,151 @@
+package simkl
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type ItemType string
+
+const (
+	ItemTypeMovies ItemType = "movies"
+	ItemTypeShows  ItemType = "shows"
+	ItemTypeAnime  ItemType = "anime"
+)
+
+type ItemStatus string
+
+const (
+	ItemStatusWatching    ItemStatus = "watching"
+	ItemStatusPlanToWatch ItemStatus = "plantowatch"
+	ItemStatusCompleted   ItemStatus = "completed"
+	ItemStatusHold        ItemStatus = "hold"
+	ItemStatusDropped     ItemStatus = "dropped"
+)
+
+type ItemIds struct {
+	Simkl   int    `json:"simkl,omitempty"`
+	Slug    string `json:"slug,omitempty"`
+	IMDB    string `json:"imdb,omitempty"`
+	TMDB    string `json:"tmdb,omitempty"`
+	TVDB    string `json:"tvdb,omitempty"`
+	MAL     string `json:"mal,omitempty"`
+	AniDB   string `json:"anidb,omitempty"`
+	AniList string `json:"anilist,omitempty"`
+	Kitsu   string `json:"kitsu,omitempty"`
+}
+
+type Item struct {
+	Title     string  `json:"title"`
+	Year      int     `json:"year"`
+	Poster    string  `json:"poster,omitempty"`
+	AnimeType string  `json:"anime_type,omitempty"`
+	Ids       ItemIds `json:"ids"`
+}
+
+type WatchedEpisode struct {
+	Number    int        `json:"number"`
+	WatchedAt *time.Time `json:"watched_at,omitempty"`
+}
+
+type WatchedSeason struct {
+	Number   int              `json:"number"`
+	Episodes []WatchedEpisode `json:"episodes"`
+}
+
+type MovieItem struct {
+	LastWatchedAt *time.Time `json:"last_watched_at"`
+	Status        ItemStatus `json:"status"`
+	Movie         Item       `json:"movie"`
+}
+
+type ShowItem struct {
+	LastWatchedAt        *time.Time      `json:"last_watched_at"`
+	Status               ItemStatus      `json:"status"`
+	WatchedEpisodesCount int             `json:"watched_episodes_count"`
+	Show                 Item            `json:"show"`
+	Seasons              []WatchedSeason `json:"seasons,omitempty"`
+}
+
+type GetAllItemsData struct {
+	ResponseError
+	Movies []MovieItem `json:"movies"`
+	Shows  []ShowItem  `json:"shows"`
+	Anime  []ShowItem  `json:"anime"`
+}
+
+type GetAllItemsParams struct {
+	Ctx
+	Type     ItemType
+	Status   ItemStatus
+	DateFrom *time.Time
+}
+
+func (c APIClient) GetAllItems(params *GetAllItemsParams) (request.APIResponse[GetAllItemsData], error) {
+	path := "/sync/all-items"
+	if params.Type != "" {
+		path += "/" + string(params.Type)
+		if params.Status != "" {
+			path += "/" + string(params.Status)
+		}
+	}
+
+	params.Query = &url.Values{}
+	params.Query.Set("extended", "full")
+	params.Query.Set("episode_watched_at", "yes")
+	if params.DateFrom != nil {
+		params.Query.Set("date_from", params.DateFrom.UTC().Format(time.RFC3339))
+	}
+
+	response := GetAllItemsData{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type SyncHistoryParamsItem struct {
+	WatchedAt *time.Time `json:"watched_at,omitempty"`
+	Ids       ItemIds    `json:"ids"`
+}
+
+type SyncHistoryShow struct {
+	SyncHistoryParamsItem
+	Seasons []WatchedSeason `json:"seasons,omitempty"`
+}
+
+type SyncHistoryItemsCount struct {
+	Movies   int `json:"movies"`
+	Shows    int `json:"shows"`
+	Episodes int `json:"episodes"`
+}
+
+type SyncHistoryData struct {
+	ResponseError
+	Added    SyncHistoryItemsCount `json:"added"`
+	Deleted  SyncHistoryItemsCount `json:"deleted"`
+	NotFound struct {
+		Movies []SyncHistoryParamsItem `json:"movies"`
+		Shows  []SyncHistoryParamsItem `json:"shows"`
+	} `json:"not_found"`
+}
+
+type SyncHistoryParams struct {
+	Ctx
+	Movies []SyncHistoryParamsItem `json:"movies,omitempty"`
+	Shows  []SyncHistoryShow       `json:"shows,omitempty"`
+}
+
+func (c APIClient) AddToHistory(params *SyncHistoryParams) (request.APIResponse[SyncHistoryData], error) {
+	params.JSON = params
+	response := SyncHistoryData{}
+	res, err := c.Request("POST", "/sync/history", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+func (c APIClient) RemoveFromHistory(params *SyncHistoryParams) (request.APIResponse[SyncHistoryData], error) {
+	params.JSON = params
+	response := SyncHistoryData{}
+	res, err := c.Request("POST", "/sync/history/remove", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
@@ -0,0 +1,106 @@
+package store_retention
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+var log = logger.Scoped("store/retention")
+
+const AddedMagnetTableName = "store_added_magnet"
+
+var AddedMagnetColumn = struct {
+	Store   string
+	TokenId string
+	Hash    string
+	CAt     string
+}{
+	Store:   "store",
+	TokenId: "tid",
+	Hash:    "hash",
+	CAt:     "cat",
+}
+
+// store tokens are not persisted, only their hash is used to scope the
+// magnets to an account.
+func getTokenId(storeToken string) string {
+	hash := sha256.Sum256([]byte(storeToken))
+	return hex.EncodeToString(hash[:8])
+}
+
+var query_track_added_magnet = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?,?,?) ON CONFLICT DO NOTHING`,
+	AddedMagnetTableName,
+	db.JoinColumnNames(
+		AddedMagnetColumn.Store,
+		AddedMagnetColumn.TokenId,
+		AddedMagnetColumn.Hash,
+	),
+)
+
+// TrackAddedMagnet records that the magnet was added to the store by StremThru.
+func TrackAddedMagnet(storeCode store.StoreCode, storeToken string, hash string) {
+	if !config.Feature.HasVault() || storeToken == "" || hash == "" {
+		return
+	}
+	if _, err := db.Exec(query_track_added_magnet, storeCode, getTokenId(storeToken), hash); err != nil {
+		log.Error("failed to track added magnet", "error", err, "store", storeCode, "hash", hash)
+	}
+}
+
+var query_get_added_magnet_hashes = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = ?`,
+	AddedMagnetColumn.Hash,
+	AddedMagnetTableName,
+	AddedMagnetColumn.Store,
+	AddedMagnetColumn.TokenId,
+)
+
+func GetAddedMagnetHashes(storeCode store.StoreCode, storeToken string) (*util.Set[string], error) {
+	hashes := util.NewSet[string]()
+	rows, err := db.Query(query_get_added_magnet_hashes, storeCode, getTokenId(storeToken))
+	if err != nil {
+		return hashes, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return hashes, err
+		}
+		hashes.Add(hash)
+	}
+	return hashes, rows.Err()
+}
+
+var query_untrack_added_magnets = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ? AND %s IN `,
+	AddedMagnetTableName,
+	AddedMagnetColumn.Store,
+	AddedMagnetColumn.TokenId,
+	AddedMagnetColumn.Hash,
+)
+
+func UntrackAddedMagnets(storeCode store.StoreCode, storeToken string, hashes []string) error {
+	count := len(hashes)
+	if count == 0 {
+		return nil
+	}
+	query := query_untrack_added_magnets + "(" + util.RepeatJoin("?", count, ",") + ")"
+	args := make([]any, 2+count)
+	args[0] = storeCode
+	args[1] = getTokenId(storeToken)
+	for i, hash := range hashes {
+		args[2+i] = hash
+	}
+	_, err := db.Exec(query, args...)
+	return err
+}
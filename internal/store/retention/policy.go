@@ -0,0 +1,200 @@
+package store_retention
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+const PolicyTableName = "store_retention_policy"
+
+type Policy struct {
+	Username      string
+	Store         store.StoreName
+	Enabled       bool
+	DryRun        bool
+	OlderThanDays int
+	MaxItems      int
+	RemoveFailed  bool
+	OnlyAdded     bool
+	CAt           db.Timestamp
+	UAt           db.Timestamp
+}
+
+func (p *Policy) GetId() string {
+	return p.Username + ":" + string(p.Store)
+}
+
+func ParseId(id string) (username string, storeName store.StoreName, err error) {
+	username, name, ok := strings.Cut(id, ":")
+	if !ok || username == "" {
+		return "", "", fmt.Errorf("invalid id format: expected {username}:{store}")
+	}
+	storeName = store.StoreName(name)
+	if !storeName.IsValid() {
+		return "", "", fmt.Errorf("invalid store: %s", name)
+	}
+	return username, storeName, nil
+}
+
+var PolicyColumn = struct {
+	Username      string
+	Store         string
+	Enabled       string
+	DryRun        string
+	OlderThanDays string
+	MaxItems      string
+	RemoveFailed  string
+	OnlyAdded     string
+	CAt           string
+	UAt           string
+}{
+	Username:      "username",
+	Store:         "store",
+	Enabled:       "enabled",
+	DryRun:        "dry_run",
+	OlderThanDays: "older_than_days",
+	MaxItems:      "max_items",
+	RemoveFailed:  "remove_failed",
+	OnlyAdded:     "only_added",
+	CAt:           "cat",
+	UAt:           "uat",
+}
+
+var policyColumns = []string{
+	PolicyColumn.Username,
+	PolicyColumn.Store,
+	PolicyColumn.Enabled,
+	PolicyColumn.DryRun,
+	PolicyColumn.OlderThanDays,
+	PolicyColumn.MaxItems,
+	PolicyColumn.RemoveFailed,
+	PolicyColumn.OnlyAdded,
+	PolicyColumn.CAt,
+	PolicyColumn.UAt,
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPolicy(row scanner) (*Policy, error) {
+	p := Policy{}
+	if err := row.Scan(&p.Username, &p.Store, &p.Enabled, &p.DryRun, &p.OlderThanDays, &p.MaxItems, &p.RemoveFailed, &p.OnlyAdded, &p.CAt, &p.UAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+var query_get_all_policies = fmt.Sprintf(
+	`SELECT %s FROM %s ORDER BY %s, %s`,
+	strings.Join(policyColumns, ", "),
+	PolicyTableName,
+	PolicyColumn.Username,
+	PolicyColumn.Store,
+)
+
+func GetAllPolicies() ([]Policy, error) {
+	rows, err := db.Query(query_get_all_policies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Policy{}
+	for rows.Next() {
+		item, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+var query_get_policy = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = ?`,
+	strings.Join(policyColumns, ", "),
+	PolicyTableName,
+	PolicyColumn.Username,
+	PolicyColumn.Store,
+)
+
+func GetPolicy(username string, storeName store.StoreName) (*Policy, error) {
+	item, err := scanPolicy(db.QueryRow(query_get_policy, username, storeName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+var query_has_enabled_policy = fmt.Sprintf(
+	`SELECT 1 FROM %s WHERE %s = %s LIMIT 1`,
+	PolicyTableName,
+	PolicyColumn.Enabled,
+	db.BooleanTrue,
+)
+
+func HasEnabledPolicy() bool {
+	var one int
+	err := db.QueryRow(query_has_enabled_policy).Scan(&one)
+	return err == nil
+}
+
+var query_upsert_policy = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES (?,?,?,?,?,?,?,?) ON CONFLICT (%s, %s) DO UPDATE SET %s`,
+	PolicyTableName,
+	db.JoinColumnNames(
+		PolicyColumn.Username,
+		PolicyColumn.Store,
+		PolicyColumn.Enabled,
+		PolicyColumn.DryRun,
+		PolicyColumn.OlderThanDays,
+		PolicyColumn.MaxItems,
+		PolicyColumn.RemoveFailed,
+		PolicyColumn.OnlyAdded,
+	),
+	PolicyColumn.Username,
+	PolicyColumn.Store,
+	strings.Join([]string{
+		fmt.Sprintf(`%s = EXCLUDED.%s`, PolicyColumn.Enabled, PolicyColumn.Enabled),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, PolicyColumn.DryRun, PolicyColumn.DryRun),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, PolicyColumn.OlderThanDays, PolicyColumn.OlderThanDays),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, PolicyColumn.MaxItems, PolicyColumn.MaxItems),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, PolicyColumn.RemoveFailed, PolicyColumn.RemoveFailed),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, PolicyColumn.OnlyAdded, PolicyColumn.OnlyAdded),
+		fmt.Sprintf(`%s = %s`, PolicyColumn.UAt, db.CurrentTimestamp),
+	}, ", "),
+)
+
+func (p *Policy) Upsert() error {
+	_, err := db.Exec(query_upsert_policy,
+		p.Username,
+		p.Store,
+		p.Enabled,
+		p.DryRun,
+		p.OlderThanDays,
+		p.MaxItems,
+		p.RemoveFailed,
+		p.OnlyAdded,
+	)
+	return err
+}
+
+var query_delete_policy = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ?`,
+	PolicyTableName,
+	PolicyColumn.Username,
+	PolicyColumn.Store,
+)
+
+func DeletePolicy(username string, storeName store.StoreName) error {
+	_, err := db.Exec(query_delete_policy, username, storeName)
+	return err
+}
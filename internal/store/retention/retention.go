@@ -0,0 +1,176 @@
+package store_retention
+
+import (
+	"slices"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+type RemovalReason string
+
+const (
+	RemovalReasonFailed    RemovalReason = "failed"
+	RemovalReasonOlderThan RemovalReason = "older_than"
+	RemovalReasonMaxItems  RemovalReason = "max_items"
+)
+
+type RemovalCandidate struct {
+	Id     string        `json:"id"`
+	Hash   string        `json:"hash"`
+	Name   string        `json:"name"`
+	Reason RemovalReason `json:"reason"`
+}
+
+func (p *Policy) HasRule() bool {
+	return p.RemoveFailed || p.OlderThanDays > 0 || p.MaxItems > 0
+}
+
+// GetRemovalCandidates returns the items to remove from the store. With
+// OnlyAdded, items not added by StremThru are neither removed nor counted
+// towards MaxItems.
+func (p *Policy) GetRemovalCandidates(items []store.ListMagnetsDataItem, addedHashes *util.Set[string], now time.Time) []RemovalCandidate {
+	candidates := []RemovalCandidate{}
+	kept := []*store.ListMagnetsDataItem{}
+
+	olderThan := now.AddDate(0, 0, -p.OlderThanDays)
+	for i := range items {
+		item := &items[i]
+		if p.OnlyAdded && (addedHashes == nil || !addedHashes.Has(item.Hash)) {
+			continue
+		}
+		reason := RemovalReason("")
+		switch {
+		case p.RemoveFailed && (item.Status == store.MagnetStatusFailed || item.Status == store.MagnetStatusInvalid):
+			reason = RemovalReasonFailed
+		case p.OlderThanDays > 0 && !item.AddedAt.IsZero() && item.AddedAt.Before(olderThan):
+			reason = RemovalReasonOlderThan
+		}
+		if reason == "" {
+			kept = append(kept, item)
+			continue
+		}
+		candidates = append(candidates, RemovalCandidate{
+			Id:     item.Id,
+			Hash:   item.Hash,
+			Name:   item.Name,
+			Reason: reason,
+		})
+	}
+
+	if p.MaxItems > 0 && len(kept) > p.MaxItems {
+		slices.SortStableFunc(kept, func(a, b *store.ListMagnetsDataItem) int {
+			return b.AddedAt.Compare(a.AddedAt)
+		})
+		for _, item := range kept[p.MaxItems:] {
+			candidates = append(candidates, RemovalCandidate{
+				Id:     item.Id,
+				Hash:   item.Hash,
+				Name:   item.Name,
+				Reason: RemovalReasonMaxItems,
+			})
+		}
+	}
+
+	return candidates
+}
+
+const maxResultCandidates = 100
+
+type Result struct {
+	Id             string             `json:"id"`
+	DryRun         bool               `json:"dry_run"`
+	TotalItems     int                `json:"total_items"`
+	CandidateCount int                `json:"candidate_count"`
+	Candidates     []RemovalCandidate `json:"candidates"`
+	Removed        int                `json:"removed"`
+	Failed         int                `json:"failed"`
+	Error          string             `json:"error,omitempty"`
+}
+
+const list_magnets_limit = 500
+
+func listMagnets(s store.Store, storeToken string) ([]store.ListMagnetsDataItem, error) {
+	items := []store.ListMagnetsDataItem{}
+	offset := 0
+	for {
+		params := &store.ListMagnetsParams{
+			Limit:  list_magnets_limit,
+			Offset: offset,
+		}
+		params.APIKey = storeToken
+		res, err := s.ListMagnets(params)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, res.Items...)
+		offset += list_magnets_limit
+		if len(res.Items) < list_magnets_limit || offset >= res.TotalItems {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return items, nil
+}
+
+// Apply lists the magnets in the store and removes the ones matching the
+// policy, nothing is removed in dry run.
+func (p *Policy) Apply(s store.Store, storeToken string) *Result {
+	result := &Result{
+		Id:         p.GetId(),
+		DryRun:     p.DryRun,
+		Candidates: []RemovalCandidate{},
+	}
+
+	if !p.HasRule() {
+		return result
+	}
+
+	items, err := listMagnets(s, storeToken)
+	if err != nil {
+		result.Error = "failed to list magnets: " + err.Error()
+		return result
+	}
+	result.TotalItems = len(items)
+
+	var addedHashes *util.Set[string]
+	if p.OnlyAdded {
+		addedHashes, err = GetAddedMagnetHashes(s.GetName().Code(), storeToken)
+		if err != nil {
+			result.Error = "failed to get added magnets: " + err.Error()
+			return result
+		}
+	}
+
+	candidates := p.GetRemovalCandidates(items, addedHashes, time.Now())
+	result.CandidateCount = len(candidates)
+	result.Candidates = candidates[:min(len(candidates), maxResultCandidates)]
+
+	if p.DryRun {
+		return result
+	}
+
+	removedHashes := []string{}
+	for i := range candidates {
+		c := &candidates[i]
+		params := &store.RemoveMagnetParams{
+			Id: c.Id,
+		}
+		params.APIKey = storeToken
+		if _, err := s.RemoveMagnet(params); err != nil {
+			log.Error("failed to remove magnet", "error", err, "policy", result.Id, "id", c.Id, "hash", c.Hash)
+			result.Failed++
+		} else {
+			result.Removed++
+			removedHashes = append(removedHashes, c.Hash)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	if err := UntrackAddedMagnets(s.GetName().Code(), storeToken, removedHashes); err != nil {
+		log.Error("failed to untrack removed magnets", "error", err, "policy", result.Id)
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+package store_retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRemovalCandidates(t *testing.T) {
+	now := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	items := []store.ListMagnetsDataItem{
+		{Id: "1", Hash: "h1", Status: store.MagnetStatusDownloaded, AddedAt: daysAgo(1)},
+		{Id: "2", Hash: "h2", Status: store.MagnetStatusFailed, AddedAt: daysAgo(2)},
+		{Id: "3", Hash: "h3", Status: store.MagnetStatusDownloaded, AddedAt: daysAgo(40)},
+		{Id: "4", Hash: "h4", Status: store.MagnetStatusDownloaded, AddedAt: daysAgo(5)},
+		{Id: "5", Hash: "h5", Status: store.MagnetStatusDownloaded, AddedAt: daysAgo(3)},
+		{Id: "6", Hash: "h6", Status: store.MagnetStatusInvalid, AddedAt: daysAgo(50)},
+	}
+
+	addedHashes := util.NewSet[string]()
+	addedHashes.Add("h1")
+	addedHashes.Add("h2")
+	addedHashes.Add("h4")
+
+	getCandidateIds := func(candidates []RemovalCandidate) map[string]RemovalReason {
+		ids := map[string]RemovalReason{}
+		for _, c := range candidates {
+			ids[c.Id] = c.Reason
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name   string
+		policy Policy
+		result map[string]RemovalReason
+	}{
+		{
+			name:   "no rule",
+			policy: Policy{},
+			result: map[string]RemovalReason{},
+		},
+		{
+			name:   "remove failed",
+			policy: Policy{RemoveFailed: true},
+			result: map[string]RemovalReason{
+				"2": RemovalReasonFailed,
+				"6": RemovalReasonFailed,
+			},
+		},
+		{
+			name:   "older than",
+			policy: Policy{OlderThanDays: 30},
+			result: map[string]RemovalReason{
+				"3": RemovalReasonOlderThan,
+				"6": RemovalReasonOlderThan,
+			},
+		},
+		{
+			name:   "max items",
+			policy: Policy{RemoveFailed: true, MaxItems: 2},
+			result: map[string]RemovalReason{
+				"2": RemovalReasonFailed,
+				"6": RemovalReasonFailed,
+				"4": RemovalReasonMaxItems,
+				"3": RemovalReasonMaxItems,
+			},
+		},
+		{
+			name:   "only added",
+			policy: Policy{OnlyAdded: true, RemoveFailed: true, MaxItems: 1},
+			result: map[string]RemovalReason{
+				"2": RemovalReasonFailed,
+				"4": RemovalReasonMaxItems,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates := tc.policy.GetRemovalCandidates(items, addedHashes, now)
+			assert.Equal(t, tc.result, getCandidateIds(candidates))
+		})
+	}
+}
@@ -6,23 +6,23 @@ import (
 	"strings"
 	"time"
 
-	"github.com/MunifTanjim/stremthru/core"
-	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	stremio_api "github.com/MunifTanjim/stremthru/internal/stremio/api"
 	stremio_userdata_account "github.com/MunifTanjim/stremthru/internal/stremio/userdata/account"
+	"github.com/MunifTanjim/stremthru/internal/sync/stremio_simkl"
 	"github.com/MunifTanjim/stremthru/internal/sync/stremio_stremio"
 	"github.com/MunifTanjim/stremthru/internal/sync/stremio_trakt"
+	"github.com/MunifTanjim/stremthru/internal/vault"
 )
 
 var stremioClient = stremio_api.NewClient(&stremio_api.ClientConfig{})
 
 func encrypt(value string) (string, error) {
-	return core.Encrypt(config.VaultSecret, value)
+	return vault.Encrypt(value)
 }
 
 func decrypt(value string) (string, error) {
-	return core.Decrypt(config.VaultSecret, value)
+	return vault.Decrypt(value)
 }
 
 const TableName = "stremio_account"
@@ -306,5 +306,40 @@ func Delete(id string) error {
 	if err := sync_stremio_stremio.UnlinkByStremioAccount(id); err != nil {
 		return err
 	}
+	if err := sync_stremio_simkl.UnlinkByStremioAccount(id); err != nil {
+		return err
+	}
 	return nil
 }
+
+var query_update_secrets = fmt.Sprintf(
+	`UPDATE %s SET %s = ?, %s = ? WHERE %s = ? AND %s = ? AND %s = ?`,
+	TableName,
+	Column.Password,
+	Column.Token,
+	Column.Id,
+	Column.Password,
+	Column.Token,
+)
+
+// ReEncrypt re-encrypts the password and token with the current vault secret,
+// returns false if those were already up-to-date.
+func (a *StremioAccount) ReEncrypt() (bool, error) {
+	password, err := vault.ReEncrypt(a.Password)
+	if err != nil {
+		return false, err
+	}
+	token, err := vault.ReEncrypt(a.Token)
+	if err != nil {
+		return false, err
+	}
+	if password == a.Password && token == a.Token {
+		return false, nil
+	}
+	if _, err := db.Exec(query_update_secrets, password, token, a.Id, a.Password, a.Token); err != nil {
+		return false, err
+	}
+	a.Password = password
+	a.Token = token
+	return true, nil
+}
@@ -1,6 +1,11 @@
 package configure
 
-import "html/template"
+import (
+	"html/template"
+	"strconv"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+)
 
 func GetScriptStoreTokenDescription(nameSelector, tokenSelector string) template.JS {
 	if nameSelector == "" {
@@ -9,7 +14,9 @@ func GetScriptStoreTokenDescription(nameSelector, tokenSelector string) template
 	if tokenSelector == "" {
 		tokenSelector = "`[name='${nameField.dataset.fieldStoreToken}']`"
 	}
+	p2pHasToken := config.Feature.HasTorrentStream()
 	return template.JS(`
+const p2pHasToken = ` + strconv.FormatBool(p2pHasToken) + `;
 function onStoreNameChangeUpdateStoreTokenDescription(nameField) {
   if (!nameField || !nameField.options[nameField.selectedIndex].textContent) {
     return;
@@ -59,11 +66,11 @@ function onStoreNameChangeUpdateStoreTokenDescription(nameField) {
 			pp: "PikPak <a href='https://mypikpak.com/drive/account/basic' target='_blank'>credential</a> in <code>email:password</code> format, e.g. <code>john.doe@example.com:secret-password</code>",
 			rd: "RealDebrid <a href='https://real-debrid.com/apitoken' target='_blank'>API Token</a>",
 			tb: "TorBox <a href='https://torbox.app/settings' target='_blank'>API Key</a>",
-			p2p: "…",
+			p2p: p2pHasToken ? "(Optional) StremThru Basic Auth Token (base64 encoded) from <a href='https://github.com/MunifTanjim/stremthru?tab=readme-ov-file#configuration' target='_blank'><code>STREMTHRU_PROXY_AUTH</code></a>, to stream through StremThru" : "…",
 		};
     tokenDescElem.innerHTML = descByStore[nameField.value] || descByStore[storeFallback[nameField.value]] || descByStore["*"] || "";
-    tokenField.disabled = nameField.value === "p2p";
-    if (nameField.value === "p2p") {
+    tokenField.disabled = nameField.value === "p2p" && !p2pHasToken;
+    if (tokenField.disabled) {
       tokenField.value = "";
     }
   }
@@ -1,6 +1,7 @@
 package stremio_list
 
 import (
+	"cmp"
 	"math/rand"
 	"net/http"
 	"net/url"
@@ -10,12 +11,15 @@ import (
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/anilist"
+	"github.com/MunifTanjim/stremthru/internal/anime"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/imdb_title"
 	"github.com/MunifTanjim/stremthru/internal/letterboxd"
+	"github.com/MunifTanjim/stremthru/internal/mal"
 	"github.com/MunifTanjim/stremthru/internal/mdblist"
 	"github.com/MunifTanjim/stremthru/internal/meta"
 	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/internal/simkl"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	"github.com/MunifTanjim/stremthru/internal/tmdb"
 	"github.com/MunifTanjim/stremthru/internal/trakt"
@@ -399,6 +403,31 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 			catalogItems = append(catalogItems, catalogItem{meta, item})
 		}
 
+	case "mal":
+		list := mal.MALList{Id: id}
+		if err := ud.FetchMALList(&list, false); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		for i := range list.Animes {
+			item := &list.Animes[i]
+
+			meta := stremio.MetaPreview{
+				Type:        stremio.ContentType(item.Type.ToSimple()),
+				Name:        item.Title,
+				Description: item.Description,
+				Poster:      item.Cover,
+				PosterShape: stremio.MetaPosterShapePoster,
+				Genres:      item.Genres,
+				ReleaseInfo: strconv.Itoa(item.StartYear),
+			}
+			if meta.Type != stremio.ContentTypeMovie && meta.Type != stremio.ContentTypeSeries {
+				meta.Type = "anime"
+			}
+			catalogItems = append(catalogItems, catalogItem{meta, *item})
+		}
+
 	case "mdblist":
 		list := mdblist.MDBListList{Id: id}
 		if err := ud.FetchMDBListList(&list); err != nil {
@@ -427,6 +456,41 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 			catalogItems = append(catalogItems, catalogItem{meta, item})
 		}
 
+	case "simkl":
+		list := simkl.SimklList{Id: id}
+		if err := ud.FetchSimklList(&list); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			meta := stremio.MetaPreview{
+				Name:        item.Title,
+				Poster:      item.PosterURL(),
+				PosterShape: stremio.MetaPosterShapePoster,
+				ReleaseInfo: strconv.Itoa(item.Year),
+			}
+			switch item.Type {
+			case simkl.ItemTypeMovies:
+				meta.Type = stremio.ContentTypeMovie
+			case simkl.ItemTypeShows:
+				meta.Type = stremio.ContentTypeSeries
+			case simkl.ItemTypeAnime:
+				switch strings.ToLower(item.AnimeType) {
+				case "movie":
+					meta.Type = stremio.ContentTypeMovie
+				case "tv", "ona":
+					meta.Type = stremio.ContentTypeSeries
+				default:
+					meta.Type = "anime"
+				}
+			default:
+				continue
+			}
+			catalogItems = append(catalogItems, catalogItem{meta, *item})
+		}
+
 	case "tmdb":
 		list := tmdb.TMDBList{Id: id}
 		if err := ud.FetchTMDBList(&list); err != nil {
@@ -670,6 +734,50 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 			items = append(items, item.MetaPreview)
 		}
 
+	case "mal":
+		animes := make([]mal.MALAnime, len(catalogItems))
+		for i := range catalogItems {
+			item := &catalogItems[i]
+			animes[i] = item.item.(mal.MALAnime)
+		}
+		if err := mal.EnsureIdMap(animes, id); err != nil {
+			SendError(w, r, err)
+			return
+		}
+
+		for i := range catalogItems {
+			item := &catalogItems[i]
+			media := animes[i]
+
+			idMap := media.IdMap
+			if idMap == nil {
+				idMap = &anime.AnimeIdMap{}
+			}
+
+			switch ud.MetaIdAnime {
+			case "anilist":
+				if idMap.AniList != "" {
+					item.Id = "anilist:" + idMap.AniList
+				}
+			case "anidb":
+				if idMap.AniDB != "" {
+					item.Id = "anidb:" + idMap.AniDB
+				}
+			}
+			if item.Id == "" && ud.MetaIdAnime != "mal" && idMap.Kitsu != "" {
+				item.Id = "kitsu:" + idMap.Kitsu
+			}
+			if item.Id == "" {
+				item.Id = "mal:" + strconv.Itoa(media.Id)
+			}
+
+			if posterBaseUrl != "" && idMap.IMDB != "" {
+				item.Poster = posterBaseUrl + idMap.IMDB + ".jpg" + posterQueryParams
+			}
+
+			items = append(items, item.MetaPreview)
+		}
+
 	case "mdblist":
 		imdbIds := []string{}
 		for i := range catalogItems {
@@ -700,6 +808,73 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 			items = append(items, item.MetaPreview)
 		}
 
+	case "simkl":
+		simklItems := make([]simkl.SimklItem, len(catalogItems))
+		for i := range catalogItems {
+			simklItems[i] = catalogItems[i].item.(simkl.SimklItem)
+		}
+
+		idMapByMALId, err := simkl.GetAnimeIdMaps(simklItems)
+		if err != nil {
+			SendError(w, r, err)
+			return
+		}
+		simkl.ScheduleIdMapSync(simklItems, idMapByMALId)
+
+		for i := range catalogItems {
+			item := &catalogItems[i]
+			sitem := &simklItems[i]
+
+			imdbId := sitem.Ids.IMDB
+			if sitem.Type == simkl.ItemTypeAnime {
+				idMap := anime.AnimeIdMap{}
+				if m, ok := idMapByMALId[sitem.Ids.MAL]; ok {
+					idMap = *m
+				}
+				idMap.AniDB = cmp.Or(idMap.AniDB, sitem.Ids.AniDB)
+				idMap.AniList = cmp.Or(idMap.AniList, sitem.Ids.AniList)
+				idMap.IMDB = cmp.Or(idMap.IMDB, sitem.Ids.IMDB)
+				idMap.Kitsu = cmp.Or(idMap.Kitsu, sitem.Ids.Kitsu)
+				idMap.MAL = cmp.Or(idMap.MAL, sitem.Ids.MAL)
+
+				switch ud.MetaIdAnime {
+				case "mal":
+					if idMap.MAL != "" {
+						item.Id = "mal:" + idMap.MAL
+					}
+				case "anilist":
+					if idMap.AniList != "" {
+						item.Id = "anilist:" + idMap.AniList
+					}
+				case "anidb":
+					if idMap.AniDB != "" {
+						item.Id = "anidb:" + idMap.AniDB
+					}
+				}
+				if item.Id == "" && idMap.Kitsu != "" {
+					item.Id = "kitsu:" + idMap.Kitsu
+				}
+				if item.Id == "" && idMap.MAL != "" {
+					item.Id = "mal:" + idMap.MAL
+				}
+				imdbId = idMap.IMDB
+			} else {
+				item.Id = imdbId
+				if imdbId != "" {
+					item.Background = stremio_shared.GetCinemetaBackgroundURL(imdbId)
+				}
+			}
+			if item.Id == "" {
+				continue
+			}
+
+			if posterBaseUrl != "" && imdbId != "" {
+				item.Poster = posterBaseUrl + imdbId + ".jpg" + posterQueryParams
+			}
+
+			items = append(items, item.MetaPreview)
+		}
+
 	case "tmdb":
 		tmdbMovieIds := make([]string, 0, len(catalogItems))
 		tmdbShowIds := make([]string, 0, len(catalogItems))
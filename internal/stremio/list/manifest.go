@@ -8,8 +8,10 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/anilist"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/letterboxd"
+	"github.com/MunifTanjim/stremthru/internal/mal"
 	"github.com/MunifTanjim/stremthru/internal/mdblist"
 	"github.com/MunifTanjim/stremthru/internal/shared"
+	"github.com/MunifTanjim/stremthru/internal/simkl"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	"github.com/MunifTanjim/stremthru/internal/tmdb"
 	"github.com/MunifTanjim/stremthru/internal/trakt"
@@ -112,6 +114,37 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 				}
 				catalogs = append(catalogs, catalog)
 
+			case "mal":
+				list := mal.MALList{Id: idStr}
+				if err := list.Fetch(); err != nil {
+					return nil, err
+				}
+				catalog := stremio.Catalog{
+					Type: "anime",
+					Id:   "st.list.mal." + idStr,
+					Name: list.GetDisplayName(),
+					Extra: []stremio.CatalogExtra{
+						{
+							Name:    "genre",
+							Options: mal.Genres,
+						},
+						{
+							Name: "skip",
+						},
+					},
+				}
+				if hasListNames {
+					if name := ud.ListNames[idx]; name != "" {
+						catalog.Name = name
+					}
+				}
+				if hasListTypes {
+					if listType := ud.ListTypes[idx]; listType != "" {
+						catalog.Type = listType
+					}
+				}
+				catalogs = append(catalogs, catalog)
+
 			case "mdblist":
 				list := mdblist.MDBListList{Id: idStr}
 				if err := list.Fetch(ud.MDBListAPIkey); err != nil {
@@ -143,6 +176,41 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 				}
 				catalogs = append(catalogs, catalog)
 
+			case "simkl":
+				list := &simkl.SimklList{Id: idStr}
+				if err := ud.FetchSimklList(list); err != nil {
+					return nil, err
+				}
+				catalog := stremio.Catalog{
+					Type: "Simkl",
+					Id:   "st.list.simkl." + idStr,
+					Name: list.GetDisplayName(),
+					Extra: []stremio.CatalogExtra{
+						{
+							Name: "skip",
+						},
+					},
+				}
+				switch list.GetItemType() {
+				case simkl.ItemTypeMovies:
+					catalog.Type = string(stremio.ContentTypeMovie)
+				case simkl.ItemTypeShows:
+					catalog.Type = string(stremio.ContentTypeSeries)
+				case simkl.ItemTypeAnime:
+					catalog.Type = "anime"
+				}
+				if hasListNames {
+					if name := ud.ListNames[idx]; name != "" {
+						catalog.Name = name
+					}
+				}
+				if hasListTypes {
+					if listType := ud.ListTypes[idx]; listType != "" {
+						catalog.Type = listType
+					}
+				}
+				catalogs = append(catalogs, catalog)
+
 			case "tmdb":
 				list := tmdb.TMDBList{Id: idStr}
 				if err := list.Fetch(ud.TMDBTokenId); err != nil {
@@ -9,8 +9,10 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/anilist"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/letterboxd"
+	"github.com/MunifTanjim/stremthru/internal/mal"
 	"github.com/MunifTanjim/stremthru/internal/mdblist"
 	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/simkl"
 	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_template "github.com/MunifTanjim/stremthru/internal/stremio/template"
@@ -24,6 +26,8 @@ import (
 var IsPublicInstance = config.IsPublicInstance
 var MaxPublicInstanceListCount = config.Stremio.List.PublicMaxListCount
 var TraktEnabled = config.Integration.Trakt.IsEnabled()
+var SimklEnabled = config.Integration.Simkl.IsEnabled()
+var MALEnabled = config.Integration.MAL.IsEnabled()
 var AnimeEnabled = config.Feature.IsEnabled("anime")
 var TMDBEnabled = config.Integration.TMDB.IsEnabled()
 var TVDBEnabled = config.Integration.TVDB.IsEnabled()
@@ -119,6 +123,8 @@ type TemplateData struct {
 
 	TraktTokenId configure.Config
 
+	SimklTokenId configure.Config
+
 	MetaIdMovie  configure.Config
 	MetaIdSeries configure.Config
 	MetaIdAnime  configure.Config
@@ -229,6 +235,20 @@ func getTemplateData(ud *UserData, udError userDataError, isAuthed bool, r *http
 			},
 			Hidden: !TraktEnabled,
 		},
+		SimklTokenId: configure.Config{
+			Key:          "simkl_token_id",
+			Title:        "Auth Code",
+			Type:         configure.ConfigTypePassword,
+			Default:      ud.SimklTokenId,
+			Error:        udError.simkl_token_id,
+			Autocomplete: "off",
+			Action: configure.ConfigAction{
+				Visible: ud.SimklTokenId == "" || udError.simkl_token_id != "",
+				Label:   "Authorize",
+				OnClick: template.JS(`window.open("` + oauth.SimklOAuthConfig.AuthCodeURL(uuid.NewString()) + `", "_blank")`),
+			},
+			Hidden: !SimklEnabled,
+		},
 		MetaIdMovie: configure.Config{
 			Key:     "meta_id_movie",
 			Title:   "Movie",
@@ -272,6 +292,16 @@ func getTemplateData(ud *UserData, udError userDataError, isAuthed bool, r *http
 		}
 	}
 
+	if SimklEnabled && td.SimklTokenId.Error == "" {
+		otok, err := ud.getSimklToken()
+		if err != nil {
+			td.SimklTokenId.Error = err.Error()
+			td.SimklTokenId.Action.Visible = true
+		} else if otok != nil {
+			td.SimklTokenId.Title += " (" + otok.UserName + ")"
+		}
+	}
+
 	if ud.Shuffle {
 		td.Shuffle.Default = "checked"
 	}
@@ -326,6 +356,15 @@ func getTemplateData(ud *UserData, udError userDataError, isAuthed bool, r *http
 						list.URL = l.GetURL()
 					}
 
+				case "mal":
+					l := mal.MALList{Id: id}
+					if err := ud.FetchMALList(&l, false); err != nil {
+						log.Error("failed to fetch list", "error", err, "id", listId)
+						list.Error.URL = "Failed to Fetch List: " + err.Error()
+					} else {
+						list.URL = l.GetURL()
+					}
+
 				case "mdblist":
 					l := mdblist.MDBListList{Id: id}
 					if err := ud.FetchMDBListList(&l); err != nil {
@@ -335,6 +374,20 @@ func getTemplateData(ud *UserData, udError userDataError, isAuthed bool, r *http
 						list.URL = l.GetURL()
 					}
 
+				case "simkl":
+					if td.SimklTokenId.Error == "" {
+						l := simkl.SimklList{Id: id}
+						if err := ud.FetchSimklList(&l); err != nil {
+							log.Error("failed to fetch list", "error", err, "id", listId)
+							list.Error.URL = "Failed to Fetch List: " + err.Error()
+						} else {
+							list.URL = l.GetURL()
+						}
+					} else {
+						list.Disabled.URL = true
+						list.Error.URL = "Simkl authorization needed"
+					}
+
 				case "tmdb":
 					if td.TMDBTokenId.Error == "" {
 						l := tmdb.TMDBList{Id: id}
@@ -452,6 +505,30 @@ var executeTemplate = func() stremio_template.Executor[TemplateData] {
 				},
 			})
 		}
+		if AnimeEnabled && MALEnabled {
+			td.SupportedServices = append(td.SupportedServices, supportedService{
+				Name:     "MyAnimeList",
+				Hostname: "myanimelist.net",
+				Icon:     "https://cdn.myanimelist.net/images/favicon.ico",
+				URLs: []supportedServiceUrl{
+					{
+						Pattern: "/animelist/{user_name}?status={status}",
+						Examples: []string{
+							"/animelist/Xinil",
+							"/animelist/Xinil?status=2",
+						},
+					},
+					{
+						Pattern: "/topanime.php?type={type}",
+						Examples: []string{
+							"/topanime.php",
+							"/topanime.php?type=airing",
+							"/topanime.php?type=bypopularity",
+						},
+					},
+				},
+			})
+		}
 		td.SupportedServices = append(td.SupportedServices, supportedService{
 			Name:     "MDBList",
 			Hostname: "mdblist.com",
@@ -477,6 +554,23 @@ var executeTemplate = func() stremio_template.Executor[TemplateData] {
 				},
 			},
 		})
+		if SimklEnabled {
+			td.SupportedServices = append(td.SupportedServices, supportedService{
+				Name:     "Simkl",
+				Hostname: "simkl.com",
+				Icon:     "https://simkl.com/favicon.ico",
+				URLs: []supportedServiceUrl{
+					{
+						Pattern: "/{own_user_id}/{movies,tv,anime}/{status}",
+						Examples: []string{
+							"/123456/movies/plantowatch",
+							"/123456/tv/watching",
+							"/123456/anime/completed",
+						},
+					},
+				},
+			})
+		}
 		if TMDBEnabled {
 			td.SupportedServices = append(td.SupportedServices, supportedService{
 				Name:     "The Movie Database",
@@ -573,6 +667,14 @@ var executeTemplate = func() stremio_template.Executor[TemplateData] {
 							"/users/garycrawfordgc/watchlist",
 						},
 					},
+					{
+						Pattern: "/users/{user_slug}/ratings/{movies,shows,all}/{min_rating}",
+						Examples: []string{
+							"/users/sean/ratings",
+							"/users/sean/ratings/movies",
+							"/users/sean/ratings/all/9",
+						},
+					},
 					{
 						Pattern: "/users/{user_slug}/lists/{list_slug}",
 						Examples: []string{
@@ -685,6 +787,9 @@ var executeTemplate = func() stremio_template.Executor[TemplateData] {
 			if td.TraktTokenId.Default != "" {
 				td.TraktTokenId.Default = redacted
 			}
+			if td.SimklTokenId.Default != "" {
+				td.SimklTokenId.Default = redacted
+			}
 		}
 
 		return td
@@ -10,8 +10,10 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/anilist"
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/letterboxd"
+	"github.com/MunifTanjim/stremthru/internal/mal"
 	"github.com/MunifTanjim/stremthru/internal/mdblist"
 	"github.com/MunifTanjim/stremthru/internal/oauth"
+	"github.com/MunifTanjim/stremthru/internal/simkl"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_userdata "github.com/MunifTanjim/stremthru/internal/stremio/userdata"
 	"github.com/MunifTanjim/stremthru/internal/tmdb"
@@ -37,6 +39,9 @@ type UserData struct {
 	TraktTokenId string            `json:"trakt_token_id,omitempty"`
 	traktToken   *oauth.OAuthToken `json:"-"`
 
+	SimklTokenId string            `json:"simkl_token_id,omitempty"`
+	simklToken   *oauth.OAuthToken `json:"-"`
+
 	RPDBAPIKey       string `json:"rpdb_api_key,omitempty"`
 	TopPostersAPIKey string `json:"top_posters_api_key,omitempty"`
 
@@ -50,6 +55,8 @@ type UserData struct {
 
 	mdblistById    map[string]mdblist.MDBListList       `json:"-"`
 	anilistById    map[string]anilist.AniListList       `json:"-"`
+	malById        map[string]mal.MALList               `json:"-"`
+	simklById      map[string]simkl.SimklList           `json:"-"`
 	traktById      map[string]trakt.TraktList           `json:"-"`
 	tmdbById       map[string]tmdb.TMDBList             `json:"-"`
 	tvdbById       map[string]tvdb.TVDBList             `json:"-"`
@@ -60,6 +67,7 @@ func (ud UserData) StripSecrets() UserData {
 	ud.MDBListAPIkey = ""
 	ud.TMDBTokenId = ""
 	ud.TraktTokenId = ""
+	ud.SimklTokenId = ""
 	ud.RPDBAPIKey = ""
 	ud.TopPostersAPIKey = ""
 	return ud
@@ -92,6 +100,7 @@ type userDataError struct {
 	list_urls           []string
 	tmdb_token_id       string
 	trakt_token_id      string
+	simkl_token_id      string
 	meta_id_movie       string
 	meta_id_series      string
 	meta_id_anime       string
@@ -168,6 +177,7 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 		ud.MDBListAPIkey = r.Form.Get("mdblist_api_key")
 		ud.TMDBTokenId = r.Form.Get("tmdb_token_id")
 		ud.TraktTokenId = r.Form.Get("trakt_token_id")
+		ud.SimklTokenId = r.Form.Get("simkl_token_id")
 
 		ud.RPDBAPIKey = r.Form.Get("rpdb_api_key")
 		ud.TopPostersAPIKey = r.Form.Get("top_posters_api_key")
@@ -201,6 +211,7 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 		isMDBListEnabled := ud.MDBListAPIkey != ""
 		isTMDBConfigured := TMDBEnabled && ud.TMDBTokenId != ""
 		isTraktTvConfigured := TraktEnabled && ud.TraktTokenId != ""
+		isSimklConfigured := SimklEnabled && ud.SimklTokenId != ""
 		isTVDBConfigured := TVDBEnabled
 
 		if isMDBListEnabled {
@@ -231,6 +242,14 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 			isTraktTvConfigured = ud.TraktTokenId != ""
 		}
 
+		if isSimklConfigured {
+			ud.simklToken, err = ud.getSimklToken()
+			if err != nil {
+				udErr.simkl_token_id = err.Error()
+			}
+			isSimklConfigured = ud.SimklTokenId != ""
+		}
+
 		ud.Lists = make([]string, 0, lists_length)
 		ud.ListNames = make([]string, 0, lists_length)
 		ud.ListTypes = make([]string, 0, lists_length)
@@ -366,6 +385,45 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 				}
 				ud.Lists[idx] = "letterboxd:" + list.Id
 
+			case "myanimelist.net":
+				if !AnimeEnabled || !MALEnabled {
+					udErr.list_urls[idx] = "Unsupported List URL"
+					continue
+				}
+
+				list := mal.MALList{}
+				switch {
+				case strings.HasPrefix(listUrl.Path, "/animelist/"):
+					userName := strings.Trim(strings.TrimPrefix(listUrl.Path, "/animelist/"), "/")
+					if userName == "" || strings.Contains(userName, "/") {
+						udErr.list_urls[idx] = "Invalid MyAnimeList URL"
+						continue
+					}
+					status, ok := mal.ParseListStatus(listUrl.Query().Get("status"))
+					if !ok {
+						udErr.list_urls[idx] = "Unsupported MyAnimeList URL"
+						continue
+					}
+					list.Id = userName + ":" + string(status)
+				case listUrl.Path == "/topanime.php":
+					rankingType, ok := mal.ParseRankingType(listUrl.Query().Get("type"))
+					if !ok {
+						udErr.list_urls[idx] = "Unsupported MyAnimeList URL"
+						continue
+					}
+					list.Id = "~:" + string(rankingType)
+				default:
+					udErr.list_urls[idx] = "Unsupported MyAnimeList URL"
+					continue
+				}
+
+				err := ud.FetchMALList(&list, true)
+				if err != nil {
+					udErr.list_urls[idx] = "Failed to fetch List: " + err.Error()
+					continue
+				}
+				ud.Lists[idx] = "mal:" + list.Id
+
 			case "mdblist.com":
 				if !isMDBListEnabled {
 					udErr.list_urls[idx] = "MDBList API Key is required"
@@ -411,6 +469,46 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 				}
 				ud.Lists[idx] = "mdblist:" + list.Id
 
+			case "simkl.com":
+				if !isSimklConfigured {
+					if SimklEnabled {
+						udErr.list_urls[idx] = "Simkl Auth Code is required"
+					} else {
+						udErr.list_urls[idx] = "Unsupported List URL"
+					}
+					continue
+				}
+
+				// e.g. /{user_id}/{movies,tv,anime}/{status}
+				parts := strings.Split(strings.Trim(listUrl.Path, "/"), "/")
+				if len(parts) != 3 {
+					udErr.list_urls[idx] = "Unsupported Simkl URL"
+					continue
+				}
+				userId := parts[0]
+				itemType, ok := simkl.ParseItemType(parts[1])
+				if !ok {
+					udErr.list_urls[idx] = "Unsupported Simkl URL"
+					continue
+				}
+				status, ok := simkl.ParseItemStatus(parts[2])
+				if !ok {
+					udErr.list_urls[idx] = "Unsupported Simkl URL"
+					continue
+				}
+				if userId != ud.simklToken.UserId {
+					udErr.list_urls[idx] = "Invalid URL: not own list"
+					continue
+				}
+
+				list := simkl.SimklList{Id: simkl.NewUserListId(userId, itemType, status)}
+				err := ud.FetchSimklList(&list)
+				if err != nil {
+					udErr.list_urls[idx] = "Failed to fetch List: " + err.Error()
+					continue
+				}
+				ud.Lists[idx] = "simkl:" + list.Id
+
 			case "www.themoviedb.org", "themoviedb.org":
 				if !isTMDBConfigured {
 					if TMDBEnabled {
@@ -530,10 +628,31 @@ func getUserData(r *http.Request, isAuthed bool) (*UserData, error) {
 								udErr.list_urls[idx] = "Invalid URL: not own list"
 								continue
 							}
+						case "ratings":
+							list.Id = trakt.ID_PREFIX_USER_RATINGS + parts[0]
+							list.UserId = parts[0]
 						default:
 							udErr.list_urls[idx] = "Unsupported Trakt.tv URL"
 							continue
 						}
+					case len(parts) == 3 && parts[1] == "ratings":
+						// e.g. /users/{user}/ratings/movies/9, rating is used as minimum
+						ratingType, rating, _ := strings.Cut(strings.TrimSuffix(parts[2], "/"), "/")
+						switch ratingType {
+						case "movies", "shows", "all":
+						default:
+							udErr.list_urls[idx] = "Unsupported Trakt.tv URL"
+							continue
+						}
+						if rating != "" && !util.IsNumericString(rating) {
+							udErr.list_urls[idx] = "Invalid Trakt.tv URL"
+							continue
+						}
+						list.Id = trakt.ID_PREFIX_USER_RATINGS + parts[0] + ":" + ratingType
+						if rating != "" {
+							list.Id += ":" + rating
+						}
+						list.UserId = parts[0]
 					default:
 						udErr.list_urls[idx] = "Unsupported Trakt.tv URL"
 						continue
@@ -640,6 +759,29 @@ func (ud *UserData) getTraktToken() (*oauth.OAuthToken, error) {
 	return ud.traktToken, nil
 }
 
+func (ud *UserData) getSimklToken() (*oauth.OAuthToken, error) {
+	if ud.SimklTokenId == "" {
+		return nil, nil
+	}
+
+	if ud.simklToken != nil {
+		return ud.simklToken, nil
+	}
+
+	otok, err := oauth.GetOAuthTokenById(ud.SimklTokenId)
+	if err != nil {
+		ud.SimklTokenId = ""
+		return nil, errors.New("failed to retrieve token: " + err.Error())
+	}
+	if otok == nil || otok.AccessToken == "" {
+		ud.SimklTokenId = ""
+		return nil, errors.New("Invalid or Revoked")
+	}
+
+	ud.simklToken = otok
+	return ud.simklToken, nil
+}
+
 func (ud *UserData) getTMDBToken() (*oauth.OAuthToken, error) {
 	if ud.TMDBTokenId == "" {
 		return nil, nil
@@ -757,6 +899,49 @@ func (ud *UserData) FetchAniListList(list *anilist.AniListList, scheduleIdMapSyn
 	return nil
 }
 
+func (ud *UserData) FetchMALList(list *mal.MALList, scheduleIdMapSync bool) error {
+	if ud.malById == nil {
+		ud.malById = map[string]mal.MALList{}
+	}
+	if list.Id != "" {
+		if l, ok := ud.malById[list.Id]; ok {
+			*list = l
+			return nil
+		}
+	}
+	if err := list.Fetch(); err != nil {
+		return err
+	}
+
+	if scheduleIdMapSync {
+		mal.ScheduleIdMapSync(list.Animes)
+	}
+
+	ud.malById[list.Id] = *list
+	return nil
+}
+
+func (ud *UserData) FetchSimklList(list *simkl.SimklList) error {
+	if ud.SimklTokenId == "" {
+		return errors.New("Simkl Auth Code missing")
+	}
+	if ud.simklById == nil {
+		ud.simklById = map[string]simkl.SimklList{}
+	}
+	if list.Id != "" {
+		if l, ok := ud.simklById[list.Id]; ok {
+			*list = l
+			return nil
+		}
+	}
+	if err := list.Fetch(ud.SimklTokenId); err != nil {
+		return err
+	}
+
+	ud.simklById[list.Id] = *list
+	return nil
+}
+
 func (ud *UserData) FetchTMDBList(list *tmdb.TMDBList) error {
 	if ud.TMDBTokenId == "" {
 		return errors.New("TMDB Auth Code missing")
@@ -61,3 +61,34 @@ func WaitForMagnetStatus(ctx *context.StoreContext, m *store.GetMagnetData, stat
 	}
 	return m, nil
 }
+
+func WaitForNewzStatus(ctx *context.StoreContext, n *store.GetNewzData, status store.NewzStatus, maxRetry int, retryInterval time.Duration) (*store.GetNewzData, error) {
+	us, ok := store.AsUsenetStore(ctx.Store)
+	if !ok {
+		error := core.NewStoreError("store does not support usenet")
+		error.StoreName = string(ctx.Store.GetName())
+		return n, error
+	}
+	retry := 0
+	for n.Status != status && retry < maxRetry {
+		gnParams := &store.GetNewzParams{
+			Id:          n.Id,
+			ClientIP:    ctx.ClientIP,
+			BypassCache: true,
+		}
+		gnParams.APIKey = ctx.StoreAuthToken
+		newz, err := us.GetNewz(gnParams)
+		if err != nil {
+			return n, err
+		}
+		n = newz
+		time.Sleep(retryInterval)
+		retry++
+	}
+	if n.Status != status {
+		error := core.NewStoreError("newz failed to reach status: " + string(status) + ", last status: " + string(n.Status))
+		error.StoreName = string(ctx.Store.GetName())
+		return n, error
+	}
+	return n, nil
+}
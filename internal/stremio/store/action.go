@@ -3,12 +3,145 @@ package stremio_store
 import (
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/cache"
+	"github.com/MunifTanjim/stremthru/internal/context"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
+	stremio_store_usenet "github.com/MunifTanjim/stremthru/internal/stremio/store/usenet"
+	stremio_store_webdl "github.com/MunifTanjim/stremthru/internal/stremio/store/webdl"
 	"github.com/MunifTanjim/stremthru/store"
 )
 
+const storeItemActionConfirmationWindow = 2 * time.Minute
+
+// requested destructive actions, waiting for confirmation.
+var storeItemActionRequestCache = cache.NewCache[bool](&cache.CacheConfig{
+	Lifetime: storeItemActionConfirmationWindow,
+	Name:     "stremio:store:itemActionRequest",
+})
+
+func getStoreItemActionRequestCacheKey(idStoreCode string, storeToken string, action StoreItemAction, itemId string) string {
+	return idStoreCode + ":" + storeToken + ":" + string(action) + ":" + itemId
+}
+
+func invalidateStoreCache(ctx *context.StoreContext, idr *ParsedId) {
+	catalogCache.Remove(getCatalogCacheKey(idr.getStoreCode(), ctx.StoreAuthToken))
+
+	// web downloads meta is listed in the store catalog, not the webdl one.
+	baseIdr := &ParsedId{
+		storeCode:    idr.storeCode,
+		storeName:    idr.storeName,
+		isDeprecated: idr.isDeprecated,
+		isST:         idr.isST,
+	}
+	baseStoreCode := baseIdr.getStoreCode()
+	switch idr.storeCode {
+	case store.StoreCodeAllDebrid:
+		adLinksCache.Remove(getADLinksCacheKey(baseStoreCode, ctx.StoreAuthToken))
+	case store.StoreCodePremiumize:
+		pmItemsCache.Remove(getPMItemsCacheKey(baseStoreCode, ctx.StoreAuthToken))
+	case store.StoreCodeRealDebrid:
+		rdDownloadsCache.Remove(getRDDownloadsCacheKey(baseStoreCode, ctx.StoreAuthToken))
+	}
+}
+
+func removeStoreItem(ctx *context.StoreContext, idr *ParsedId, itemId string) error {
+	if idr.isUsenet {
+		params := &stremio_store_usenet.RemoveNewsParams{
+			Id: itemId,
+		}
+		params.APIKey = ctx.StoreAuthToken
+		_, err := stremio_store_usenet.RemoveNews(params, ctx.Store)
+		return err
+	}
+
+	if idr.isWebDL {
+		params := &stremio_store_webdl.RemoveWebDLParams{
+			Id: itemId,
+		}
+		params.APIKey = ctx.StoreAuthToken
+		_, err := stremio_store_webdl.RemoveWebDL(params, ctx.Store)
+		return err
+	}
+
+	params := &store.RemoveMagnetParams{
+		Id: itemId,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	_, err := ctx.Store.RemoveMagnet(params)
+	return err
+}
+
+func reAddStoreItem(ctx *context.StoreContext, idr *ParsedId, itemId string) error {
+	if idr.isUsenet || idr.isWebDL {
+		error := core.NewAPIError("re-add is only supported for torrents")
+		error.StatusCode = http.StatusBadRequest
+		return error
+	}
+
+	gmParams := &store.GetMagnetParams{
+		Id:          itemId,
+		ClientIP:    ctx.ClientIP,
+		BypassCache: true,
+	}
+	gmParams.APIKey = ctx.StoreAuthToken
+	magnet, err := ctx.Store.GetMagnet(gmParams)
+	if err != nil {
+		return err
+	}
+
+	amParams := &store.AddMagnetParams{
+		Magnet:   magnet.Hash,
+		ClientIP: ctx.ClientIP,
+	}
+	amParams.APIKey = ctx.StoreAuthToken
+	newMagnet, err := ctx.Store.AddMagnet(amParams)
+	if err != nil {
+		return err
+	}
+
+	// some stores return the existing item for the same hash
+	if newMagnet.Id == magnet.Id {
+		return nil
+	}
+	return removeStoreItem(ctx, idr, magnet.Id)
+}
+
+func refreshStoreItem(ctx *context.StoreContext, idr *ParsedId, itemId string) error {
+	if idr.isUsenet {
+		params := &stremio_store_usenet.GetNewsParams{
+			Id:          itemId,
+			ClientIP:    ctx.ClientIP,
+			BypassCache: true,
+		}
+		params.APIKey = ctx.StoreAuthToken
+		_, err := stremio_store_usenet.GetNews(params, ctx.Store)
+		return err
+	}
+
+	if idr.isWebDL {
+		params := &stremio_store_webdl.GetWebDLParams{
+			Id:       itemId,
+			ClientIP: ctx.ClientIP,
+		}
+		params.APIKey = ctx.StoreAuthToken
+		_, err := stremio_store_webdl.GetWebDL(params, ctx.Store)
+		return err
+	}
+
+	params := &store.GetMagnetParams{
+		Id:          itemId,
+		ClientIP:    ctx.ClientIP,
+		BypassCache: true,
+	}
+	params.APIKey = ctx.StoreAuthToken
+	_, err := ctx.Store.GetMagnet(params)
+	return err
+}
+
 func handleAction(w http.ResponseWriter, r *http.Request) {
 	if !IsMethod(r, http.MethodGet) {
 		shared.ErrorMethodNotAllowed(r).Send(w, r)
@@ -44,17 +177,45 @@ func handleAction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	idStoreCode := idr.getStoreCode()
+
+	if action, itemId, isConfirm, ok := parseStoreItemActionId(actionId, idStoreCode); ok {
+		if action.needsConfirmation() {
+			cacheKey := getStoreItemActionRequestCacheKey(idStoreCode, ctx.StoreAuthToken, action, itemId)
+			if !isConfirm {
+				storeItemActionRequestCache.Add(cacheKey, true)
+				store_video.Redirect("200", w, r)
+				return
+			}
+			var requested bool
+			if !storeItemActionRequestCache.Get(cacheKey, &requested) || !requested {
+				store_video.Redirect("403", w, r)
+				return
+			}
+			storeItemActionRequestCache.Remove(cacheKey)
+		}
+
+		var err error
+		switch action {
+		case StoreItemActionRemove:
+			err = removeStoreItem(ctx, idr, itemId)
+		case StoreItemActionReAdd:
+			err = reAddStoreItem(ctx, idr, itemId)
+		case StoreItemActionRefresh:
+			err = refreshStoreItem(ctx, idr, itemId)
+		}
+		invalidateStoreCache(ctx, idr)
+		if err != nil {
+			LogError(r, "failed to perform store item action: "+string(action), err)
+			store_video.Redirect("500", w, r)
+			return
+		}
+		store_video.Redirect("200", w, r)
+		return
+	}
+
 	switch strings.TrimPrefix(actionId, storeActionIdPrefix) {
 	case "clear_cache":
-		catalogCache.Remove(getCatalogCacheKey(idStoreCode, ctx.StoreAuthToken))
-		switch ctx.Store.GetName() {
-		case store.StoreNameAlldebrid:
-			adLinksCache.Remove(getADLinksCacheKey(idStoreCode, ctx.StoreAuthToken))
-		case store.StoreNamePremiumize:
-			pmItemsCache.Remove(getPMItemsCacheKey(idStoreCode, ctx.StoreAuthToken))
-		case store.StoreNameRealDebrid:
-			rdDownloadsCache.Remove(getRDDownloadsCacheKey(idStoreCode, ctx.StoreAuthToken))
-		}
+		invalidateStoreCache(ctx, idr)
 	}
 
 	store_video.Redirect("200", w, r)
@@ -25,7 +25,10 @@ import (
 
 type CachedCatalogItem struct {
 	stremio.MetaPreview
-	Hash string
+	Hash     string
+	Status   store.MagnetStatus
+	AddedAt  time.Time
+	IsSeries bool
 }
 
 var catalogCache = cache.NewCache[[]CachedCatalogItem](&cache.CacheConfig{
@@ -34,6 +37,20 @@ var catalogCache = cache.NewCache[[]CachedCatalogItem](&cache.CacheConfig{
 	LocalCapacity: 2048,
 })
 
+// progress of the items being downloaded goes stale quickly, so the catalog
+// having any of them is cached for shorter.
+const inProgressCatalogCacheTime = 15 * time.Second
+
+func addToCatalogCache(cacheKey string, items []CachedCatalogItem) {
+	for i := range items {
+		if isMagnetInProgress(items[i].Status) {
+			catalogCache.AddWithLifetime(cacheKey, items, min(inProgressCatalogCacheTime, config.Stremio.Store.CatalogCacheTime))
+			return
+		}
+	}
+	catalogCache.Add(cacheKey, items)
+}
+
 func InvalidateCatalogCache(storeCode store.StoreCode, storeToken string) {
 	codes := []string{
 		string(storeCode),
@@ -66,7 +83,7 @@ func getUsenetCatalogItems(s store.Store, storeToken string, clientIp string, id
 				ClientIP: clientIp,
 			}
 			params.APIKey = storeToken
-			res, err := stremio_store_usenet.ListNews(params, storeName)
+			res, err := stremio_store_usenet.ListNews(params, s)
 			if err != nil {
 				log.Error("failed to list news", "error", err, "duration", time.Since(start).String(), "store.name", storeName, "offset", offset)
 				break
@@ -78,12 +95,17 @@ func getUsenetCatalogItems(s store.Store, storeToken string, clientIp string, id
 			for i := range res.Items {
 				item := &res.Items[i]
 				if item.Status == store.MagnetStatusDownloaded {
-					cItem := CachedCatalogItem{stremio.MetaPreview{
-						Id:          idPrefix + item.Id,
-						Type:        ContentTypeOther,
-						Name:        item.Name,
-						PosterShape: stremio.MetaPosterShapePoster,
-					}, item.Hash}
+					cItem := CachedCatalogItem{
+						MetaPreview: stremio.MetaPreview{
+							Id:          idPrefix + item.Id,
+							Type:        ContentTypeOther,
+							Name:        item.Name,
+							PosterShape: stremio.MetaPosterShapePoster,
+						},
+						Hash:    item.Hash,
+						Status:  item.Status,
+						AddedAt: item.AddedAt,
+					}
 					cItem.Description = getMetaPreviewDescriptionForUsenet(cItem.Hash, item.Name, item.GetLargestFileName())
 					items = append(items, cItem)
 				}
@@ -120,7 +142,7 @@ func getWebDLCatalogItems(s store.Store, storeToken string, clientIp string, idS
 				ClientIP: clientIp,
 			}
 			params.APIKey = storeToken
-			res, err := stremio_store_webdl.ListWebDLs(params, storeName)
+			res, err := stremio_store_webdl.ListWebDLs(params, s)
 			if err != nil {
 				log.Error("failed to list webdls", "error", err, "duration", time.Since(start).String(), "store.name", storeName, "offset", offset)
 				break
@@ -132,12 +154,17 @@ func getWebDLCatalogItems(s store.Store, storeToken string, clientIp string, idS
 			for i := range res.Items {
 				item := &res.Items[i]
 				if item.Status == store.MagnetStatusDownloaded {
-					cItem := CachedCatalogItem{stremio.MetaPreview{
-						Id:          idPrefix + item.Id,
-						Type:        ContentTypeOther,
-						Name:        item.Name,
-						PosterShape: stremio.MetaPosterShapePoster,
-					}, item.Hash}
+					cItem := CachedCatalogItem{
+						MetaPreview: stremio.MetaPreview{
+							Id:          idPrefix + item.Id,
+							Type:        ContentTypeOther,
+							Name:        item.Name,
+							PosterShape: stremio.MetaPosterShapePoster,
+						},
+						Hash:    item.Hash,
+						Status:  item.Status,
+						AddedAt: item.AddedAt,
+					}
 					cItem.Description = getMetaPreviewDescriptionForWebDL(cItem.Hash, item.Name, false)
 					items = append(items, cItem)
 				}
@@ -199,14 +226,48 @@ func getCatalogItems(s store.Store, storeToken string, clientIp string, idr *Par
 			start = time.Now()
 			for i := range res.Items {
 				item := &res.Items[i]
-				if item.Status == store.MagnetStatusDownloaded {
-					items = append(items, CachedCatalogItem{stremio.MetaPreview{
-						Id:          idPrefix + item.Id,
-						Type:        ContentTypeOther,
-						Name:        item.Name,
-						Description: getMetaPreviewDescriptionForTorrent(item.Hash, item.Name),
-						PosterShape: stremio.MetaPosterShapePoster,
-					}, item.Hash})
+				switch item.Status {
+				case store.MagnetStatusDownloaded:
+					items = append(items, CachedCatalogItem{
+						MetaPreview: stremio.MetaPreview{
+							Id:          idPrefix + item.Id,
+							Type:        ContentTypeOther,
+							Name:        item.Name,
+							Description: getMetaPreviewDescriptionForTorrent(item.Hash, item.Name),
+							PosterShape: stremio.MetaPosterShapePoster,
+						},
+						Hash:     item.Hash,
+						Status:   item.Status,
+						AddedAt:  item.AddedAt,
+						IsSeries: isSeriesTitle(item.Name),
+					})
+				case store.MagnetStatusFailed, store.MagnetStatusInvalid:
+					// listed so that they can be re-added / removed from the meta actions
+					items = append(items, CachedCatalogItem{
+						MetaPreview: stremio.MetaPreview{
+							Id:          idPrefix + item.Id,
+							Type:        ContentTypeOther,
+							Name:        "⚠️ " + item.Name,
+							Description: "[ ⚠️ " + string(item.Status) + " ] " + getMetaPreviewDescriptionForTorrent(item.Hash, item.Name),
+							PosterShape: stremio.MetaPosterShapePoster,
+						},
+						Hash:    item.Hash,
+						Status:  item.Status,
+						AddedAt: item.AddedAt,
+					})
+				case store.MagnetStatusQueued, store.MagnetStatusDownloading, store.MagnetStatusProcessing, store.MagnetStatusUploading:
+					items = append(items, CachedCatalogItem{
+						MetaPreview: stremio.MetaPreview{
+							Id:          idPrefix + item.Id,
+							Type:        ContentTypeOther,
+							Name:        "⏳ " + item.Name,
+							Description: getMetaPreviewDescriptionForProgress(item.Status, item.Progress, item.Speed, item.Seeders, item.ETA) + " " + getMetaPreviewDescriptionForTorrent(item.Hash, item.Name),
+							PosterShape: stremio.MetaPosterShapePoster,
+						},
+						Hash:    item.Hash,
+						Status:  item.Status,
+						AddedAt: item.AddedAt,
+					})
 				}
 				tInfoItems = append(tInfoItems, torrent_info.TorrentInfoInsertData{
 					Hash:         item.Hash,
@@ -231,7 +292,7 @@ func getCatalogItems(s store.Store, storeToken string, clientIp string, idr *Par
 
 			time.Sleep(500 * time.Millisecond)
 		}
-		catalogCache.Add(cacheKey, items)
+		addToCatalogCache(cacheKey, items)
 		go torrent_info.Upsert(tInfoItems, "", storeCode != store.StoreCodeRealDebrid)
 	}
 
@@ -306,7 +367,8 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if catalogId != getCatalogId(idr.getStoreCode()) {
+	partition, ok := parseCatalogPartition(catalogId, idr.getStoreCode())
+	if !ok || (partition != "" && (idr.isUsenet || idr.isWebDL)) {
 		shared.ErrorBadRequest(r, "unsupported catalog id: "+catalogId).Send(w, r)
 		return
 	}
@@ -337,6 +399,7 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 	}
 
 	items := getCatalogItems(ctx.Store, ctx.StoreAuthToken, ctx.ClientIP, idr, log)
+	items = filterCatalogItemsByPartition(items, partition)
 
 	if extra.Search != "" {
 		start := time.Now()
@@ -381,7 +444,7 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 		hashes[i] = item.Hash
 	}
 
-	includeWebDLsMetaPreview := ud.EnableWebDL && (idr.storeCode == store.StoreCodeRealDebrid || idr.storeCode == store.StoreCodePremiumize || idr.storeCode == store.StoreCodeAllDebrid)
+	includeWebDLsMetaPreview := ud.EnableWebDL && !idr.isWebDL && !idr.isUsenet && (idr.storeCode == store.StoreCodeRealDebrid || idr.storeCode == store.StoreCodePremiumize || idr.storeCode == store.StoreCodeAllDebrid)
 
 	count := len(hashes)
 	if includeWebDLsMetaPreview {
@@ -390,7 +453,7 @@ func handleCatalog(w http.ResponseWriter, r *http.Request) {
 
 	res.Metas = make([]stremio.MetaPreview, 0, count)
 
-	if includeWebDLsMetaPreview && extra.Skip == 0 {
+	if includeWebDLsMetaPreview && extra.Skip == 0 && partition == "" {
 		res.Metas = append(res.Metas, stremio.MetaPreview{
 			Id:          getWebDLsMetaId(idStoreCode),
 			Type:        ContentTypeOther,
@@ -0,0 +1,116 @@
+package stremio_store
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+type CatalogPartition string
+
+const (
+	CatalogPartitionRecent      CatalogPartition = "recent"
+	CatalogPartitionMovie       CatalogPartition = "movie"
+	CatalogPartitionSeries      CatalogPartition = "series"
+	CatalogPartitionDownloading CatalogPartition = "downloading"
+)
+
+var catalogPartitions = []CatalogPartition{
+	CatalogPartitionRecent,
+	CatalogPartitionMovie,
+	CatalogPartitionSeries,
+	CatalogPartitionDownloading,
+}
+
+var catalogPartitionTitle = map[CatalogPartition]string{
+	CatalogPartitionRecent:      "Recently Added",
+	CatalogPartitionMovie:       "Movies",
+	CatalogPartitionSeries:      "Series",
+	CatalogPartitionDownloading: "Downloading",
+}
+
+func (p CatalogPartition) IsValid() bool {
+	return slices.Contains(catalogPartitions, p)
+}
+
+func (p CatalogPartition) GetTitle() string {
+	return catalogPartitionTitle[p]
+}
+
+func getPartitionCatalogId(storeCode string, partition CatalogPartition) string {
+	return getCatalogId(storeCode) + ":" + string(partition)
+}
+
+// parseCatalogPartition returns the partition from catalog id, empty for the
+// unpartitioned catalog.
+func parseCatalogPartition(catalogId string, storeCode string) (partition CatalogPartition, ok bool) {
+	rest, found := strings.CutPrefix(catalogId, getCatalogId(storeCode))
+	if !found {
+		return "", false
+	}
+	if rest == "" {
+		return "", true
+	}
+	p, found := strings.CutPrefix(rest, ":")
+	if !found {
+		return "", false
+	}
+	partition = CatalogPartition(p)
+	return partition, partition.IsValid()
+}
+
+func isCatalogItemInProgress(status store.MagnetStatus) bool {
+	switch status {
+	case store.MagnetStatusQueued, store.MagnetStatusDownloading, store.MagnetStatusProcessing, store.MagnetStatusUploading:
+		return true
+	default:
+		return false
+	}
+}
+
+func isSeriesTitle(title string) bool {
+	r, err := util.ParseTorrentTitle(title)
+	if err != nil {
+		return false
+	}
+	return len(r.Seasons) > 0 || len(r.Episodes) > 0
+}
+
+func filterCatalogItemsByPartition(items []CachedCatalogItem, partition CatalogPartition) []CachedCatalogItem {
+	if partition == "" {
+		return items
+	}
+
+	filteredItems := []CachedCatalogItem{}
+	for i := range items {
+		item := &items[i]
+		switch partition {
+		case CatalogPartitionRecent:
+			if item.Status == store.MagnetStatusDownloaded {
+				filteredItems = append(filteredItems, *item)
+			}
+		case CatalogPartitionMovie:
+			if item.Status == store.MagnetStatusDownloaded && !item.IsSeries {
+				filteredItems = append(filteredItems, *item)
+			}
+		case CatalogPartitionSeries:
+			if item.Status == store.MagnetStatusDownloaded && item.IsSeries {
+				filteredItems = append(filteredItems, *item)
+			}
+		case CatalogPartitionDownloading:
+			if isCatalogItemInProgress(item.Status) {
+				filteredItems = append(filteredItems, *item)
+			}
+		}
+	}
+
+	if partition == CatalogPartitionRecent {
+		slices.SortStableFunc(filteredItems, func(a, b CachedCatalogItem) int {
+			return b.AddedAt.Compare(a.AddedAt)
+		})
+	}
+
+	return filteredItems
+}
@@ -0,0 +1,77 @@
+package stremio_store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/store"
+	"github.com/MunifTanjim/stremthru/stremio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCatalogPartition(t *testing.T) {
+	for _, tc := range []struct {
+		catalogId string
+		storeCode string
+		partition CatalogPartition
+		ok        bool
+	}{
+		{"st:store:rd", "rd", "", true},
+		{"st:store:rd:movie", "rd", CatalogPartitionMovie, true},
+		{"st:store:st-tb:downloading", "st-tb", CatalogPartitionDownloading, true},
+		{"st:store:st:rd", "st:rd", "", true},
+		{"st:store:rd:unknown", "rd", "unknown", false},
+		{"st:store:rdx", "rd", "", false},
+		{"st:store:tb", "rd", "", false},
+	} {
+		t.Run(tc.catalogId, func(t *testing.T) {
+			partition, ok := parseCatalogPartition(tc.catalogId, tc.storeCode)
+			assert.Equal(t, tc.ok, ok)
+			if ok {
+				assert.Equal(t, tc.partition, partition)
+			}
+		})
+	}
+}
+
+func TestFilterCatalogItemsByPartition(t *testing.T) {
+	now := time.Now()
+	newItem := func(id string, status store.MagnetStatus, addedAt time.Time) CachedCatalogItem {
+		return CachedCatalogItem{
+			MetaPreview: stremio.MetaPreview{Id: id, Name: id},
+			Status:      status,
+			AddedAt:     addedAt,
+			IsSeries:    isSeriesTitle(id),
+		}
+	}
+	items := []CachedCatalogItem{
+		newItem("Movie.2020.1080p.WEB-DL", store.MagnetStatusDownloaded, now.Add(-3*time.Hour)),
+		newItem("Show.S01E02.1080p.WEB-DL", store.MagnetStatusDownloaded, now.Add(-1*time.Hour)),
+		newItem("Show.S02.Complete.720p", store.MagnetStatusDownloading, now),
+		newItem("Another.Movie.2021.2160p", store.MagnetStatusFailed, now),
+		newItem("Show.S03.1080p", store.MagnetStatusDownloaded, now.Add(-2*time.Hour)),
+	}
+
+	getIds := func(items []CachedCatalogItem) []string {
+		ids := make([]string, len(items))
+		for i := range items {
+			ids[i] = items[i].Id
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		partition CatalogPartition
+		expected  []string
+	}{
+		{"", []string{"Movie.2020.1080p.WEB-DL", "Show.S01E02.1080p.WEB-DL", "Show.S02.Complete.720p", "Another.Movie.2021.2160p", "Show.S03.1080p"}},
+		{CatalogPartitionRecent, []string{"Show.S01E02.1080p.WEB-DL", "Show.S03.1080p", "Movie.2020.1080p.WEB-DL"}},
+		{CatalogPartitionMovie, []string{"Movie.2020.1080p.WEB-DL"}},
+		{CatalogPartitionSeries, []string{"Show.S01E02.1080p.WEB-DL", "Show.S03.1080p"}},
+		{CatalogPartitionDownloading, []string{"Show.S02.Complete.720p"}},
+	} {
+		t.Run(string(tc.partition), func(t *testing.T) {
+			assert.Equal(t, tc.expected, getIds(filterCatalogItemsByPartition(items, tc.partition)))
+		})
+	}
+}
@@ -29,6 +29,55 @@ func getStoreActionIdPrefix(storeCode string) string {
 	return getStoreActionId(storeCode) + ":"
 }
 
+type StoreItemAction string
+
+const (
+	StoreItemActionRemove  StoreItemAction = "remove"
+	StoreItemActionReAdd   StoreItemAction = "readd"
+	StoreItemActionRefresh StoreItemAction = "refresh"
+)
+
+func getStoreItemActionIdPrefix(storeCode string) string {
+	return getStoreActionIdPrefix(storeCode) + "item:"
+}
+
+// destructive actions only run when confirmed after being requested.
+func (a StoreItemAction) needsConfirmation() bool {
+	return a == StoreItemActionRemove || a == StoreItemActionReAdd
+}
+
+const storeItemActionConfirmIndicator = "confirm"
+
+func getStoreItemActionId(storeCode string, action StoreItemAction, itemId string) string {
+	return getStoreItemActionIdPrefix(storeCode) + string(action) + ":" + itemId
+}
+
+func getStoreItemActionConfirmId(storeCode string, action StoreItemAction, itemId string) string {
+	return getStoreItemActionIdPrefix(storeCode) + storeItemActionConfirmIndicator + ":" + string(action) + ":" + itemId
+}
+
+func parseStoreItemActionId(actionId string, storeCode string) (action StoreItemAction, itemId string, isConfirm bool, ok bool) {
+	rest, found := strings.CutPrefix(actionId, getStoreItemActionIdPrefix(storeCode))
+	if !found {
+		return "", "", false, false
+	}
+	rest, isConfirm = strings.CutPrefix(rest, storeItemActionConfirmIndicator+":")
+	a, itemId, found := strings.Cut(rest, ":")
+	if !found || itemId == "" {
+		return "", "", false, false
+	}
+	action = StoreItemAction(a)
+	switch action {
+	case StoreItemActionRemove, StoreItemActionReAdd, StoreItemActionRefresh:
+		if isConfirm && !action.needsConfirmation() {
+			return "", "", false, false
+		}
+		return action, itemId, isConfirm, true
+	default:
+		return "", "", false, false
+	}
+}
+
 const WEBDL_META_ID_INDICATOR = "webdls"
 
 func getWebDLsMetaId(storeCode string) string {
@@ -79,3 +79,37 @@ func TestIdParser(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStoreItemActionId(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		id        string
+		storeCode string
+		action    StoreItemAction
+		itemId    string
+		isConfirm bool
+		ok        bool
+	}{
+		{"remove rd", "st:store:rd:action:item:remove:XXX", "rd", StoreItemActionRemove, "XXX", false, true},
+		{"confirm remove rd", "st:store:rd:action:item:confirm:remove:XXX", "rd", StoreItemActionRemove, "XXX", true, true},
+		{"readd st-tb", "st:store:st-tb:action:item:readd:123", "st-tb", StoreItemActionReAdd, "123", false, true},
+		{"confirm readd st-tb", "st:store:st-tb:action:item:confirm:readd:123", "st-tb", StoreItemActionReAdd, "123", true, true},
+		{"refresh tb-usenet", "st:store:tb-usenet:action:item:refresh:42", "tb-usenet", StoreItemActionRefresh, "42", false, true},
+		{"confirm refresh", "st:store:tb-usenet:action:item:confirm:refresh:42", "tb-usenet", "", "", false, false},
+		{"item id with colon", "st:store:pm:action:item:remove:cached:XXX", "pm", StoreItemActionRemove, "cached:XXX", false, true},
+		{"unknown action", "st:store:rd:action:item:pause:XXX", "rd", "", "", false, false},
+		{"missing item id", "st:store:rd:action:item:remove:", "rd", "", "", false, false},
+		{"store action", "st:store:rd:action:clear_cache", "rd", "", "", false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			idr, err := parseId(tc.id)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.storeCode, idr.getStoreCode())
+			action, itemId, isConfirm, ok := parseStoreItemActionId(tc.id, idr.getStoreCode())
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.action, action)
+			assert.Equal(t, tc.itemId, itemId)
+			assert.Equal(t, tc.isConfirm, isConfirm)
+		})
+	}
+}
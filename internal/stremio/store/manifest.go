@@ -9,6 +9,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_store_usenet "github.com/MunifTanjim/stremthru/internal/stremio/store/usenet"
+	stremio_store_webdl "github.com/MunifTanjim/stremthru/internal/stremio/store/webdl"
 	"github.com/MunifTanjim/stremthru/store"
 	"github.com/MunifTanjim/stremthru/stremio"
 )
@@ -55,6 +56,22 @@ func getManifestCatalog(code string, hideCatalog bool) stremio.Catalog {
 	}
 }
 
+func getManifestPartitionCatalog(code string, partition CatalogPartition) stremio.Catalog {
+	return stremio.Catalog{
+		Id:   getPartitionCatalogId(code, partition),
+		Name: "Store " + strings.ToUpper(code) + " | " + partition.GetTitle(),
+		Type: ContentTypeOther,
+		Extra: []stremio.CatalogExtra{
+			{
+				Name: "search",
+			},
+			{
+				Name: "skip",
+			},
+		},
+	}
+}
+
 func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 	isConfigured := ud.HasRequiredValues()
 
@@ -91,6 +108,9 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 						code := "st-" + string(storeCode)
 						idPrefixes = append(idPrefixes, getIdPrefix(code))
 						catalogs = append(catalogs, getManifestCatalog(code, ud.HideCatalog))
+						for _, partition := range ud.CatalogPartitions {
+							catalogs = append(catalogs, getManifestPartitionCatalog(code, partition))
+						}
 
 						if ud.EnableUsenet && stremio_store_usenet.IsSupported(storeCode) && user.HasUsenet {
 							usenetCode := code + "-usenet"
@@ -98,12 +118,10 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 							catalogs = append(catalogs, getManifestCatalog(usenetCode, ud.HideCatalog))
 						}
 
-						if storeName == store.StoreNameTorBox {
-							if ud.EnableWebDL {
-								webdlCode := code + "-webdl"
-								idPrefixes = append(idPrefixes, getIdPrefix(webdlCode))
-								catalogs = append(catalogs, getManifestCatalog(webdlCode, ud.HideCatalog))
-							}
+						if ud.EnableWebDL && stremio_store_webdl.IsSupported(storeCode) {
+							webdlCode := code + "-webdl"
+							idPrefixes = append(idPrefixes, getIdPrefix(webdlCode))
+							catalogs = append(catalogs, getManifestCatalog(webdlCode, ud.HideCatalog))
 						}
 					}
 				}
@@ -137,6 +155,9 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 
 			idPrefixes = append(idPrefixes, getIdPrefix(storeCode))
 			catalogs = append(catalogs, getManifestCatalog(storeCode, ud.HideCatalog))
+			for _, partition := range ud.CatalogPartitions {
+				catalogs = append(catalogs, getManifestPartitionCatalog(storeCode, partition))
+			}
 
 			if ud.EnableUsenet && stremio_store_usenet.IsSupported(storeName.Code()) && user.HasUsenet {
 				usenetCode := storeCode + "-usenet"
@@ -144,12 +165,10 @@ func GetManifest(r *http.Request, ud *UserData) (*stremio.Manifest, error) {
 				catalogs = append(catalogs, getManifestCatalog(usenetCode, ud.HideCatalog))
 			}
 
-			if storeName == store.StoreNameTorBox {
-				if ud.EnableWebDL {
-					webdlCode := storeCode + "-webdl"
-					idPrefixes = append(idPrefixes, getIdPrefix(webdlCode))
-					catalogs = append(catalogs, getManifestCatalog(webdlCode, ud.HideCatalog))
-				}
+			if ud.EnableWebDL && stremio_store_webdl.IsSupported(storeName.Code()) {
+				webdlCode := storeCode + "-webdl"
+				idPrefixes = append(idPrefixes, getIdPrefix(webdlCode))
+				catalogs = append(catalogs, getManifestCatalog(webdlCode, ud.HideCatalog))
 			}
 		}
 	} else {
@@ -143,6 +143,33 @@ func getMetaPreviewDescription(description string, r *ptt.Result, includeSeriesM
 	return description
 }
 
+func isMagnetInProgress(status store.MagnetStatus) bool {
+	switch status {
+	case store.MagnetStatusQueued, store.MagnetStatusDownloading, store.MagnetStatusProcessing, store.MagnetStatusUploading:
+		return true
+	default:
+		return false
+	}
+}
+
+func getMetaPreviewDescriptionForProgress(status store.MagnetStatus, progress float64, speed int64, seeders int, eta int64) string {
+	description := "[ ⏳ " + string(status)
+	if progress > 0 {
+		description += " " + strconv.FormatFloat(progress, 'f', 1, 64) + "%"
+	}
+	if speed > 0 {
+		description += " | " + util.ToSize(speed) + "/s"
+	}
+	if seeders > 0 {
+		description += " | 👤 " + strconv.Itoa(seeders)
+	}
+	if eta > 0 {
+		description += " | ⏱️ " + (time.Duration(eta) * time.Second).String()
+	}
+	description += " ]"
+	return description
+}
+
 func getMetaPreviewDescriptionForTorrent(hash, name string) string {
 	description := "[ 🧲 " + hash + " ]"
 
@@ -245,7 +272,7 @@ type contentInfo struct {
 
 func getStoreContentInfo(s store.Store, storeToken string, id string, clientIp string, idr *ParsedId) (*contentInfo, error) {
 	if idr.isUsenet {
-		if s.GetName() != store.StoreNameTorBox {
+		if _, ok := store.AsUsenetStore(s); !ok {
 			return nil, nil
 		}
 
@@ -253,7 +280,7 @@ func getStoreContentInfo(s store.Store, storeToken string, id string, clientIp s
 			Id: id,
 		}
 		params.APIKey = storeToken
-		news, err := stremio_store_usenet.GetNews(params, s.GetName())
+		news, err := stremio_store_usenet.GetNews(params, s)
 		if err != nil {
 			return nil, err
 		}
@@ -279,15 +306,12 @@ func getStoreContentInfo(s store.Store, storeToken string, id string, clientIp s
 	}
 
 	if idr.isWebDL {
-		if s.GetName() != store.StoreNameTorBox {
-			return nil, nil
-		}
-
 		params := &stremio_store_webdl.GetWebDLParams{
-			Id: id,
+			Id:       id,
+			ClientIP: clientIp,
 		}
 		params.APIKey = storeToken
-		webdl, err := stremio_store_webdl.GetWebDL(params, s.GetName())
+		webdl, err := stremio_store_webdl.GetWebDL(params, s)
 		if err != nil {
 			return nil, err
 		}
@@ -351,6 +375,53 @@ func getStoreActionMeta(r *http.Request, storeCode string, eud string) stremio.M
 	return meta
 }
 
+// getStoreItemActionVideos returns the videos for the item actions. They are
+// kept in season 0, out of the episodes. Destructive actions have a pair of
+// videos, the action is requested with one and performed with the other. The
+// confirm video comes first, so playing the next video never confirms.
+func getStoreItemActionVideos(r *http.Request, storeCode string, eud string, itemId string, cInfo *contentInfo, isTorrent bool) []stremio.MetaVideo {
+	type itemAction struct {
+		action      StoreItemAction
+		title       string
+		description string
+	}
+	actions := []itemAction{}
+	if isTorrent && (cInfo.Status == store.MagnetStatusFailed || cInfo.Status == store.MagnetStatusInvalid) {
+		actions = append(actions, itemAction{StoreItemActionReAdd, "Re-Add", "Add Again and Remove Old"})
+	}
+	actions = append(actions, itemAction{StoreItemActionRemove, "Remove", "Remove from Store"})
+
+	baseUrl := ExtractRequestBaseURL(r)
+	confirmWindow := strconv.Itoa(int(storeItemActionConfirmationWindow.Minutes())) + " minutes"
+
+	videos := make([]stremio.MetaVideo, 0, 2*len(actions)+1)
+	addVideo := func(actionId, title, name, description string) {
+		videos = append(videos, stremio.MetaVideo{
+			Id:       actionId,
+			Title:    title,
+			Released: cInfo.AddedAt,
+			Season:   0,
+			Episode:  stremio.ZeroIndexedInt(len(videos) + 1),
+			Streams: []stremio.Stream{
+				{
+					URL:         baseUrl.JoinPath("/stremio/store/" + eud + "/_/action/" + actionId).String(),
+					Name:        name,
+					Description: description + "\n📄 " + cInfo.Name,
+				},
+			},
+		})
+	}
+
+	addVideo(getStoreItemActionId(storeCode, StoreItemActionRefresh, itemId), "⚡ Refresh", "Refresh", "Refresh File List")
+	for _, a := range actions {
+		addVideo(getStoreItemActionConfirmId(storeCode, a.action, itemId), "⚠️ Confirm "+a.title, "Confirm "+a.title, a.description+", if requested in last "+confirmWindow)
+	}
+	for _, a := range actions {
+		addVideo(getStoreItemActionId(storeCode, a.action, itemId), "⚡ "+a.title, a.title, "Request to "+a.description+", confirm within "+confirmWindow)
+	}
+	return videos
+}
+
 func handleMeta(w http.ResponseWriter, r *http.Request) {
 	if !IsMethod(r, http.MethodGet) {
 		shared.ErrorMethodNotAllowed(r).Send(w, r)
@@ -456,6 +527,9 @@ func handleMeta(w http.ResponseWriter, r *http.Request) {
 		meta.Description = getMetaPreviewDescriptionForWebDL(cInfo.Hash, cInfo.Name, false)
 	} else {
 		meta.Description = getMetaPreviewDescriptionForTorrent(cInfo.Hash, cInfo.Name)
+		if isMagnetInProgress(cInfo.Status) {
+			meta.Description = getMetaPreviewDescriptionForProgress(cInfo.Status, cInfo.Progress, cInfo.Speed, cInfo.Seeders, cInfo.ETA) + " " + meta.Description
+		}
 
 		if stremIdByHashes, err := torrent_stream.GetStremIdByHashes([]string{cInfo.Hash}); err != nil {
 			log.Error("failed to get strem id by hashes", "error", err)
@@ -570,6 +644,8 @@ func handleMeta(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	meta.Videos = append(meta.Videos, getStoreItemActionVideos(r, idStoreCode, eud, strings.TrimPrefix(id, idPrefix), cInfo, !idr.isUsenet && !idr.isWebDL)...)
+
 	if !idr.isUsenet && !idr.isWebDL {
 		go torrent_info.Upsert([]torrent_info.TorrentInfoInsertData{tInfo}, "", ctx.Store.GetName().Code() != store.StoreCodeRealDebrid)
 	}
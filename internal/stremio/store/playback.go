@@ -82,7 +82,7 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 		}
 		rParams.APIKey = ctx.StoreAuthToken
 		var lerr error
-		data, err := stremio_store_usenet.GenerateLink(rParams, storeName)
+		data, err := stremio_store_usenet.GenerateLink(rParams, ctx.Store)
 		if err == nil {
 			if config.StoreContentProxy.IsEnabled(string(storeName)) && ctx.StoreAuthToken == config.StoreAuthToken.GetToken(ctx.ProxyAuthUser, string(storeName)) {
 				if ctx.IsProxyAuthorized {
@@ -113,7 +113,7 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 		}
 		rParams.APIKey = ctx.StoreAuthToken
 		var lerr error
-		data, err := stremio_store_webdl.GenerateLink(rParams, storeName)
+		data, err := stremio_store_webdl.GenerateLink(rParams, ctx.Store)
 		if err == nil {
 			if data.Link == "" {
 				store_video.Redirect(store_video.StoreVideoNameDownloading, w, r)
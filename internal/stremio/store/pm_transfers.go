@@ -38,9 +38,11 @@ func getPMWebDLsMeta(r *http.Request, ctx *context.StoreContext, idr *ParsedId,
 	}
 	cacheKey := getPMItemsCacheKey(idr.getStoreCode(), ctx.StoreAuthToken)
 	if !pmItemsCache.Get(cacheKey, &meta.Videos) {
-		params := &stremio_store_webdl.ListWebDLsParams{}
+		params := &stremio_store_webdl.ListWebDLsParams{
+			Limit: 500,
+		}
 		params.APIKey = ctx.StoreAuthToken
-		res, err := stremio_store_webdl.ListWebDLs(params, idr.storeName)
+		res, err := stremio_store_webdl.ListWebDLs(params, ctx.Store)
 		if err != nil {
 			log.Error("failed to list webdls", "error", err, "store.name", idr.storeName)
 			return meta, err
@@ -2,6 +2,7 @@ package stremio_store
 
 import (
 	"bytes"
+	"slices"
 
 	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/stremio/configure"
@@ -69,28 +70,40 @@ func getTemplateData(ud *UserData) *configure.TemplateData {
 	if ud.EnableUsenet {
 		enableUsenetConfig.Default = "checked"
 	}
+	configs := []configure.Config{
+		getStoreNameConfig(ud.StoreName),
+		{
+			Key:         "store_token",
+			Type:        "password",
+			Default:     ud.StoreToken,
+			Title:       "Store Token",
+			Description: "",
+			Required:    true,
+		},
+		hideCatalogConfig,
+		hideStreamConfig,
+		enableWebDLConfig,
+		enableUsenetConfig,
+	}
+	for _, partition := range catalogPartitions {
+		partitionConfig := configure.Config{
+			Key:   "catalog_partition_" + string(partition),
+			Type:  configure.ConfigTypeCheckbox,
+			Title: "Catalog: " + partition.GetTitle(),
+		}
+		if slices.Contains(ud.CatalogPartitions, partition) {
+			partitionConfig.Default = "checked"
+		}
+		configs = append(configs, partitionConfig)
+	}
 	return &configure.TemplateData{
 		Base: configure.Base{
 			Title:       "StremThru Store",
 			Description: "Explore and Search Store Catalog",
 			NavTitle:    "Store",
 		},
-		Configs: []configure.Config{
-			getStoreNameConfig(ud.StoreName),
-			{
-				Key:         "store_token",
-				Type:        "password",
-				Default:     ud.StoreToken,
-				Title:       "Store Token",
-				Description: "",
-				Required:    true,
-			},
-			hideCatalogConfig,
-			hideStreamConfig,
-			enableWebDLConfig,
-			enableUsenetConfig,
-		},
-		Script: configure.GetScriptStoreTokenDescription("'#store_name'", "'#store_token'"),
+		Configs: configs,
+		Script:  configure.GetScriptStoreTokenDescription("'#store_name'", "'#store_token'"),
 	}
 }
 
@@ -1,41 +1,37 @@
 package stremio_store_usenet
 
 import (
-	"errors"
 	"net/http"
-	"regexp"
-	"strconv"
 	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
-	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
-	"github.com/MunifTanjim/stremthru/store/torbox"
 )
 
-var tbClient = torbox.NewAPIClient(&torbox.APIClientConfig{
-	HTTPClient: config.GetHTTPClient(config.StoreTunnel.GetTypeForAPI("torbox")),
-})
-
 func IsSupported(storeCode store.StoreCode) bool {
 	switch storeCode {
-	case store.StoreCodeTorBox:
+	case store.StoreCodeTorBox, store.StoreCodeDebrider:
 		return true
 	default:
 		return false
 	}
 }
 
-type NewsFile struct {
-	Idx       int    `json:"index"`
-	Link      string `json:"link,omitempty"`
-	Name      string `json:"name"`
-	Path      string `json:"path,omitempty"`
-	Size      int64  `json:"size"`
-	VideoHash string `json:"video_hash,omitempty"`
+func getUsenetStore(s store.Store) (store.UsenetStore, error) {
+	us, ok := store.AsUsenetStore(s)
+	if !ok {
+		error := core.NewAPIError("store does not support usenet")
+		error.Code = core.ErrorCodeNotImplemented
+		error.StatusCode = http.StatusNotImplemented
+		error.StoreName = string(s.GetName())
+		return nil, error
+	}
+	return us, nil
 }
 
+type NewsFile = store.NewzFile
+
 type ListNewsParams struct {
 	request.Ctx
 	Limit    int // min 1, max 500, default 100
@@ -43,7 +39,7 @@ type ListNewsParams struct {
 	ClientIP string
 }
 
-type NewsStatus = store.MagnetStatus
+type NewsStatus = store.NewzStatus
 
 type News struct {
 	Id      string     `json:"id"`
@@ -53,6 +49,10 @@ type News struct {
 	Status  NewsStatus `json:"status"`
 	AddedAt time.Time  `json:"added_at"`
 	Files   []NewsFile `json:"files"`
+
+	Progress float64 `json:"progress,omitempty"`
+	Speed    int64   `json:"speed,omitempty"`
+	ETA      int64   `json:"eta,omitempty"`
 }
 
 func (n News) GetLargestFileName() string {
@@ -69,79 +69,50 @@ func (n News) GetLargestFileName() string {
 	return name
 }
 
-var torboxGarbageNewsNameRegex = regexp.MustCompile(`(?i)^\[[a-z0-9]+\]\s*-\s*[a-z0-9]+$`)
-
 type ListNewsData struct {
 	Items      []News `json:"items"`
 	TotalItems int    `json:"total_items"`
 }
 
-func ListNews(params *ListNewsParams, storeName store.StoreName) (*ListNewsData, error) {
+func ListNews(params *ListNewsParams, s store.Store) (*ListNewsData, error) {
 	params.Limit = max(1, min(params.Limit, 500))
 
-	switch storeName {
-	case store.StoreNameTorBox:
-		rParams := &torbox.ListUsenetDownloadParams{
-			Ctx:    params.Ctx,
-			Limit:  params.Limit,
-			Offset: params.Offset,
-		}
-		res, err := tbClient.ListUsenetDownload(rParams)
-		if err != nil {
-			return nil, err
-		}
-
-		data := ListNewsData{}
-		for i := range res.Data {
-			und := &res.Data[i]
-			item := News{
-				Id:      strconv.Itoa(und.Id),
-				Hash:    und.Hash,
-				Name:    und.Name,
-				Size:    und.Size,
-				Status:  store.MagnetStatusUnknown,
-				AddedAt: und.GetAddedAt(),
-			}
-			if und.DownloadState == torbox.TorrentDownloadStateDownloading {
-				item.Status = store.MagnetStatusDownloading
-			} else if und.DownloadFinished && und.DownloadPresent {
-				item.Status = store.MagnetStatusDownloaded
-			}
-			hasGarbageName := torboxGarbageNewsNameRegex.MatchString(item.Name)
-			maxFileSize := int64(0)
-			for i := range und.Files {
-				f := &und.Files[i]
-				file := NewsFile{
-					Idx:  f.Id,
-					Link: torbox.LockedFileLink("").Create(und.Id, f.Id),
-					Name: f.ShortName,
-					Path: "/" + f.Name,
-					Size: f.Size,
-				}
-				if hasGarbageName && file.Size > maxFileSize {
-					item.Name = file.Name
-					maxFileSize = file.Size
-				}
-				item.Files = append(item.Files, file)
-			}
-			data.Items = append(data.Items, item)
-		}
+	us, err := getUsenetStore(s)
+	if err != nil {
+		return nil, err
+	}
 
-		count := len(data.Items)
-		// torbox returns 1 extra item
-		if count > params.Limit {
-			data.Items = data.Items[0:params.Limit]
-			count = params.Limit
-		}
-		data.TotalItems = params.Offset + count
-		if count == params.Limit {
-			data.TotalItems += 1
-		}
+	lParams := &store.ListNewzParams{
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+		ClientIP: params.ClientIP,
+	}
+	lParams.APIKey = params.APIKey
+	res, err := us.ListNewz(lParams)
+	if err != nil {
+		return nil, err
+	}
 
-		return &data, nil
-	default:
-		return &ListNewsData{}, nil
+	data := ListNewsData{
+		Items:      make([]News, 0, len(res.Items)),
+		TotalItems: res.TotalItems,
 	}
+	for i := range res.Items {
+		item := &res.Items[i]
+		data.Items = append(data.Items, News{
+			Id:       item.Id,
+			Hash:     item.Hash,
+			Name:     item.Name,
+			Size:     item.Size,
+			Status:   item.Status,
+			AddedAt:  item.AddedAt,
+			Files:    item.Files,
+			Progress: item.Progress,
+			Speed:    item.Speed,
+			ETA:      item.ETA,
+		})
+	}
+	return &data, nil
 }
 
 type GetNewsParams struct {
@@ -153,58 +124,62 @@ type GetNewsParams struct {
 
 type GetNewsData = News
 
-func GetNews(params *GetNewsParams, storeName store.StoreName) (*News, error) {
-	switch storeName {
-	case store.StoreNameTorBox:
-		id, err := strconv.Atoi(params.Id)
-		if err != nil {
-			return nil, err
-		}
-		rParams := &torbox.GetUsenetDownloadParams{
-			Ctx:         params.Ctx,
-			Id:          id,
-			BypassCache: params.BypassCache,
-		}
-		res, err := tbClient.GetUsenetDownload(rParams)
-		if err != nil {
-			return nil, err
-		}
-		und := &res.Data
-		item := News{
-			Id:      strconv.Itoa(und.Id),
-			Hash:    und.Hash,
-			Name:    und.Name,
-			Size:    und.Size,
-			Status:  store.MagnetStatusUnknown,
-			AddedAt: und.GetAddedAt(),
-		}
-		if und.DownloadState == torbox.TorrentDownloadStateDownloading {
-			item.Status = store.MagnetStatusDownloading
-		}
-		if und.DownloadFinished && und.DownloadPresent {
-			item.Status = store.MagnetStatusDownloaded
-		}
-		hasGarbageName := torboxGarbageNewsNameRegex.MatchString(item.Name)
-		maxFileSize := int64(0)
-		for i := range und.Files {
-			f := &und.Files[i]
-			file := NewsFile{
-				Idx:  f.Id,
-				Link: torbox.LockedFileLink("").Create(und.Id, f.Id),
-				Name: f.ShortName,
-				Path: "/" + f.Name,
-				Size: f.Size,
-			}
-			if hasGarbageName && file.Size > maxFileSize {
-				item.Name = file.Name
-				maxFileSize = file.Size
-			}
-			item.Files = append(item.Files, file)
-		}
-		return &item, nil
-	default:
-		return nil, errors.New("unsupported")
+func GetNews(params *GetNewsParams, s store.Store) (*News, error) {
+	us, err := getUsenetStore(s)
+	if err != nil {
+		return nil, err
 	}
+
+	gParams := &store.GetNewzParams{
+		Id:          params.Id,
+		ClientIP:    params.ClientIP,
+		BypassCache: params.BypassCache,
+	}
+	gParams.APIKey = params.APIKey
+	res, err := us.GetNewz(gParams)
+	if err != nil {
+		return nil, err
+	}
+
+	item := News{
+		Id:       res.Id,
+		Hash:     res.Hash,
+		Name:     res.Name,
+		Size:     res.Size,
+		Status:   res.Status,
+		AddedAt:  res.AddedAt,
+		Files:    res.Files,
+		Progress: res.Progress,
+		Speed:    res.Speed,
+		ETA:      res.ETA,
+	}
+	return &item, nil
+}
+
+type RemoveNewsParams struct {
+	request.Ctx
+	Id string
+}
+
+type RemoveNewsData struct {
+	Id string `json:"id"`
+}
+
+func RemoveNews(params *RemoveNewsParams, s store.Store) (*RemoveNewsData, error) {
+	us, err := getUsenetStore(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rParams := &store.RemoveNewzParams{
+		Id: params.Id,
+	}
+	rParams.APIKey = params.APIKey
+	res, err := us.RemoveNewz(rParams)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveNewsData{Id: res.Id}, nil
 }
 
 type GenerateLinkData struct {
@@ -217,31 +192,20 @@ type GenerateLinkParams struct {
 	CLientIP string
 }
 
-func GenerateLink(params *GenerateLinkParams, storeName store.StoreName) (*GenerateLinkData, error) {
-	switch storeName {
-	case store.StoreNameTorBox:
-		id, fileId, err := torbox.LockedFileLink(params.Link).Parse()
-		if err != nil {
-			error := core.NewAPIError("invalid link")
-			error.StatusCode = http.StatusBadRequest
-			error.Cause = err
-			return nil, error
-		}
-		rParams := &torbox.RequestUsenetDownloadLinkParams{
-			Ctx:      params.Ctx,
-			UsenetId: id,
-			FileId:   fileId,
-			UserIP:   params.CLientIP,
-		}
-		res, err := tbClient.RequestUsenetDownloadLink(rParams)
-		if err != nil {
-			return nil, err
-		}
-		data := GenerateLinkData{
-			Link: res.Data.Link,
-		}
-		return &data, nil
-	default:
-		return nil, errors.New("unsupported")
+func GenerateLink(params *GenerateLinkParams, s store.Store) (*GenerateLinkData, error) {
+	us, err := getUsenetStore(s)
+	if err != nil {
+		return nil, err
+	}
+
+	gParams := &store.GenerateLinkParams{
+		Link:     params.Link,
+		ClientIP: params.CLientIP,
+	}
+	gParams.APIKey = params.APIKey
+	res, err := us.GenerateNewzLink(gParams)
+	if err != nil {
+		return nil, err
 	}
+	return &GenerateLinkData{Link: res.Link}, nil
 }
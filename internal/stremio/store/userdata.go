@@ -20,7 +20,10 @@ type UserData struct {
 	HideStream   bool   `json:"hide_stream,omitempty"`
 	EnableWebDL  bool   `json:"webdl,omitempty"`
 	EnableUsenet bool   `json:"usenet,omitempty"`
-	encoded      string `json:"-"`
+
+	CatalogPartitions []CatalogPartition `json:"catalog_partitions,omitempty"`
+
+	encoded string `json:"-"`
 
 	idPrefixes []string `json:"-"`
 }
@@ -161,6 +164,11 @@ func getUserData(r *http.Request) (*UserData, error) {
 		data.HideStream = r.FormValue("hide_stream") == "on"
 		data.EnableWebDL = r.FormValue("enable_webdl") == "on"
 		data.EnableUsenet = r.FormValue("enable_usenet") == "on"
+		for _, partition := range catalogPartitions {
+			if r.FormValue("catalog_partition_"+string(partition)) == "on" {
+				data.CatalogPartitions = append(data.CatalogPartitions, partition)
+			}
+		}
 		encoded, err := data.GetEncoded()
 		if err != nil {
 			return nil, err
@@ -13,6 +13,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/logger"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
+	store_retention "github.com/MunifTanjim/stremthru/internal/store/retention"
 	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_store "github.com/MunifTanjim/stremthru/internal/stremio/store"
@@ -153,6 +154,7 @@ func handleStrem(w http.ResponseWriter, r *http.Request) {
 		}
 
 		stremio_store.InvalidateCatalogCache(storeCode, ctx.StoreAuthToken)
+		store_retention.TrackAddedMagnet(storeCode, ctx.StoreAuthToken, amRes.Hash)
 
 		magnet := &store.GetMagnetData{
 			Id:      amRes.Id,
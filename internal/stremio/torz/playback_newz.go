@@ -0,0 +1,300 @@
+package stremio_torz
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/kv"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
+	"github.com/MunifTanjim/stremthru/internal/server"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	store_video "github.com/MunifTanjim/stremthru/internal/store/video"
+	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
+	stremio_store "github.com/MunifTanjim/stremthru/internal/stremio/store"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+// nzb links carry the indexer's apikey, so they are kept server-side and
+// only an opaque id is exposed in the stream url.
+var newzLinkStore = kv.NewKVStore[string](&kv.KVStoreConfig{
+	Type:      "torz:newzlink",
+	ExpiresIn: 7 * 24 * time.Hour,
+})
+
+func getNewzLinkId(link string) string {
+	sum := sha256.Sum256([]byte(link))
+	return hex.EncodeToString(sum[:16])
+}
+
+func saveNewzLink(link string) (string, error) {
+	id := getNewzLinkId(link)
+	if err := newzLinkStore.Set(id, link); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func getNewzLink(id string) (string, error) {
+	link := ""
+	if err := newzLinkStore.GetValue(id, &link); err != nil {
+		return "", err
+	}
+	if link == "" {
+		return "", errors.New("nzb link not found")
+	}
+	return link, nil
+}
+
+// fetchNZBFile downloads the nzb file through the user's indexer that
+// served the link, links of other hosts are never fetched.
+func fetchNZBFile(ctx *RequestContext, link string) (*multipart.FileHeader, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	var indexer newznab_client.Indexer
+	for _, idxr := range ctx.NewznabIndexers {
+		if idxr.GetId() == "newznab/"+u.Host {
+			indexer = idxr
+			break
+		}
+	}
+	if indexer == nil {
+		return nil, errors.New("nzb link does not belong to any configured indexer")
+	}
+	blob, err := indexer.FetchNZB(link)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", getNewzLinkId(link)+".nzb")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(blob); err != nil {
+		return nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	form, err := multipart.NewReader(&body, mw.Boundary()).ReadForm(int64(body.Len()) + 1024)
+	if err != nil {
+		return nil, err
+	}
+	return form.File["file"][0], nil
+}
+
+func handleStremNewz(w http.ResponseWriter, r *http.Request) {
+	if !IsMethod(r, http.MethodGet) && !IsMethod(r, http.MethodHead) {
+		shared.ErrorMethodNotAllowed(r).Send(w, r)
+		return
+	}
+
+	log := server.GetReqCtx(r).Log
+
+	linkId := r.PathValue("newzLinkId")
+	fileName := r.PathValue("fileName")
+
+	ud, err := getUserData(r)
+	if err != nil {
+		SendError(w, r, err)
+		return
+	}
+
+	ctx, err := ud.GetRequestContext(r)
+	if err != nil {
+		LogError(r, "failed to get request context", err)
+		shared.ErrorBadRequest(r, "failed to get request context: "+err.Error()).Send(w, r)
+		return
+	}
+
+	sid := r.PathValue("stremId")
+
+	s := ud.GetStoreByCode(r.PathValue("storeCode"))
+	ctx.Store, ctx.StoreAuthToken = s.Store, s.AuthToken
+	storeCode := s.Store.GetName().Code()
+
+	cacheKey := strings.Join([]string{ctx.ClientIP, string(storeCode), ctx.StoreAuthToken, sid, "newz", linkId, fileName}, ":")
+
+	stremLink := ""
+	if stremLinkCache.Get(cacheKey, &stremLink) {
+		log.Debug("redirecting to cached stream link")
+		http.Redirect(w, r, stremLink, http.StatusFound)
+		return
+	}
+
+	result, err, _ := stremGroup.Do(cacheKey, func() (any, error) {
+		us, ok := store.AsUsenetStore(ctx.Store)
+		if !ok {
+			return &stremResult{
+				error_level: logger.LevelWarn,
+				error_log:   "store does not support usenet",
+				error_video: store_video.StoreVideoName500,
+			}, nil
+		}
+
+		link, err := getNewzLink(linkId)
+		if err != nil {
+			return &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "failed to get nzb link",
+				error_video: store_video.StoreVideoName500,
+			}, err
+		}
+
+		log.Debug("creating stream link")
+		anParams := &store.AddNewzParams{
+			Link:     link,
+			ClientIP: ctx.ClientIP,
+		}
+		// debrider only accepts nzb file content
+		if ctx.Store.GetName() == store.StoreNameDebrider {
+			file, err := fetchNZBFile(ctx, link)
+			if err != nil {
+				return &stremResult{
+					error_level: logger.LevelError,
+					error_log:   "failed to fetch nzb file",
+					error_video: store_video.StoreVideoNameDownloadFailed,
+				}, err
+			}
+			anParams.Link = ""
+			anParams.File = file
+		}
+		anParams.APIKey = ctx.StoreAuthToken
+		anRes, err := us.AddNewz(anParams)
+		if err != nil {
+			result := &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "failed to add newz",
+				error_video: store_video.StoreVideoNameDownloadFailed,
+			}
+			var uerr *core.UpstreamError
+			if errors.As(err, &uerr) {
+				switch uerr.Code {
+				case core.ErrorCodeUnauthorized:
+					result.error_level = logger.LevelWarn
+					result.error_log = "unauthorized"
+					result.error_video = store_video.StoreVideoName401
+				case core.ErrorCodeTooManyRequests:
+					result.error_level = logger.LevelWarn
+					result.error_log = "too many requests"
+					result.error_video = store_video.StoreVideoName429
+				case core.ErrorCodePaymentRequired:
+					result.error_level = logger.LevelWarn
+					result.error_log = "payment required"
+					result.error_video = store_video.StoreVideoNamePaymentRequired
+				case core.ErrorCodeStoreLimitExceeded:
+					result.error_log = "store limit exceeded"
+					result.error_video = store_video.StoreVideoNameStoreLimitExceeded
+				}
+			}
+			return result, err
+		}
+
+		stremio_store.InvalidateCatalogCache(storeCode, ctx.StoreAuthToken)
+
+		newz := &store.GetNewzData{
+			Id:      anRes.Id,
+			Hash:    anRes.Hash,
+			Name:    anRes.Name,
+			Size:    anRes.Size,
+			Status:  anRes.Status,
+			Files:   anRes.Files,
+			AddedAt: anRes.AddedAt,
+		}
+
+		newz, err = stremio_shared.WaitForNewzStatus(ctx.StoreContext, newz, store.MagnetStatusDownloaded, 3, 5*time.Second)
+		if err != nil {
+			strem := &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "failed wait for newz status",
+				error_video: store_video.StoreVideoName500,
+			}
+			switch newz.Status {
+			case store.MagnetStatusQueued, store.MagnetStatusDownloading, store.MagnetStatusProcessing:
+				strem.error_level = logger.LevelWarn
+				strem.error_video = store_video.StoreVideoNameDownloading
+			case store.MagnetStatusFailed, store.MagnetStatusInvalid, store.MagnetStatusUnknown:
+				strem.error_level = logger.LevelWarn
+				strem.error_video = store_video.StoreVideoNameDownloadFailed
+			}
+			return strem, err
+		}
+
+		videoFiles := []store.MagnetFile{}
+		for i := range newz.Files {
+			f := &newz.Files[i]
+			if core.HasVideoExtension(f.Name) {
+				videoFiles = append(videoFiles, store.MagnetFile{
+					Idx:  f.Idx,
+					Link: f.Link,
+					Name: f.Name,
+					Path: f.Path,
+					Size: f.Size,
+				})
+			}
+		}
+
+		var file *store.MagnetFile
+		if strings.Contains(sid, ":") {
+			if file = stremio_shared.MatchFileByStremId(videoFiles, sid, newz.Hash, storeCode); file != nil {
+				log.Debug("matched file using strem id", "sid", sid, "filename", file.Name)
+			}
+		}
+		if file == nil && fileName != "" {
+			if file = stremio_shared.MatchFileByName(videoFiles, fileName); file != nil {
+				log.Debug("matched file using filename", "filename", file.Name)
+			}
+		}
+		if file == nil {
+			if file = stremio_shared.MatchFileByLargestSize(videoFiles); file != nil {
+				log.Debug("matched file using largest size", "filename", file.Name)
+			}
+		}
+
+		if file == nil || file.Link == "" {
+			return &stremResult{
+				error_level: logger.LevelWarn,
+				error_log:   "no matching file found for (" + sid + " - " + newz.Hash + ")",
+				error_video: store_video.StoreVideoNameNoMatchingFile,
+			}, nil
+		}
+
+		glRes, err := shared.GenerateStremThruNewzLink(r, ctx.StoreContext, file.Link)
+		if err != nil {
+			return &stremResult{
+				error_level: logger.LevelError,
+				error_log:   "failed to generate stremthru link",
+				error_video: store_video.StoreVideoName500,
+			}, err
+		}
+
+		stremLinkCache.Add(cacheKey, glRes.Link)
+
+		return &stremResult{
+			link: glRes.Link,
+		}, nil
+	})
+
+	strem := result.(*stremResult)
+
+	if strem.error_log != "" {
+		log.Log(strem.error_level, strem.error_log, "error", err)
+		redirectToStaticVideo(w, r, cacheKey, strem.error_video)
+		return
+	}
+
+	log.Debug("redirecting to stream link")
+	http.Redirect(w, r, strem.link, http.StatusFound)
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/MunifTanjim/go-ptt"
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/anidb"
 	"github.com/MunifTanjim/stremthru/internal/buddy"
@@ -18,6 +19,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_shared "github.com/MunifTanjim/stremthru/internal/stremio/shared"
 	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+	"github.com/MunifTanjim/stremthru/internal/torrent_engine"
 	"github.com/MunifTanjim/stremthru/internal/torrent_info"
 	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
 	tznc "github.com/MunifTanjim/stremthru/internal/torznab/client"
@@ -36,6 +38,11 @@ type WrappedStream struct {
 	*stremio.Stream
 	R           *stremio_transformer.StreamExtractorResult
 	torrentLink string
+	newzLinkId  string
+}
+
+func (s WrappedStream) IsNewz() bool {
+	return s.newzLinkId != ""
 }
 
 func (s WrappedStream) IsSortable() bool {
@@ -58,6 +65,10 @@ func (s WrappedStream) GetHDR() string {
 	return strings.Join(s.R.HDR, "|")
 }
 
+func (s WrappedStream) GetExtractorResult() *stremio_transformer.StreamExtractorResult {
+	return s.R
+}
+
 type indexerSearchQueryMeta struct {
 	titles     []string
 	year       int
@@ -73,41 +84,55 @@ func (m *indexerSearchQueryMeta) MatchesTitle(parsedTitle string, normalizer *ut
 	return false
 }
 
-type indexerSearchQuery struct {
-	indexer  tznc.Indexer
+func (m *indexerSearchQueryMeta) MatchesParsedResult(pttr *ptt.Result, isSeries bool, normalizer *util.StringNormalizer) bool {
+	if !m.MatchesTitle(pttr.Title, normalizer) {
+		return false
+	}
+	if isSeries {
+		if !slices.Contains(pttr.Seasons, m.season) {
+			return false
+		}
+		if len(pttr.Episodes) > 0 && !slices.Contains(pttr.Episodes, m.ep) {
+			return false
+		}
+	} else if m.year > 0 {
+		if pttr.Year != "" && pttr.Year != strconv.Itoa(m.year) {
+			return false
+		}
+	}
+	return true
+}
+
+// implemented by both torznab and newznab indexers
+type searchableIndexer interface {
+	GetId() string
+	NewSearchQuery(fn func(caps tznc.Caps) tznc.Function) (*tznc.Query, error)
+}
+
+type indexerSearchQuery[I searchableIndexer] struct {
+	indexer  I
 	query    *tznc.Query
 	is_exact bool
 }
 
 var torrentFetchPool = pond.NewPool(20)
 
-func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]WrappedStream, []string, error) {
-	if len(ctx.Indexers) == 0 {
-		return []WrappedStream{}, []string{}, nil
-	}
-
-	log = ctx.Log
-
-	nsid, err := torrent_stream.NormalizeStreamId(stremId)
-	if err != nil {
-		return nil, nil, err
-	}
-
+func getIndexerSearchQueryMeta(nsid *torrent_stream.NormalizedStremId) (*indexerSearchQueryMeta, error) {
 	queryMeta := indexerSearchQueryMeta{titles: []string{}}
 	if nsid.IsAnime {
 		if aniEp := util.SafeParseInt(nsid.Episode, -1); aniEp != -1 {
 			tvdbMaps, err := anidb.GetTVDBEpisodeMaps(nsid.Id, false)
 			if err != nil {
-				return nil, nil, err
+				return nil, err
 			}
 			if epMap := tvdbMaps.GetByAnidbEpisode(aniEp); epMap != nil {
 				ep := epMap.GetTMDBEpisode(aniEp)
 				titles, err := anidb.GetTitlesByIds([]string{nsid.Id})
 				if err != nil {
-					return nil, nil, err
+					return nil, err
 				}
 				if len(titles) == 0 {
-					return nil, nil, errors.New("no titles found for anidb id: " + nsid.Id)
+					return nil, errors.New("no titles found for anidb id: " + nsid.Id)
 				}
 				queryMeta.titles = make([]string, 0, len(titles))
 				queryMeta.season = epMap.TVDBSeason
@@ -129,10 +154,10 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 	} else {
 		it, err := imdb_title.Get(nsid.Id)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		if it == nil {
-			return nil, nil, errors.New("imdb title not found: " + nsid.Id)
+			return nil, errors.New("imdb title not found: " + nsid.Id)
 		}
 		queryMeta.titles = append(queryMeta.titles, it.Title)
 		if it.OrigTitle != "" && it.OrigTitle != it.Title {
@@ -147,9 +172,13 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 		}
 	}
 
-	sQueries := make([]indexerSearchQuery, 0, len(ctx.Indexers)*2)
-	for i := range ctx.Indexers {
-		indexer := ctx.Indexers[i]
+	return &queryMeta, nil
+}
+
+func buildIndexerSearchQueries[I searchableIndexer](indexers []I, nsid *torrent_stream.NormalizedStremId, queryMeta *indexerSearchQueryMeta) []indexerSearchQuery[I] {
+	sQueries := make([]indexerSearchQuery[I], 0, len(indexers)*2)
+	for i := range indexers {
+		indexer := indexers[i]
 
 		query, err := indexer.NewSearchQuery(func(caps tznc.Caps) tznc.Function {
 			if nsid.IsSeries() && caps.SupportsFunction(tznc.FunctionSearchTV) {
@@ -177,7 +206,7 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 					}
 				}
 			}
-			sQueries = append(sQueries, indexerSearchQuery{
+			sQueries = append(sQueries, indexerSearchQuery[I]{
 				indexer:  indexer,
 				query:    query,
 				is_exact: is_exact,
@@ -192,7 +221,7 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 				var q strings.Builder
 				q.WriteString(title)
 				if nsid.IsSeries() {
-					sQueries = append(sQueries, indexerSearchQuery{
+					sQueries = append(sQueries, indexerSearchQuery[I]{
 						indexer: indexer,
 						query:   query.Clone().Set(tznc.SearchParamQ, q.String()),
 					})
@@ -200,14 +229,14 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 						q.WriteString(" S")
 						q.WriteString(util.ZeroPadInt(queryMeta.season, 2))
 						if queryMeta.ep > 0 {
-							sQueries = append(sQueries, indexerSearchQuery{
+							sQueries = append(sQueries, indexerSearchQuery[I]{
 								indexer: indexer,
 								query:   query.Clone().Set(tznc.SearchParamQ, q.String()),
 							})
 							q.WriteString("E")
 							q.WriteString(util.ZeroPadInt(queryMeta.ep, 2))
 						}
-						sQueries = append(sQueries, indexerSearchQuery{
+						sQueries = append(sQueries, indexerSearchQuery[I]{
 							indexer: indexer,
 							query:   query.Clone().Set(tznc.SearchParamQ, q.String()),
 						})
@@ -217,7 +246,7 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 						q.WriteString(" ")
 						q.WriteString(strconv.Itoa(queryMeta.year))
 					}
-					sQueries = append(sQueries, indexerSearchQuery{
+					sQueries = append(sQueries, indexerSearchQuery[I]{
 						indexer: indexer,
 						query:   query.Clone().Set(tznc.SearchParamQ, q.String()),
 					})
@@ -226,12 +255,42 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 		}
 	}
 
+	return sQueries
+}
+
+func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]WrappedStream, []string, error) {
+	if len(ctx.Indexers) == 0 && len(ctx.NewznabIndexers) == 0 {
+		return []WrappedStream{}, []string{}, nil
+	}
+
+	log = ctx.Log
+
+	nsid, err := torrent_stream.NormalizeStreamId(stremId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	queryMeta, err := getIndexerSearchQueryMeta(nsid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var newzWg sync.WaitGroup
+	var newzStreams []WrappedStream
+	if len(ctx.NewznabIndexers) > 0 {
+		newzWg.Go(func() {
+			newzStreams = getNewzStreamsFromIndexers(ctx, stremType, nsid, queryMeta)
+		})
+	}
+
+	sQueries := buildIndexerSearchQueries(ctx.Indexers, nsid, queryMeta)
+
 	var wg sync.WaitGroup
 	results := make([][]tznc.Torz, len(sQueries))
 	errs := make([]error, len(sQueries))
 	for i := range sQueries {
 		wg.Add(1)
-		go func(sq indexerSearchQuery, i int) {
+		go func(sq indexerSearchQuery[tznc.Indexer], i int) {
 			defer wg.Done()
 			start := time.Now()
 			results[i], errs[i] = sq.indexer.Search(sq.query)
@@ -314,21 +373,9 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 				if err != nil {
 					continue
 				}
-				if !queryMeta.MatchesTitle(pttr.Title, strn) {
+				if !queryMeta.MatchesParsedResult(pttr, nsid.IsSeries(), strn) {
 					continue
 				}
-				if nsid.IsSeries() {
-					if !slices.Contains(pttr.Seasons, queryMeta.season) {
-						continue
-					}
-					if len(pttr.Episodes) > 0 && !slices.Contains(pttr.Episodes, queryMeta.ep) {
-						continue
-					}
-				} else if queryMeta.year > 0 {
-					if pttr.Year != "" && pttr.Year != strconv.Itoa(queryMeta.year) {
-						continue
-					}
-				}
 			}
 
 			if tInfo, ok := tInfoByHash[item.Hash]; ok {
@@ -506,6 +553,9 @@ func GetStreamsFromIndexers(ctx *RequestContext, stremType, stremId string) ([]W
 	}
 	go torrent_info.Upsert(tInfosToUpsert, torrent_info.TorrentInfoCategoryUnknown, false)
 
+	newzWg.Wait()
+	wrappedStreams = append(wrappedStreams, newzStreams...)
+
 	return wrappedStreams, hashes, nil
 }
 
@@ -788,6 +838,34 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var isNewzCachedByHash map[string]string
+	var hasNewzErrByStoreCode map[string]struct{}
+	if !isP2P {
+		newzHashes := []string{}
+		for i := range wrappedStreams {
+			if wrappedStreams[i].IsNewz() {
+				newzHashes = append(newzHashes, wrappedStreams[i].R.Hash)
+			}
+		}
+		if len(newzHashes) > 0 {
+			cnRes := ud.CheckNewz(&store.CheckNewzParams{
+				Hashes:   newzHashes,
+				ClientIP: ctx.ClientIP,
+			}, ctx.Log)
+			isNewzCachedByHash = cnRes.ByHash
+			hasNewzErrByStoreCode = cnRes.HasErrByStoreCode
+		}
+	}
+
+	for i := range wrappedStreams {
+		wStream := &wrappedStreams[i]
+		if wStream.IsNewz() {
+			wStream.R.Store.IsCached = isNewzCachedByHash[wStream.R.Hash] != ""
+		} else {
+			wStream.R.Store.IsCached = isCachedByHash[wStream.R.Hash] != ""
+		}
+	}
+
 	if ud.Filter != "" {
 		filter, err := stremio_transformer.StreamFilterBlob(ud.Filter).Parse()
 		if err == nil {
@@ -800,11 +878,60 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 	stremio_transformer.SortStreams(wrappedStreams, ud.Sort)
 
 	streamBaseUrl := ExtractRequestBaseURL(r).JoinPath("/stremio/torz", eud, "_/strem", id)
+	newzStreamBaseUrl := ExtractRequestBaseURL(r).JoinPath("/stremio/torz", eud, "_/newz", id)
 
 	cachedStreams := []stremio.Stream{}
 	uncachedStreams := []stremio.Stream{}
 	for _, wStream := range wrappedStreams {
 		hash := wStream.R.Hash
+		if wStream.IsNewz() {
+			if isP2P {
+				continue
+			}
+			if storeCode, isCached := isNewzCachedByHash[hash]; isCached && storeCode != "" {
+				storeName := store.StoreCode(strings.ToLower(storeCode)).Name()
+				wStream.R.Store.Code = storeCode
+				wStream.R.Store.Name = string(storeName)
+				wStream.R.Store.IsCached = true
+				wStream.R.Store.IsProxied = ctx.IsProxyAuthorized && config.StoreContentProxy.IsEnabled(string(storeName))
+				stream, err := streamTemplate.Execute(wStream.Stream, wStream.R)
+				if err != nil {
+					SendError(w, r, err)
+					return
+				}
+				stream.URL = getNewzStreamURL(newzStreamBaseUrl, strings.ToLower(storeCode), wStream)
+				stream.FileIndex = 0
+				cachedStreams = append(cachedStreams, *stream)
+			} else if !ud.CachedOnly {
+				stores := ud.GetStores()
+				for i := range stores {
+					s := &stores[i]
+					if _, ok := store.AsUsenetStore(s.Store); !ok {
+						continue
+					}
+					storeName := s.Store.GetName()
+					storeCode := storeName.Code()
+					if _, hasErr := hasNewzErrByStoreCode[strings.ToUpper(string(storeCode))]; hasErr {
+						continue
+					}
+
+					origStream := *wStream.Stream
+					wStream.R.Store.Code = strings.ToUpper(string(storeCode))
+					wStream.R.Store.Name = string(storeName)
+					wStream.R.Store.IsProxied = ctx.IsProxyAuthorized && config.StoreContentProxy.IsEnabled(string(storeName))
+					stream, err := streamTemplate.Execute(&origStream, wStream.R)
+					if err != nil {
+						SendError(w, r, err)
+						return
+					}
+					stream.URL = getNewzStreamURL(newzStreamBaseUrl, string(storeCode), wStream)
+					stream.FileIndex = 0
+					uncachedStreams = append(uncachedStreams, *stream)
+				}
+			}
+			continue
+		}
+
 		if isP2P {
 			if wStream.FileIndex == -1 || wStream.R.IsPrivate {
 				continue
@@ -812,11 +939,29 @@ func handleStream(w http.ResponseWriter, r *http.Request) {
 
 			wStream.R.Store.Code = "P2P"
 			wStream.R.Store.Name = "P2P"
+			wStream.R.Store.IsProxied = torrent_engine.IsEnabled && ctx.IsProxyAuthorized
 			stream, err := streamTemplate.Execute(wStream.Stream, wStream.R)
 			if err != nil {
 				SendError(w, r, err)
 				return
 			}
+			if wStream.R.Store.IsProxied {
+				trackers := []string{}
+				for _, source := range stream.Sources {
+					if tracker, ok := strings.CutPrefix(source, "tracker:"); ok {
+						trackers = append(trackers, tracker)
+					}
+				}
+				link := torrent_engine.CreateMagnetLink(hash, wStream.R.File.Idx, trackers)
+				if proxyLink, err := shared.CreateTorrentProxyLink(r, link, 12*time.Hour, ctx.ProxyAuthUser, ctx.ProxyAuthPassword, wStream.R.File.Name); err == nil {
+					stream.URL = proxyLink
+					stream.InfoHash = ""
+					stream.FileIndex = 0
+					stream.Sources = nil
+				} else {
+					log.Error("failed to create proxy link", "error", err)
+				}
+			}
 			uncachedStreams = append(uncachedStreams, *stream)
 		} else if storeCode, isCached := isCachedByHash[hash]; isCached && storeCode != "" {
 			storeName := store.StoreCode(strings.ToLower(storeCode)).Name()
@@ -0,0 +1,108 @@
+package stremio_torz
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/core"
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+	"github.com/MunifTanjim/stremthru/internal/torrent_stream"
+	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/MunifTanjim/stremthru/stremio"
+)
+
+func getNewzStreamsFromIndexers(ctx *RequestContext, stremType string, nsid *torrent_stream.NormalizedStremId, queryMeta *indexerSearchQueryMeta) []WrappedStream {
+	sQueries := buildIndexerSearchQueries(ctx.NewznabIndexers, nsid, queryMeta)
+
+	var wg sync.WaitGroup
+	results := make([][]newznab_client.Newz, len(sQueries))
+	for i := range sQueries {
+		sq := sQueries[i]
+		wg.Go(func() {
+			start := time.Now()
+			items, err := sq.indexer.Search(sq.query)
+			if err != nil {
+				log.Error("newznab indexer search failed", "error", err, "indexer", sq.indexer.GetId(), "query", sq.query.Encode(), "duration", time.Since(start).String())
+				return
+			}
+			log.Debug("newznab indexer search completed", "indexer", sq.indexer.GetId(), "query", sq.query.Encode(), "duration", time.Since(start).String(), "count", len(items))
+			results[i] = items
+		})
+	}
+	wg.Wait()
+
+	strn := util.NewStringNormalizer()
+	seenHash := util.NewSet[string]()
+	wrappedStreams := []WrappedStream{}
+	for i, items := range results {
+		is_exact := sQueries[i].is_exact
+		for i := range items {
+			item := &items[i]
+			if seenHash.Has(item.Hash) {
+				continue
+			}
+
+			pttr, err := util.ParseTorrentTitle(item.Title)
+			if err != nil {
+				continue
+			}
+			if !is_exact && !queryMeta.MatchesParsedResult(pttr, nsid.IsSeries(), strn) {
+				continue
+			}
+			seenHash.Add(item.Hash)
+
+			linkId, err := saveNewzLink(item.Link)
+			if err != nil {
+				log.Error("failed to save nzb link", "error", err, "indexer", item.Indexer)
+				continue
+			}
+
+			if pttr.Size == "" && item.Size > 0 {
+				pttr.Size = util.ToSize(item.Size)
+			}
+
+			data := &stremio_transformer.StreamExtractorResult{
+				Hash:    item.Hash,
+				TTitle:  item.Title,
+				Result:  pttr,
+				Indexer: item.Indexer,
+				Addon: stremio_transformer.StreamExtractorResultAddon{
+					Name: "Torz",
+				},
+				Category: stremType,
+				File: stremio_transformer.StreamExtractorResultFile{
+					Idx: -1,
+				},
+			}
+			if core.HasVideoExtension(item.Title) {
+				data.File.Name = item.Title
+			}
+
+			wrappedStreams = append(wrappedStreams, WrappedStream{
+				newzLinkId: linkId,
+				R:          data,
+				Stream: &stremio.Stream{
+					Name:        data.Addon.Name,
+					Description: data.TTitle,
+					FileIndex:   -1,
+					BehaviorHints: &stremio.StreamBehaviorHints{
+						Filename:   data.File.Name,
+						BingeGroup: "torz:newz:" + data.Hash,
+					},
+				},
+			})
+		}
+	}
+
+	return wrappedStreams
+}
+
+func getNewzStreamURL(baseUrl *url.URL, storeCode string, wStream WrappedStream) string {
+	streamUrl := baseUrl.JoinPath(storeCode, wStream.newzLinkId, "/")
+	if wStream.R.File.Name != "" {
+		streamUrl = streamUrl.JoinPath(url.PathEscape(wStream.R.File.Name))
+	}
+	return streamUrl.String()
+}
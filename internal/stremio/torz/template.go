@@ -99,6 +99,14 @@ func getIndexerNameOptions() []configure.ConfigOption {
 			Value: string(stremio_userdata.IndexerNameJackett),
 			Label: "Jackett",
 		},
+		{
+			Value: string(stremio_userdata.IndexerNameProwlarr),
+			Label: "Prowlarr",
+		},
+		{
+			Value: string(stremio_userdata.IndexerNameNewznab),
+			Label: "Newznab",
+		},
 	}
 	return options
 }
@@ -158,7 +166,7 @@ func getTemplateData(ud *UserData, w http.ResponseWriter, r *http.Request) *Temp
 			Type:        "text",
 			Default:     ud.Sort,
 			Title:       "Stream Sort",
-			Description: "Comma separated fields: <code>resolution</code>, <code>quality</code>, <code>size</code>, <code>hdr</code>. Prefix with <code>-</code> for reverse sort. Default: <code>" + stremio_transformer.StreamDefaultSortConfig + "</code>",
+			Description: "Comma separated fields: <code>resolution</code>, <code>quality</code>, <code>size</code>, <code>hdr</code>. Prefix with <code>-</code> for reverse sort. Default: <code>" + stremio_transformer.StreamDefaultSortConfig + "</code><br />Or, score expression (same as filter), higher score first, e.g. <code>[Store.IsCached && \"en\" in Languages, bytes(Size)]</code>",
 		},
 		FilterConfig: configure.Config{
 			Key:         "filter",
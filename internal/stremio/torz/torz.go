@@ -43,5 +43,8 @@ func AddStremioTorzEndpoints(mux *http.ServeMux) {
 	router.HandleFunc("/{userData}/_/strem/{stremId}/{storeCode}/{magnetHash}/{fileIdx}/{$}", withCors(handleStrem))
 	router.HandleFunc("/{userData}/_/strem/{stremId}/{storeCode}/{magnetHash}/{fileIdx}/{fileName}", withCors(handleStrem))
 
+	router.HandleFunc("/{userData}/_/newz/{stremId}/{storeCode}/{newzLinkId}/{$}", withCors(handleStremNewz))
+	router.HandleFunc("/{userData}/_/newz/{stremId}/{storeCode}/{newzLinkId}/{fileName}", withCors(handleStremNewz))
+
 	mux.Handle("/stremio/torz/", http.StripPrefix("/stremio/torz", commonMiddleware(router)))
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/MunifTanjim/stremthru/internal/context"
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
 	"github.com/MunifTanjim/stremthru/internal/server"
 	"github.com/MunifTanjim/stremthru/internal/shared"
 	stremio_userdata "github.com/MunifTanjim/stremthru/internal/stremio/userdata"
@@ -133,7 +134,8 @@ func (uderr *userDataError) Error() string {
 
 type RequestContext struct {
 	*context.StoreContext
-	Indexers []torznab_client.Indexer
+	Indexers        []torznab_client.Indexer
+	NewznabIndexers []newznab_client.Indexer
 }
 
 func (ud *UserData) GetRequestContext(r *http.Request) (*RequestContext, error) {
@@ -167,6 +169,12 @@ func (ud *UserData) GetRequestContext(r *http.Request) (*RequestContext, error)
 		ctx.Indexers = indexers
 	}
 
+	if indexers, err := ud.UserDataIndexers.PrepareNewznab(); err != nil {
+		return ctx, &userDataError{indexerURL: []string{err.Error()}}
+	} else {
+		ctx.NewznabIndexers = indexers
+	}
+
 	return ctx, nil
 }
 
@@ -138,7 +138,7 @@ type StreamExtractorResult struct {
 	Seeders   int
 	Store     StreamExtractorResultStore
 	TTitle    string `expr:"-"`
-	Indexer   string `expr:"-"`
+	Indexer   string
 }
 
 var language_to_code = map[string]string{
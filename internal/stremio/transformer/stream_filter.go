@@ -91,6 +91,27 @@ func (ValuePatcher) Visit(node *ast.Node) {
 	}
 }
 
+// exprOptions returns the environment shared by filter and sort expressions.
+func exprOptions() []expr.Option {
+	return []expr.Option{
+		expr.Env(&StreamExtractorResult{}),
+		expr.AllowUndefinedVariables(),
+		expr.Function("__Resolution__", func(val ...any) (any, error) {
+			return Resolution(val[0].(string)), nil
+		}, new(func(string) Resolution)),
+		expr.Function("__Quality__", func(val ...any) (any, error) {
+			return Quality(val[0].(string)), nil
+		}, new(func(string) Quality)),
+		expr.Function("__Size__", func(val ...any) (any, error) {
+			return Size(val[0].(string)), nil
+		}, new(func(string) Size)),
+		expr.Function("bytes", func(val ...any) (any, error) {
+			return max(getSizeRank(val[0].(string)), 0), nil
+		}, new(func(string) int64)),
+		expr.Patch(ValuePatcher{}),
+	}
+}
+
 type StreamFilterEnv struct {
 	*StreamExtractorResult
 }
@@ -111,22 +132,7 @@ func (sfb StreamFilterBlob) Parse() (*StreamFilter, error) {
 		return sf, nil
 	}
 
-	program, err := expr.Compile(
-		string(sfb),
-		expr.Env(&StreamExtractorResult{}),
-		expr.AsBool(),
-		expr.AllowUndefinedVariables(),
-		expr.Function("__Resolution__", func(val ...any) (any, error) {
-			return Resolution(val[0].(string)), nil
-		}, new(func(string) Resolution)),
-		expr.Function("__Quality__", func(val ...any) (any, error) {
-			return Quality(val[0].(string)), nil
-		}, new(func(string) Quality)),
-		expr.Function("__Size__", func(val ...any) (any, error) {
-			return Size(val[0].(string)), nil
-		}, new(func(string) Size)),
-		expr.Patch(ValuePatcher{}),
-	)
+	program, err := expr.Compile(string(sfb), append(exprOptions(), expr.AsBool())...)
 	if err != nil {
 		return sf, err
 	}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/MunifTanjim/stremthru/internal/util"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 )
 
 type StreamSortableField string
@@ -22,6 +24,7 @@ type StreamSortable interface {
 	GetResolution() string
 	GetSize() string
 	GetHDR() string
+	GetExtractorResult() *StreamExtractorResult
 	IsSortable() bool
 }
 
@@ -133,8 +136,8 @@ type StreamSorterConfig struct {
 	Desc  bool
 }
 
-func parseSortConfig(config string) []StreamSorterConfig {
-	sortConfigs := []StreamSorterConfig{}
+func parseSortConfig(config string) (sortConfigs []StreamSorterConfig, hasUnknownField bool) {
+	sortConfigs = []StreamSorterConfig{}
 	for part := range strings.SplitSeq(config, ",") {
 		part = strings.TrimSpace(part)
 		desc := strings.HasPrefix(part, "-")
@@ -142,13 +145,77 @@ func parseSortConfig(config string) []StreamSorterConfig {
 		switch field {
 		case StreamSortableFieldResolution, StreamSortableFieldQuality, StreamSortableFieldSize, StreamSortableFieldHDR:
 			sortConfigs = append(sortConfigs, StreamSorterConfig{Field: field, Desc: desc})
+		default:
+			hasUnknownField = true
 		}
 	}
-	return sortConfigs
+	return sortConfigs, hasUnknownField
+}
+
+// compileSortExpr compiles a sort expression, evaluated to a score (or a list
+// of scores, compared in order) for each stream. Higher score comes first.
+func compileSortExpr(config string) (*vm.Program, error) {
+	return expr.Compile(config, exprOptions()...)
+}
+
+func toScore(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+func getScores(program *vm.Program, r *StreamExtractorResult) []float64 {
+	output, err := expr.Run(program, r)
+	if err != nil {
+		return nil
+	}
+	if list, ok := output.([]any); ok {
+		scores := make([]float64, len(list))
+		for i := range list {
+			scores[i], _ = toScore(list[i])
+		}
+		return scores
+	}
+	if score, ok := toScore(output); ok {
+		return []float64{score}
+	}
+	return nil
+}
+
+func compareScores(a, b []float64) int {
+	for i := range max(len(a), len(b)) {
+		va, vb := float64(0), float64(0)
+		if i < len(a) {
+			va = a[i]
+		}
+		if i < len(b) {
+			vb = b[i]
+		}
+		if va != vb {
+			if va > vb {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
 }
 
 type streamSorter[T StreamSortable] struct {
 	items  []T
+	scores [][]float64
 	config []StreamSorterConfig
 }
 
@@ -157,6 +224,9 @@ func (ss streamSorter[StreamSortable]) Len() int {
 }
 func (ss streamSorter[StreamSortable]) Swap(i, j int) {
 	ss.items[i], ss.items[j] = ss.items[j], ss.items[i]
+	if ss.scores != nil {
+		ss.scores[i], ss.scores[j] = ss.scores[j], ss.scores[i]
+	}
 }
 
 func (ss streamSorter[StreamSortable]) Less(a, b int) bool {
@@ -167,6 +237,12 @@ func (ss streamSorter[StreamSortable]) Less(a, b int) bool {
 		return false
 	}
 
+	if ss.scores != nil {
+		if cmp := compareScores(ss.scores[a], ss.scores[b]); cmp != 0 {
+			return cmp > 0
+		}
+	}
+
 	for _, config := range ss.config {
 		va := getFieldRank(aData, config.Field)
 		vb := getFieldRank(bData, config.Field)
@@ -186,15 +262,42 @@ func (ss streamSorter[StreamSortable]) Less(a, b int) bool {
 
 const StreamDefaultSortConfig = "-resolution,-quality,-size"
 
+// SortStreams sorts by comma separated fields, e.g. `-resolution,-size`.
+// Otherwise config is treated as an expression, and streams with equal
+// score are sorted using `StreamDefaultSortConfig`.
 func SortStreams[T StreamSortable](items []T, config string) {
 	if config == "" {
 		config = StreamDefaultSortConfig
 	}
 
-	sortConfigs := parseSortConfig(config)
-	if len(sortConfigs) == 0 {
+	sortConfigs, hasUnknownField := parseSortConfig(config)
+	if !hasUnknownField {
+		if len(sortConfigs) == 0 {
+			return
+		}
+		sorter := streamSorter[T]{items: items, config: sortConfigs}
+		sort.Stable(sorter)
+		return
+	}
+
+	program, err := compileSortExpr(config)
+	if err != nil {
+		log.Warn("failed to parse sort expression", "error", err)
+		if len(sortConfigs) == 0 {
+			return
+		}
+		sorter := streamSorter[T]{items: items, config: sortConfigs}
+		sort.Stable(sorter)
 		return
 	}
-	sorter := streamSorter[T]{items: items, config: sortConfigs}
+
+	scores := make([][]float64, len(items))
+	for i := range items {
+		if items[i].IsSortable() {
+			scores[i] = getScores(program, items[i].GetExtractorResult())
+		}
+	}
+	defaultSortConfigs, _ := parseSortConfig(StreamDefaultSortConfig)
+	sorter := streamSorter[T]{items: items, scores: scores, config: defaultSortConfigs}
 	sort.Stable(sorter)
 }
@@ -0,0 +1,130 @@
+package stremio_transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MunifTanjim/go-ptt"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSortableStream struct {
+	name string
+	r    *StreamExtractorResult
+}
+
+func (s testSortableStream) GetQuality() string {
+	return s.r.Quality
+}
+
+func (s testSortableStream) GetResolution() string {
+	return s.r.Resolution
+}
+
+func (s testSortableStream) GetSize() string {
+	return s.r.Size
+}
+
+func (s testSortableStream) GetHDR() string {
+	return strings.Join(s.r.HDR, "|")
+}
+
+func (s testSortableStream) GetExtractorResult() *StreamExtractorResult {
+	return s.r
+}
+
+func (s testSortableStream) IsSortable() bool {
+	return s.r != nil
+}
+
+func getSortedNames(items []testSortableStream) []string {
+	names := make([]string, len(items))
+	for i := range items {
+		names[i] = items[i].name
+	}
+	return names
+}
+
+func TestSortStreams(t *testing.T) {
+	newItems := func() []testSortableStream {
+		return []testSortableStream{
+			{
+				name: "a",
+				r: &StreamExtractorResult{
+					Result:  &ptt.Result{Resolution: "2160p", Codec: "x264", Size: "20 GB", Languages: []string{"en"}},
+					Seeders: 5,
+				},
+			},
+			{
+				name: "b",
+				r: &StreamExtractorResult{
+					Result:  &ptt.Result{Resolution: "1080p", Codec: "x265", Size: "2 GB", Languages: []string{"en"}},
+					Seeders: 50,
+					Store:   StreamExtractorResultStore{IsCached: true},
+				},
+			},
+			{
+				name: "c",
+				r: &StreamExtractorResult{
+					Result:  &ptt.Result{Resolution: "1080p", Codec: "x265", Size: "4 GB", Languages: []string{"en"}},
+					Seeders: 10,
+					Store:   StreamExtractorResultStore{IsCached: true},
+				},
+			},
+			{
+				name: "d",
+				r: &StreamExtractorResult{
+					Result:  &ptt.Result{Resolution: "1080p", Codec: "x265", Size: "8 GB", Languages: []string{"fr"}},
+					Seeders: 100,
+					Store:   StreamExtractorResultStore{IsCached: true},
+				},
+			},
+			{
+				name: "e",
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name:     "fields",
+			config:   "-resolution,-size",
+			expected: []string{"a", "d", "c", "b", "e"},
+		},
+		{
+			name:     "expression",
+			config:   `Seeders`,
+			expected: []string{"d", "b", "c", "a", "e"},
+		},
+		{
+			name:     "expression with tie breaker",
+			config:   `("en" in Languages ? 4 : 0) + (Resolution == "1080p" ? 2 : 0) + (Codec == "x265" ? 1 : 0) + (Store.IsCached ? 1 : 0)`,
+			expected: []string{"c", "b", "a", "d", "e"},
+		},
+		{
+			name:     "expression list",
+			config:   `["en" in Languages && Resolution == "1080p" && Codec == "x265" && Store.IsCached, bytes(Size)]`,
+			expected: []string{"c", "b", "a", "d", "e"},
+		},
+		{
+			name:     "expression with size band",
+			config:   `Size < "5GB" ? 1 : 0`,
+			expected: []string{"c", "b", "a", "d", "e"},
+		},
+		{
+			name:     "invalid expression",
+			config:   "-size,(",
+			expected: []string{"a", "d", "c", "b", "e"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			items := newItems()
+			SortStreams(items, tc.config)
+			assert.Equal(t, tc.expected, getSortedNames(items))
+		})
+	}
+}
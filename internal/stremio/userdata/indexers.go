@@ -3,21 +3,30 @@ package stremio_userdata
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"time"
 
 	"github.com/MunifTanjim/stremthru/internal/cache"
+	newznab_client "github.com/MunifTanjim/stremthru/internal/newznab/client"
 	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
 	"github.com/MunifTanjim/stremthru/internal/torznab/jackett"
+	"github.com/MunifTanjim/stremthru/internal/torznab/prowlarr"
 )
 
 type IndexerName string
 
 const (
-	IndexerNameGeneric IndexerName = "generic"
-	IndexerNameJackett IndexerName = "jackett"
+	IndexerNameGeneric  IndexerName = "generic"
+	IndexerNameJackett  IndexerName = "jackett"
+	IndexerNameProwlarr IndexerName = "prowlarr"
+	IndexerNameNewznab  IndexerName = "newznab"
 )
 
+func (n IndexerName) IsNewznab() bool {
+	return n == IndexerNameNewznab
+}
+
 type Indexer struct {
 	Name   IndexerName `json:"n"`
 	URL    string      `json:"u"`
@@ -46,6 +55,8 @@ func (i *Indexer) Decompress() {
 	switch i.Name {
 	case IndexerNameJackett:
 		i.URL = jackett.TorznabURL(i.URL).Decode()
+	case IndexerNameProwlarr:
+		i.URL = prowlarr.TorznabURL(i.URL).Decode()
 	}
 }
 
@@ -53,6 +64,8 @@ func (i *Indexer) Compress() {
 	switch i.Name {
 	case IndexerNameJackett:
 		i.URL = jackett.TorznabURL(i.URL).Encode()
+	case IndexerNameProwlarr:
+		i.URL = prowlarr.TorznabURL(i.URL).Encode()
 	}
 }
 
@@ -68,6 +81,14 @@ func (i Indexer) Validate() (string, error) {
 		if err := jackett.TorznabURL(i.URL).Parse(); err != nil {
 			return "url", fmt.Errorf("indexer url is invalid")
 		}
+	case IndexerNameProwlarr:
+		if err := prowlarr.TorznabURL(i.URL).Parse(); err != nil {
+			return "url", fmt.Errorf("indexer url is invalid")
+		}
+	case IndexerNameNewznab:
+		if u, err := url.Parse(i.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "url", fmt.Errorf("indexer url is invalid")
+		}
 	}
 	return "", nil
 }
@@ -100,6 +121,16 @@ var jackettCache = cache.NewLRUCache[*jackett.Client](&cache.CacheConfig{
 	Name:     "stremio:userdata:indexers:jackett",
 })
 
+var prowlarrCache = cache.NewLRUCache[*prowlarr.Client](&cache.CacheConfig{
+	Lifetime: 2 * time.Hour,
+	Name:     "stremio:userdata:indexers:prowlarr",
+})
+
+var newznabCache = cache.NewLRUCache[*newznab_client.Client](&cache.CacheConfig{
+	Lifetime: 2 * time.Hour,
+	Name:     "stremio:userdata:indexers:newznab",
+})
+
 func (ud *UserDataIndexers) Compress() {
 	for i := range ud.Indexers {
 		indexer := &ud.Indexers[i]
@@ -119,6 +150,10 @@ func (ud *UserDataIndexers) Prepare() ([]torznab_client.Indexer, error) {
 	for i := range ud.Indexers {
 		indexer := &ud.Indexers[i]
 
+		if indexer.Name.IsNewznab() {
+			continue
+		}
+
 		baseURL := indexer.URL
 		apiKey := indexer.APIKey
 
@@ -144,9 +179,55 @@ func (ud *UserDataIndexers) Prepare() ([]torznab_client.Indexer, error) {
 			c := client.GetTorznabClient(u.IndexerId)
 			indexers = append(indexers, c)
 
+		case IndexerNameProwlarr:
+			u := prowlarr.TorznabURL(baseURL)
+			if err := u.Parse(); err != nil {
+				return indexers, err
+			}
+
+			key := u.BaseURL + ":" + apiKey
+			var client *prowlarr.Client
+			if !prowlarrCache.Get(key, &client) {
+				client = prowlarr.NewClient(&prowlarr.ClientConfig{
+					BaseURL: u.BaseURL,
+					APIKey:  apiKey,
+				})
+				err := prowlarrCache.Add(key, client)
+				if err != nil {
+					return indexers, err
+				}
+			}
+			c := client.GetTorznabClient(u.IndexerId)
+			indexers = append(indexers, c)
+
 		default:
 			return indexers, errors.New("unsupported indexer: " + string(indexer.Name))
 		}
 	}
 	return indexers, nil
 }
+
+func (ud *UserDataIndexers) PrepareNewznab() ([]newznab_client.Indexer, error) {
+	indexers := []newznab_client.Indexer{}
+	for i := range ud.Indexers {
+		indexer := &ud.Indexers[i]
+		if !indexer.Name.IsNewznab() {
+			continue
+		}
+
+		key := indexer.URL + ":" + indexer.APIKey
+		var client *newznab_client.Client
+		if !newznabCache.Get(key, &client) {
+			client = newznab_client.NewClient(&newznab_client.ClientConfig{
+				BaseURL: indexer.URL,
+				APIKey:  indexer.APIKey,
+			})
+			err := newznabCache.Add(key, client)
+			if err != nil {
+				return indexers, err
+			}
+		}
+		indexers = append(indexers, client)
+	}
+	return indexers, nil
+}
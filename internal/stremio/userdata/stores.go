@@ -103,6 +103,20 @@ func (ud *UserDataStores) Prepare(ctx *context.StoreContext) (err error, errFiel
 		ud.stores = stores
 		ud.isStremThruStore = true
 	} else if storeCount == 1 && ud.Stores[0].Code.IsP2P() {
+		// optional token, authorizes streaming through the torrent engine
+		if token := ud.Stores[0].Token; token != "" {
+			auth, err := core.ParseBasicAuth(token)
+			if err != nil {
+				return err, "token"
+			}
+			password := config.ProxyAuthPassword.GetPassword(auth.Username)
+			if password == "" || password != auth.Password {
+				return errors.New("invalid token"), "token"
+			}
+			ctx.IsProxyAuthorized = true
+			ctx.ProxyAuthUser = auth.Username
+			ctx.ProxyAuthPassword = auth.Password
+		}
 		ud.stores = nil
 		ud.isP2P = true
 		return nil, ""
@@ -291,3 +305,63 @@ func (ud *UserDataStores) CheckMagnet(params *store.CheckMagnetParams, log *logg
 
 	return &res
 }
+
+func (ud *UserDataStores) CheckNewz(params *store.CheckNewzParams, log *logger.Logger) *storesCheckMagnetData {
+	ms := ud.stores
+
+	storeCount := len(ms)
+	res := storesCheckMagnetData{
+		ByHash:            map[string]string{},
+		Err:               make([]error, storeCount),
+		HasErr:            false,
+		HasErrByStoreCode: map[string]struct{}{},
+	}
+
+	cachedHashes := make([][]string, storeCount)
+
+	var wg sync.WaitGroup
+	for i := range ms {
+		s := &ms[i]
+		us, ok := store.AsUsenetStore(s.Store)
+		if !ok {
+			continue
+		}
+
+		wg.Go(func() {
+			cnParams := &store.CheckNewzParams{
+				Hashes:   params.Hashes,
+				ClientIP: params.ClientIP,
+			}
+			cnParams.APIKey = s.AuthToken
+			cnRes, err := us.CheckNewz(cnParams)
+			if err != nil {
+				log.Warn("failed to check newz", "error", err, "store.name", s.Store.GetName())
+				storeCode := strings.ToUpper(string(s.Store.GetName().Code()))
+				res.m.Lock()
+				defer res.m.Unlock()
+				res.Err[i] = err
+				res.HasErr = true
+				res.HasErrByStoreCode[storeCode] = struct{}{}
+				return
+			}
+			for _, item := range cnRes.Items {
+				if item.Status == store.MagnetStatusCached {
+					cachedHashes[i] = append(cachedHashes[i], item.Hash)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	// earlier stores take precedence
+	for i := range ms {
+		storeCode := strings.ToUpper(string(ms[i].Store.GetName().Code()))
+		for _, hash := range cachedHashes[i] {
+			if _, found := res.ByHash[hash]; !found {
+				res.ByHash[hash] = storeCode
+			}
+		}
+	}
+
+	return &res
+}
@@ -121,7 +121,7 @@ func handleConfigure(w http.ResponseWriter, r *http.Request) {
 				}
 				if up.ExtractorError == "" {
 					if value == "" {
-						if err := extractorStore.Del(id); err != nil {
+						if err := ExtractorStore.Delete(id); err != nil {
 							LogError(r, "failed to delete extractor", err)
 							up.ExtractorError = "Failed to delete extractor"
 						}
@@ -140,7 +140,7 @@ func handleConfigure(w http.ResponseWriter, r *http.Request) {
 							}
 						}
 					} else {
-						if err := extractorStore.Set(id, value); err != nil {
+						if err := ExtractorStore.Save(id, value); err != nil {
 							LogError(r, "failed to save extractor", err)
 							up.ExtractorError = "Failed to save extractor"
 						} else {
@@ -198,7 +198,7 @@ func handleConfigure(w http.ResponseWriter, r *http.Request) {
 				}
 				if td.TemplateError.IsEmpty() {
 					if value.Name == "" && value.Description == "" {
-						if err := templateStore.Del(id); err != nil {
+						if err := TemplateStore.Delete(id); err != nil {
 							LogError(r, "failed to delete template", err)
 							td.TemplateError.Name = "Failed to delete template"
 							td.TemplateError.Description = "Failed to delete template"
@@ -213,7 +213,7 @@ func handleConfigure(w http.ResponseWriter, r *http.Request) {
 						td.TemplateId = ""
 						td.Template = stremio_transformer.StreamTemplateBlob{}
 					} else {
-						if err := templateStore.Set(id, value); err != nil {
+						if err := TemplateStore.Save(id, value); err != nil {
 							LogError(r, "failed to save template", err)
 							td.TemplateError.Name = "Failed to save template"
 							td.TemplateError.Description = "Failed to save template"
@@ -41,6 +41,10 @@ func (ws WrappedStream) GetHDR() string {
 	return strings.Join(ws.r.HDR, "|")
 }
 
+func (ws WrappedStream) GetExtractorResult() *stremio_transformer.StreamExtractorResult {
+	return ws.r
+}
+
 func (st StreamTransformer) Do(stream *stremio.Stream, sType string, tryReconfigure bool) (*WrappedStream, error) {
 	s := &WrappedStream{Stream: stream}
 
@@ -0,0 +1,185 @@
+package stremio_wrap
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/kv"
+	stremio_transformer "github.com/MunifTanjim/stremthru/internal/stremio/transformer"
+)
+
+const maxTransformerEntityVersions = 10
+
+func IsBuiltInTransformerEntityId(id string) bool {
+	return strings.HasPrefix(id, BUILTIN_TRANSFORMER_ENTITY_ID_EMOJI)
+}
+
+type TransformerEntity[V any] struct {
+	Id        string
+	Value     V
+	IsBuiltIn bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type TransformerEntityVersion[V any] struct {
+	Version   int64
+	Value     V
+	CreatedAt time.Time
+}
+
+// TransformerEntityStore manages user-defined transformer entities, keeping
+// the previous values of each entity as versions.
+type TransformerEntityStore[V any] struct {
+	store   kv.KVStore[V]
+	history kv.KVStore[V]
+	builtIn map[string]V
+}
+
+func (s *TransformerEntityStore[V]) List() ([]TransformerEntity[V], error) {
+	items, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]TransformerEntity[V], 0, len(s.builtIn)+len(items))
+	for id, value := range s.builtIn {
+		entities = append(entities, TransformerEntity[V]{
+			Id:        id,
+			Value:     value,
+			IsBuiltIn: true,
+		})
+	}
+	for i := range items {
+		item := &items[i]
+		entities = append(entities, TransformerEntity[V]{
+			Id:        item.Key,
+			Value:     item.Value,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	slices.SortFunc(entities, func(a, b TransformerEntity[V]) int {
+		if a.IsBuiltIn != b.IsBuiltIn {
+			if a.IsBuiltIn {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	return entities, nil
+}
+
+func (s *TransformerEntityStore[V]) Get(id string) (*TransformerEntity[V], error) {
+	if IsBuiltInTransformerEntityId(id) {
+		if value, ok := s.builtIn[id]; ok {
+			return &TransformerEntity[V]{Id: id, Value: value, IsBuiltIn: true}, nil
+		}
+		return nil, nil
+	}
+
+	items, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		item := &items[i]
+		if item.Key == id {
+			return &TransformerEntity[V]{
+				Id:        item.Key,
+				Value:     item.Value,
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *TransformerEntityStore[V]) getVersionKey(id string, version int64) string {
+	return id + ":" + strconv.FormatInt(version, 10)
+}
+
+func (s *TransformerEntityStore[V]) ListVersions(id string) ([]TransformerEntityVersion[V], error) {
+	items, err := s.history.List()
+	if err != nil {
+		return nil, err
+	}
+	versions := []TransformerEntityVersion[V]{}
+	for i := range items {
+		item := &items[i]
+		v, ok := strings.CutPrefix(item.Key, id+":")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, TransformerEntityVersion[V]{
+			Version:   version,
+			Value:     item.Value,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+	slices.SortFunc(versions, func(a, b TransformerEntityVersion[V]) int {
+		return cmp.Compare(b.Version, a.Version)
+	})
+	return versions, nil
+}
+
+// Save stores the value for id, the existing value is kept as a version.
+func (s *TransformerEntityStore[V]) Save(id string, value V) error {
+	existing, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		version := time.Now().UnixMilli()
+		if err := s.history.Set(s.getVersionKey(id, version), existing.Value); err != nil {
+			return err
+		}
+		versions, err := s.ListVersions(id)
+		if err != nil {
+			return err
+		}
+		for i := maxTransformerEntityVersions; i < len(versions); i++ {
+			if err := s.history.Del(s.getVersionKey(id, versions[i].Version)); err != nil {
+				return err
+			}
+		}
+	}
+	return s.store.Set(id, value)
+}
+
+func (s *TransformerEntityStore[V]) Delete(id string) error {
+	versions, err := s.ListVersions(id)
+	if err != nil {
+		return err
+	}
+	for i := range versions {
+		if err := s.history.Del(s.getVersionKey(id, versions[i].Version)); err != nil {
+			return err
+		}
+	}
+	return s.store.Del(id)
+}
+
+var ExtractorStore = &TransformerEntityStore[stremio_transformer.StreamExtractorBlob]{
+	store: extractorStore,
+	history: kv.NewKVStore[stremio_transformer.StreamExtractorBlob](&kv.KVStoreConfig{
+		Type: "st:wrap:transformer:extractor:history",
+	}),
+	builtIn: builtInExtractors,
+}
+
+var TemplateStore = &TransformerEntityStore[stremio_transformer.StreamTemplateBlob]{
+	store: templateStore,
+	history: kv.NewKVStore[stremio_transformer.StreamTemplateBlob](&kv.KVStoreConfig{
+		Type: "st:wrap:transformer:template:history",
+	}),
+	builtIn: builtInTemplates,
+}
@@ -38,15 +38,68 @@ func (d SyncDirection) ShouldSyncToStremio() bool {
 }
 
 func (d SyncDirection) IsDisabled() bool {
-	return d == SyncDirectionNone
+	return d == SyncDirectionNone || d == ""
 }
 
 type SyncConfigWatched struct {
 	Direction SyncDirection `json:"dir"`
 }
 
+// Stremio does not have separate watchlist and collection, both of
+// them are synced with the Stremio library.
+type SyncConfigWatchlist struct {
+	Direction SyncDirection `json:"dir"`
+}
+
+type SyncConfigCollection struct {
+	Direction SyncDirection `json:"dir"`
+}
+
+// Playback progress of the items that are in progress.
+type SyncConfigPlayback struct {
+	Direction SyncDirection `json:"dir"`
+}
+
+// Stremio does not have ratings of its own, Trakt ratings are recorded
+// against the Stremio library items. Only trakt_to_stremio is supported.
+type SyncConfigRatings struct {
+	Direction SyncDirection `json:"dir"`
+	// push ratings set via StremThru to Trakt
+	Push bool `json:"push"`
+}
+
+func (c SyncConfigRatings) IsValid() bool {
+	return c.Direction == SyncDirectionNone || c.Direction == SyncDirectionTraktToStremio
+}
+
+func (c SyncConfigRatings) IsDisabled() bool {
+	return c.Direction.IsDisabled() && !c.Push
+}
+
 type SyncConfig struct {
-	Watched SyncConfigWatched `json:"watched"`
+	Watched    SyncConfigWatched    `json:"watched"`
+	Watchlist  SyncConfigWatchlist  `json:"watchlist"`
+	Collection SyncConfigCollection `json:"collection"`
+	Playback   SyncConfigPlayback   `json:"playback"`
+	Ratings    SyncConfigRatings    `json:"ratings"`
+}
+
+func (sc *SyncConfig) Normalize() {
+	if sc.Watched.Direction == "" {
+		sc.Watched.Direction = SyncDirectionNone
+	}
+	if sc.Watchlist.Direction == "" {
+		sc.Watchlist.Direction = SyncDirectionNone
+	}
+	if sc.Collection.Direction == "" {
+		sc.Collection.Direction = SyncDirectionNone
+	}
+	if sc.Playback.Direction == "" {
+		sc.Playback.Direction = SyncDirectionNone
+	}
+	if sc.Ratings.Direction == "" {
+		sc.Ratings.Direction = SyncDirectionNone
+	}
 }
 
 func (sc SyncConfig) Value() (driver.Value, error) {
@@ -61,8 +114,27 @@ type SyncStateWatched struct {
 	LastSyncedAt *time.Time `json:"last_synced_at"`
 }
 
+// Ids are the imdb ids present on both sides after the last sync,
+// used to detect removals.
+type SyncStateList struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+	Ids          []string   `json:"ids"`
+}
+
+type SyncStatePlayback struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+}
+
+type SyncStateRatings struct {
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+}
+
 type SyncState struct {
-	Watched SyncStateWatched `json:"watched"`
+	Watched    SyncStateWatched  `json:"watched"`
+	Watchlist  SyncStateList     `json:"watchlist"`
+	Collection SyncStateList     `json:"collection"`
+	Playback   SyncStatePlayback `json:"playback"`
+	Ratings    SyncStateRatings  `json:"ratings"`
 }
 
 func (ss SyncState) Value() (driver.Value, error) {
@@ -193,8 +265,10 @@ var query_unlink = fmt.Sprintf(
 )
 
 func Unlink(stremioAccountId, traktAccountId string) error {
-	_, err := db.Exec(query_unlink, stremioAccountId, traktAccountId)
-	return err
+	if _, err := db.Exec(query_unlink, stremioAccountId, traktAccountId); err != nil {
+		return err
+	}
+	return DeleteAllRatings(stremioAccountId, traktAccountId)
 }
 
 var query_unlink_by_stremio_account = fmt.Sprintf(
@@ -203,8 +277,17 @@ var query_unlink_by_stremio_account = fmt.Sprintf(
 	Column.StremioAccountId,
 )
 
+var query_delete_ratings_by_stremio_account = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	RatingTableName,
+	RatingColumn.StremioAccountId,
+)
+
 func UnlinkByStremioAccount(stremioAccountId string) error {
-	_, err := db.Exec(query_unlink_by_stremio_account, stremioAccountId)
+	if _, err := db.Exec(query_unlink_by_stremio_account, stremioAccountId); err != nil {
+		return err
+	}
+	_, err := db.Exec(query_delete_ratings_by_stremio_account, stremioAccountId)
 	return err
 }
 
@@ -214,8 +297,17 @@ var query_unlink_by_trakt_account = fmt.Sprintf(
 	Column.TraktAccountId,
 )
 
+var query_delete_ratings_by_trakt_account = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ?`,
+	RatingTableName,
+	RatingColumn.TraktAccountId,
+)
+
 func UnlinkByTraktAccount(traktAccountId string) error {
-	_, err := db.Exec(query_unlink_by_trakt_account, traktAccountId)
+	if _, err := db.Exec(query_unlink_by_trakt_account, traktAccountId); err != nil {
+		return err
+	}
+	_, err := db.Exec(query_delete_ratings_by_trakt_account, traktAccountId)
 	return err
 }
 
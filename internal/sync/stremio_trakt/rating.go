@@ -0,0 +1,284 @@
+package sync_stremio_trakt
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+const RatingTableName = "sync_stremio_trakt_rating"
+
+// Rating of a Stremio library item. Ratings set via StremThru are pending
+// until they are pushed to Trakt, pending rating of 0 means removal.
+type SyncStremioTraktRating struct {
+	StremioAccountId string
+	TraktAccountId   string
+	Id               string // imdb id
+	Type             string // movie / series
+	Rating           int    // 1 - 10
+	RatedAt          db.Timestamp
+	Pending          bool
+	CAt              db.Timestamp
+	UAt              db.Timestamp
+}
+
+var RatingColumn = struct {
+	StremioAccountId string
+	TraktAccountId   string
+	Id               string
+	Type             string
+	Rating           string
+	RatedAt          string
+	Pending          string
+	CAt              string
+	UAt              string
+}{
+	StremioAccountId: "stremio_account_id",
+	TraktAccountId:   "trakt_account_id",
+	Id:               "id",
+	Type:             "type",
+	Rating:           "rating",
+	RatedAt:          "rated_at",
+	Pending:          "pending",
+	CAt:              "cat",
+	UAt:              "uat",
+}
+
+var ratingColumns = []string{
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Id,
+	RatingColumn.Type,
+	RatingColumn.Rating,
+	RatingColumn.RatedAt,
+	RatingColumn.Pending,
+	RatingColumn.CAt,
+	RatingColumn.UAt,
+}
+
+var query_get_ratings = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = ? ORDER BY %s DESC, %s DESC`,
+	strings.Join(ratingColumns, ", "),
+	RatingTableName,
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Rating,
+	RatingColumn.RatedAt,
+)
+
+func GetRatings(stremioAccountId, traktAccountId string) ([]SyncStremioTraktRating, error) {
+	rows, err := db.Query(query_get_ratings, stremioAccountId, traktAccountId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []SyncStremioTraktRating{}
+	for rows.Next() {
+		item := SyncStremioTraktRating{}
+		if err := rows.Scan(
+			&item.StremioAccountId,
+			&item.TraktAccountId,
+			&item.Id,
+			&item.Type,
+			&item.Rating,
+			&item.RatedAt,
+			&item.Pending,
+			&item.CAt,
+			&item.UAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var query_get_pending_ratings_by_trakt_account = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = %s ORDER BY %s ASC`,
+	strings.Join(ratingColumns, ", "),
+	RatingTableName,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Pending,
+	db.BooleanTrue,
+	RatingColumn.UAt,
+)
+
+// GetPendingRatingsByTraktAccount returns the ratings set via StremThru, that
+// are not pushed to Trakt yet, across every Stremio account linked with the
+// Trakt account. Latest ones come last.
+func GetPendingRatingsByTraktAccount(traktAccountId string) ([]SyncStremioTraktRating, error) {
+	rows, err := db.Query(query_get_pending_ratings_by_trakt_account, traktAccountId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []SyncStremioTraktRating{}
+	for rows.Next() {
+		item := SyncStremioTraktRating{}
+		if err := rows.Scan(
+			&item.StremioAccountId,
+			&item.TraktAccountId,
+			&item.Id,
+			&item.Type,
+			&item.Rating,
+			&item.RatedAt,
+			&item.Pending,
+			&item.CAt,
+			&item.UAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var query_get_rating = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s = ? AND %s = ?`,
+	strings.Join(ratingColumns, ", "),
+	RatingTableName,
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Id,
+)
+
+func GetRating(stremioAccountId, traktAccountId, id string) (*SyncStremioTraktRating, error) {
+	row := db.QueryRow(query_get_rating, stremioAccountId, traktAccountId, id)
+	item := SyncStremioTraktRating{}
+	if err := row.Scan(
+		&item.StremioAccountId,
+		&item.TraktAccountId,
+		&item.Id,
+		&item.Type,
+		&item.Rating,
+		&item.RatedAt,
+		&item.Pending,
+		&item.CAt,
+		&item.UAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+var query_upsert_ratings_before_values = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES `,
+	RatingTableName,
+	db.JoinColumnNames(
+		RatingColumn.StremioAccountId,
+		RatingColumn.TraktAccountId,
+		RatingColumn.Id,
+		RatingColumn.Type,
+		RatingColumn.Rating,
+		RatingColumn.RatedAt,
+		RatingColumn.Pending,
+	),
+)
+var query_upsert_ratings_values_placeholder = "(" + util.RepeatJoin("?", 7, ",") + ")"
+var query_upsert_ratings_after_values = fmt.Sprintf(
+	` ON CONFLICT (%s, %s, %s) DO UPDATE SET %s`,
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Id,
+	strings.Join([]string{
+		fmt.Sprintf(`%s = EXCLUDED.%s`, RatingColumn.Type, RatingColumn.Type),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, RatingColumn.Rating, RatingColumn.Rating),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, RatingColumn.RatedAt, RatingColumn.RatedAt),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, RatingColumn.Pending, RatingColumn.Pending),
+		fmt.Sprintf(`%s = %s`, RatingColumn.UAt, db.CurrentTimestamp),
+	}, ", "),
+)
+
+func UpsertRatings(ratings []SyncStremioTraktRating) error {
+	for cRatings := range slices.Chunk(ratings, 500) {
+		count := len(cRatings)
+		query := query_upsert_ratings_before_values +
+			util.RepeatJoin(query_upsert_ratings_values_placeholder, count, ",") +
+			query_upsert_ratings_after_values
+		args := make([]any, 0, count*7)
+		for i := range cRatings {
+			r := &cRatings[i]
+			args = append(
+				args,
+				r.StremioAccountId,
+				r.TraktAccountId,
+				r.Id,
+				r.Type,
+				r.Rating,
+				r.RatedAt,
+				r.Pending,
+			)
+		}
+		if _, err := db.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var query_delete_ratings = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ? AND %s IN `,
+	RatingTableName,
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Id,
+)
+
+func DeleteRatings(stremioAccountId, traktAccountId string, ids []string) error {
+	for cIds := range slices.Chunk(ids, 500) {
+		count := len(cIds)
+		query := query_delete_ratings + "(" + util.RepeatJoin("?", count, ",") + ")"
+		args := make([]any, 0, 2+count)
+		args = append(args, stremioAccountId, traktAccountId)
+		for _, id := range cIds {
+			args = append(args, id)
+		}
+		if _, err := db.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var query_delete_all_ratings = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ?`,
+	RatingTableName,
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+)
+
+func DeleteAllRatings(stremioAccountId, traktAccountId string) error {
+	_, err := db.Exec(query_delete_all_ratings, stremioAccountId, traktAccountId)
+	return err
+}
+
+var query_delete_pending_ratings = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ? AND %s = %s`,
+	RatingTableName,
+	RatingColumn.StremioAccountId,
+	RatingColumn.TraktAccountId,
+	RatingColumn.Pending,
+	db.BooleanTrue,
+)
+
+// DeletePendingRatings drops the ratings set via StremThru that are not
+// pushed to Trakt yet.
+func DeletePendingRatings(stremioAccountId, traktAccountId string) error {
+	_, err := db.Exec(query_delete_pending_ratings, stremioAccountId, traktAccountId)
+	return err
+}
@@ -0,0 +1,260 @@
+//go:build torrent_engine
+
+package torrent_engine
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/anacrolix/torrent/storage"
+)
+
+const isAvailable = true
+
+const (
+	infoTimeout  = 90 * time.Second
+	idleTimeout  = 15 * time.Minute
+	evictTick    = 1 * time.Minute
+	minReadahead = 8 * 1024 * 1024
+	maxReadahead = 64 * 1024 * 1024
+)
+
+type entry struct {
+	hash       string
+	dir        string
+	t          *torrent.Torrent
+	storage    storage.ClientImplCloser
+	readers    int
+	size       int64
+	accessedAt time.Time
+}
+
+// drop removes the torrent from the client, keeping its data on disk.
+func (e *entry) drop() {
+	if e.t == nil {
+		return
+	}
+	e.size = e.t.BytesCompleted()
+	e.t.Drop()
+	e.t = nil
+	if err := e.storage.Close(); err != nil {
+		log.Warn("failed to close storage", "error", err, "hash", e.hash)
+	}
+	e.storage = nil
+}
+
+func (e *entry) diskUsage() int64 {
+	if e.t != nil {
+		e.size = e.t.BytesCompleted()
+	}
+	return e.size
+}
+
+type engine struct {
+	client    *torrent.Client
+	mu        sync.Mutex
+	entryById map[string]*entry
+}
+
+var getEngine = sync.OnceValues(func() (*engine, error) {
+	dataDir := config.TorrentStream.DataDir
+	// piece completion is kept in memory, leftovers from previous runs can not be trusted
+	if err := os.RemoveAll(dataDir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	cfg := torrent.NewDefaultClientConfig()
+	cfg.DataDir = dataDir
+	cfg.Seed = false
+	cfg.ListenPort = 0
+	client, err := torrent.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &engine{
+		client:    client,
+		entryById: map[string]*entry{},
+	}
+	go e.runEvictor()
+	return e, nil
+})
+
+func (e *engine) acquire(m *metainfo.Magnet) *entry {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	hash := m.InfoHash.HexString()
+	en, ok := e.entryById[hash]
+	if !ok {
+		en = &entry{
+			hash: hash,
+			dir:  filepath.Join(config.TorrentStream.DataDir, hash),
+		}
+		e.entryById[hash] = en
+	}
+	if en.t == nil {
+		en.storage = storage.NewFileOpts(storage.NewFileClientOpts{
+			ClientBaseDir:   en.dir,
+			PieceCompletion: storage.NewMapPieceCompletion(),
+		})
+		t, _ := e.client.AddTorrentOpt(torrent.AddTorrentOpts{
+			InfoHash: m.InfoHash,
+			Storage:  en.storage,
+		})
+		en.t = t
+	}
+	if len(m.Trackers) > 0 {
+		en.t.AddTrackers([][]string{m.Trackers})
+	}
+	en.readers++
+	en.accessedAt = time.Now()
+	return en
+}
+
+func (e *engine) release(en *entry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	en.readers--
+	en.accessedAt = time.Now()
+}
+
+func (e *engine) runEvictor() {
+	ticker := time.NewTicker(evictTick)
+	defer ticker.Stop()
+	for range ticker.C {
+		e.evict()
+	}
+}
+
+// evict drops idle torrents from the client, and removes the data of least
+// recently accessed torrents until the disk usage fits in the budget.
+func (e *engine) evict() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	totalSize := int64(0)
+	idle := []*entry{}
+	for _, en := range e.entryById {
+		totalSize += en.diskUsage()
+		if en.readers > 0 {
+			continue
+		}
+		if time.Since(en.accessedAt) > idleTimeout {
+			en.drop()
+		}
+		idle = append(idle, en)
+	}
+
+	budget := config.TorrentStream.DiskBudget
+	for totalSize > budget && len(idle) > 0 {
+		oldestIdx := 0
+		for i, en := range idle {
+			if en.accessedAt.Before(idle[oldestIdx].accessedAt) {
+				oldestIdx = i
+			}
+		}
+		en := idle[oldestIdx]
+		idle = append(idle[:oldestIdx], idle[oldestIdx+1:]...)
+
+		en.drop()
+		if err := os.RemoveAll(en.dir); err != nil {
+			log.Error("failed to remove data", "error", err, "hash", en.hash)
+			continue
+		}
+		totalSize -= en.size
+		delete(e.entryById, en.hash)
+		log.Debug("evicted torrent", "hash", en.hash, "size", en.size)
+	}
+	if totalSize > budget {
+		log.Warn("disk budget exceeded by active streams", "size", totalSize, "budget", budget)
+	}
+}
+
+func selectFile(t *torrent.Torrent, m *metainfo.Magnet) *torrent.File {
+	files := t.Files()
+	if so := m.Params.Get("so"); so != "" {
+		if idx, err := strconv.Atoi(so); err == nil && 0 <= idx && idx < len(files) {
+			return files[idx]
+		}
+	}
+	if dn := m.DisplayName; dn != "" {
+		for _, f := range files {
+			if filepath.Base(f.DisplayPath()) == dn {
+				return f
+			}
+		}
+	}
+	var largest *torrent.File
+	for _, f := range files {
+		if largest == nil || f.Length() > largest.Length() {
+			largest = f
+		}
+	}
+	return largest
+}
+
+// Open starts streaming the file selected by the magnet link. Pieces are
+// fetched in the order they are read, with some readahead.
+func Open(ctx context.Context, link string) (*Stream, error) {
+	if !IsEnabled {
+		return nil, newError("torrent stream is disabled", http.StatusNotImplemented, nil)
+	}
+
+	m, err := metainfo.ParseMagnetUri(link)
+	if err != nil {
+		return nil, newError("invalid magnet link", http.StatusBadRequest, err)
+	}
+
+	e, err := getEngine()
+	if err != nil {
+		return nil, newError("failed to start torrent engine", http.StatusInternalServerError, err)
+	}
+
+	en := e.acquire(&m)
+	t := en.t
+
+	select {
+	case <-t.GotInfo():
+	case <-ctx.Done():
+		e.release(en)
+		return nil, ctx.Err()
+	case <-time.After(infoTimeout):
+		e.release(en)
+		return nil, newError("timed out fetching torrent info", http.StatusGatewayTimeout, nil)
+	}
+
+	f := selectFile(t, &m)
+	if f == nil {
+		e.release(en)
+		return nil, newError("no file found in torrent", http.StatusNotFound, nil)
+	}
+
+	reader := f.NewReader()
+	reader.SetContext(ctx)
+	reader.SetResponsive()
+	reader.SetReadahead(min(max(f.Length()/100, minReadahead), maxReadahead))
+
+	go e.evict()
+
+	return &Stream{
+		ReadSeekCloser: reader,
+		Name:           filepath.Base(f.DisplayPath()),
+		Size:           f.Length(),
+		release: func() {
+			e.release(en)
+		},
+	}, nil
+}
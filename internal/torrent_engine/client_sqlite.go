@@ -0,0 +1,9 @@
+//go:build torrent_engine && cgo && !nosqlite
+
+package torrent_engine
+
+// Under cgo, anacrolix/torrent keeps piece completion in sqlite by default,
+// through a driver that bundles its own copy of sqlite. It clashes with the
+// one bundled by mattn/go-sqlite3 at link time. The `nosqlite` tag switches
+// it to bolt, the engine itself keeps piece completion in memory.
+var _ = torrent_engine_build_tag_requires_nosqlite_build_tag
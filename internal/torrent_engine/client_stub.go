@@ -0,0 +1,15 @@
+//go:build !torrent_engine
+
+package torrent_engine
+
+import (
+	"context"
+	"net/http"
+)
+
+// build with the `torrent_engine` tag to include the engine.
+const isAvailable = false
+
+func Open(ctx context.Context, link string) (*Stream, error) {
+	return nil, newError("torrent engine is not available in this build", http.StatusNotImplemented, nil)
+}
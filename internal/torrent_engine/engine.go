@@ -0,0 +1,62 @@
+package torrent_engine
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/logger"
+)
+
+var log = logger.Scoped("torrent_engine")
+
+var IsEnabled = func() bool {
+	if !config.Feature.HasTorrentStream() {
+		return false
+	}
+	if !isAvailable {
+		log.Warn("torrent_stream feature is enabled, but the engine is not included in this build, build with the `torrent_engine` tag")
+		return false
+	}
+	return true
+}()
+
+func IsMagnetLink(link string) bool {
+	return len(link) >= 7 && strings.EqualFold(link[:7], "magnet:")
+}
+
+// CreateMagnetLink builds the link handed to the content proxy, `so`
+// (select-only) carries the file index to stream.
+func CreateMagnetLink(hash string, fileIdx int, trackers []string) string {
+	link := "magnet:?xt=urn:btih:" + hash + "&so=" + strconv.Itoa(fileIdx)
+	for _, tr := range trackers {
+		link += "&tr=" + url.QueryEscape(tr)
+	}
+	return link
+}
+
+type Stream struct {
+	io.ReadSeekCloser
+	Name string
+	Size int64
+
+	release func()
+	once    sync.Once
+}
+
+func (s *Stream) Close() error {
+	err := s.ReadSeekCloser.Close()
+	s.once.Do(s.release)
+	return err
+}
+
+func newError(msg string, statusCode int, cause error) *core.APIError {
+	err := core.NewAPIError(msg)
+	err.StatusCode = statusCode
+	err.Cause = cause
+	return err
+}
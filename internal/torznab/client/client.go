@@ -148,7 +148,7 @@ func (c *Client) NewSearchQuery(fn func(caps Caps) Function) (*Query, error) {
 	if err != nil {
 		return nil, err
 	}
-	q := Query{caps: &caps, values: url.Values{}}
+	q := NewQuery(&caps)
 	q.SetT(fn(caps))
-	return &q, nil
+	return q, nil
 }
@@ -35,6 +35,10 @@ type Query struct {
 	values url.Values
 }
 
+func NewQuery(caps *Caps) *Query {
+	return &Query{caps: caps, values: url.Values{}}
+}
+
 func (q Query) Clone() *Query {
 	values := url.Values{}
 	for key := range q.values {
@@ -154,6 +158,10 @@ func (q *Query) GetLimit() int {
 
 func (q *Query) SetLimit(value int) *Query {
 	if value <= 0 {
+		if q.caps.Limits == nil || q.caps.Limits.Max <= 0 {
+			q.values.Del(SearchParamLimit)
+			return q
+		}
 		value = q.caps.Limits.Max
 	}
 	q.values.Set(SearchParamLimit, strconv.Itoa(value))
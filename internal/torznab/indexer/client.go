@@ -7,6 +7,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
 	"github.com/MunifTanjim/stremthru/internal/torznab/jackett"
+	"github.com/MunifTanjim/stremthru/internal/torznab/prowlarr"
 )
 
 var jackettCache = cache.NewLRUCache[*jackett.Client](&cache.CacheConfig{
@@ -14,6 +15,11 @@ var jackettCache = cache.NewLRUCache[*jackett.Client](&cache.CacheConfig{
 	Name:     "torznab:indexer:jackett",
 })
 
+var prowlarrCache = cache.NewLRUCache[*prowlarr.Client](&cache.CacheConfig{
+	Lifetime: 3 * time.Hour,
+	Name:     "torznab:indexer:prowlarr",
+})
+
 func (tidxr TorznabIndexer) GetClient() (torznab_client.Indexer, error) {
 	switch tidxr.Type {
 	case IndexerTypeJackett:
@@ -40,6 +46,30 @@ func (tidxr TorznabIndexer) GetClient() (torznab_client.Indexer, error) {
 		}
 		c := client.GetTorznabClient(u.IndexerId)
 		return c, nil
+	case IndexerTypeProwlarr:
+		apiKey, err := tidxr.GetAPIKey()
+		if err != nil {
+			return nil, err
+		}
+
+		u := prowlarr.TorznabURL(tidxr.URL)
+		if err := u.Parse(); err != nil {
+			return nil, err
+		}
+
+		var client *prowlarr.Client
+		if !prowlarrCache.Get(tidxr.Id, &client) {
+			client = prowlarr.NewClient(&prowlarr.ClientConfig{
+				BaseURL: u.BaseURL,
+				APIKey:  apiKey,
+			})
+			err := prowlarrCache.Add(tidxr.Id, client)
+			if err != nil {
+				return nil, err
+			}
+		}
+		c := client.GetTorznabClient(u.IndexerId)
+		return c, nil
 	default:
 		return nil, errors.New("invalid indexer type: " + string(tidxr.Type))
 	}
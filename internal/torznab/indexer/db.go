@@ -5,18 +5,18 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/MunifTanjim/stremthru/core"
-	"github.com/MunifTanjim/stremthru/internal/config"
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/torznab/jackett"
+	"github.com/MunifTanjim/stremthru/internal/torznab/prowlarr"
+	"github.com/MunifTanjim/stremthru/internal/vault"
 )
 
 func encrypt(value string) (string, error) {
-	return core.Encrypt(config.VaultSecret, value)
+	return vault.Encrypt(value)
 }
 
 func decrypt(value string) (string, error) {
-	return core.Decrypt(config.VaultSecret, value)
+	return vault.Decrypt(value)
 }
 
 const TableName = "torznab_indexer"
@@ -24,12 +24,13 @@ const TableName = "torznab_indexer"
 type IndexerType string
 
 const (
-	IndexerTypeJackett IndexerType = "jackett"
+	IndexerTypeJackett  IndexerType = "jackett"
+	IndexerTypeProwlarr IndexerType = "prowlarr"
 )
 
 func (it IndexerType) IsValid() bool {
 	switch it {
-	case IndexerTypeJackett:
+	case IndexerTypeJackett, IndexerTypeProwlarr:
 		return true
 	default:
 		return false
@@ -66,6 +67,22 @@ func NewTorznabIndexer(indexerType IndexerType, url, apiKey string) (*TorznabInd
 			return nil, fmt.Errorf("invalid torznab url: %w", err)
 		}
 
+		indexer := &TorznabIndexer{
+			Type: indexerType,
+			Id:   u.Encode(),
+			URL:  url,
+		}
+		err := indexer.SetAPIKey(apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return indexer, nil
+	case IndexerTypeProwlarr:
+		u := prowlarr.TorznabURL(url)
+		if err := u.Parse(); err != nil {
+			return nil, fmt.Errorf("invalid torznab url: %w", err)
+		}
+
 		indexer := &TorznabIndexer{
 			Type: indexerType,
 			Id:   u.Encode(),
@@ -126,6 +143,46 @@ func (i *TorznabIndexer) Validate() error {
 			i.Name = jackett.GetIndexerName(u.IndexerId)
 		}
 
+		return nil
+	case IndexerTypeProwlarr:
+		u := prowlarr.TorznabURL(i.URL)
+		if err := u.Parse(); err != nil {
+			return fmt.Errorf("invalid torznab url: %w", err)
+		}
+
+		apiKey, err := i.GetAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to decrypt api key: %w", err)
+		}
+
+		client := prowlarr.NewClient(&prowlarr.ClientConfig{
+			BaseURL: u.BaseURL,
+			APIKey:  apiKey,
+		})
+
+		res, err := client.GetIndexer(&prowlarr.GetIndexerParams{Id: u.IndexerId})
+		if err != nil {
+			return fmt.Errorf("failed to fetch indexer: %w", err)
+		}
+		details := res.Data
+		if details.Protocol != prowlarr.IndexerProtocolTorrent {
+			return fmt.Errorf("unsupported indexer protocol: %s", details.Protocol)
+		}
+		if !details.Enable {
+			return fmt.Errorf("indexer is disabled in prowlarr")
+		}
+
+		torznabClient := client.GetTorznabClient(u.IndexerId)
+
+		_, err = torznabClient.GetCaps()
+		if err != nil {
+			return fmt.Errorf("failed to fetch capabilities: %w", err)
+		}
+
+		if i.Name == "" {
+			i.Name = prowlarr.GetIndexerName(&details)
+		}
+
 		return nil
 	default:
 		return fmt.Errorf("unsupported indexer type: %s", i.Type)
@@ -274,3 +331,29 @@ func DeleteByCompositeId(compositeId string) error {
 	}
 	return Delete(indexerType, id)
 }
+
+var query_update_api_key = fmt.Sprintf(
+	`UPDATE %s SET %s = ? WHERE %s = ? AND %s = ? AND %s = ?`,
+	TableName,
+	Column.APIKey,
+	Column.Type,
+	Column.Id,
+	Column.APIKey,
+)
+
+// ReEncrypt re-encrypts the api key with the current vault secret, returns
+// false if it was already up-to-date.
+func (i *TorznabIndexer) ReEncrypt() (bool, error) {
+	apiKey, err := vault.ReEncrypt(i.APIKey)
+	if err != nil {
+		return false, err
+	}
+	if apiKey == i.APIKey {
+		return false, nil
+	}
+	if _, err := db.Exec(query_update_api_key, apiKey, i.Type, i.Id, i.APIKey); err != nil {
+		return false, err
+	}
+	i.APIKey = apiKey
+	return true, nil
+}
@@ -6,7 +6,9 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
 )
 
@@ -124,7 +126,9 @@ func (tc TorznabClient) Search(query *torznab_client.Query) ([]torznab_client.To
 	q := query.Values()
 	params.Query = &q
 	var resp torznab_client.Response[SearchResponse]
+	start := time.Now()
 	_, err := tc.Client.Request("GET", "/api", params, &resp)
+	metrics.ObserveTorznabQuery(tc.GetId(), start, err)
 	if err != nil {
 		return nil, err
 	}
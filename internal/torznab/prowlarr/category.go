@@ -0,0 +1,80 @@
+package prowlarr
+
+import (
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+)
+
+type Category = torznab_client.Category
+
+const (
+	CustomCategoryOffset = 100000
+)
+
+// Prowlarr uses the Newznab standard categories, same as Jackett. Indexer
+// specific categories are exposed with ids above CustomCategoryOffset, nested
+// under the standard category they are mapped to.
+type IndexerCategory struct {
+	Id            int               `json:"id"`
+	Name          string            `json:"name"`
+	SubCategories []IndexerCategory `json:"subCategories"`
+}
+
+func (ic IndexerCategory) ToCategory() Category {
+	return Category{ID: ic.Id, Name: ic.Name}
+}
+
+func (ic IndexerCategory) ToCapsCategory() torznab_client.CapsCategory {
+	cc := torznab_client.CapsCategory{
+		Category: ic.ToCategory(),
+		Subcat:   make([]Category, len(ic.SubCategories)),
+	}
+	for i := range ic.SubCategories {
+		cc.Subcat[i] = ic.SubCategories[i].ToCategory()
+	}
+	return cc
+}
+
+type IndexerCategories []IndexerCategory
+
+func (cats IndexerCategories) ToCapsCategories() []torznab_client.CapsCategory {
+	result := make([]torznab_client.CapsCategory, len(cats))
+	for i := range cats {
+		result[i] = cats[i].ToCapsCategory()
+	}
+	return result
+}
+
+func (cats IndexerCategories) Flatten() torznab_client.Categories {
+	result := torznab_client.Categories{}
+	for i := range cats {
+		cat := &cats[i]
+		result = append(result, cat.ToCategory())
+		result = append(result, IndexerCategories(cat.SubCategories).Flatten()...)
+	}
+	return result
+}
+
+// MapCategory resolves a category id to the standard category it belongs to.
+// Custom categories are mapped to the standard category they are nested under.
+func (cats IndexerCategories) MapCategory(id int) *Category {
+	for i := range cats {
+		cat := &cats[i]
+		if cat.Id == id {
+			c := cat.ToCategory()
+			return &c
+		}
+		for j := range cat.SubCategories {
+			subcat := &cat.SubCategories[j]
+			if subcat.Id != id {
+				continue
+			}
+			if id >= CustomCategoryOffset {
+				c := cat.ToCategory()
+				return &c
+			}
+			c := subcat.ToCategory()
+			return &c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package prowlarr
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/request"
+	tznc "github.com/MunifTanjim/stremthru/internal/torznab/client"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+type IndexerPrivacy string
+
+const (
+	IndexerPrivacyPublic      IndexerPrivacy = "public"
+	IndexerPrivacyPrivate     IndexerPrivacy = "private"
+	IndexerPrivacySemiPrivate IndexerPrivacy = "semiPrivate"
+)
+
+type IndexerProtocol string
+
+const (
+	IndexerProtocolTorrent IndexerProtocol = "torrent"
+	IndexerProtocolUsenet  IndexerProtocol = "usenet"
+)
+
+type IndexerCapabilities struct {
+	LimitsMax         int               `json:"limitsMax"`
+	LimitsDefault     int               `json:"limitsDefault"`
+	Categories        IndexerCategories `json:"categories"`
+	SearchParams      []string          `json:"searchParams"`
+	TvSearchParams    []string          `json:"tvSearchParams"`
+	MovieSearchParams []string          `json:"movieSearchParams"`
+}
+
+type IndexerDetails struct {
+	Id             int                 `json:"id"`
+	Name           string              `json:"name"`
+	DefinitionName string              `json:"definitionName"`
+	Enable         bool                `json:"enable"`
+	Protocol       IndexerProtocol     `json:"protocol"`
+	Privacy        IndexerPrivacy      `json:"privacy"`
+	Priority       int                 `json:"priority"`
+	Capabilities   IndexerCapabilities `json:"capabilities"`
+}
+
+func (d IndexerDetails) IsPrivate() bool {
+	return d.Privacy == IndexerPrivacyPrivate || d.Privacy == IndexerPrivacySemiPrivate
+}
+
+type GetIndexersParams struct {
+	Ctx
+}
+
+func (c *Client) GetIndexers(params *GetIndexersParams) (request.APIResponse[[]IndexerDetails], error) {
+	var resp Response[[]IndexerDetails]
+	res, err := c.Request("GET", "/api/v1/indexer", params, &resp)
+	return request.NewAPIResponse(res, resp.Data), err
+}
+
+type GetIndexerParams struct {
+	Ctx
+	Id string
+}
+
+func (c *Client) GetIndexer(params *GetIndexerParams) (request.APIResponse[IndexerDetails], error) {
+	var resp Response[IndexerDetails]
+	res, err := c.Request("GET", "/api/v1/indexer/"+params.Id, params, &resp)
+	return request.NewAPIResponse(res, resp.Data), err
+}
+
+type ItemProwlarrIndexer struct {
+	ID   string `xml:"id,attr"`
+	Type string `xml:"type,attr"`
+	Name string `xml:",chardata"`
+}
+
+type ChannelItem struct {
+	Title           string              `xml:"title"`
+	GUID            string              `xml:"guid"`
+	ProwlarrIndexer ItemProwlarrIndexer `xml:"prowlarrindexer"`
+	Type            string              `xml:"type"`
+	Comments        string              `xml:"comments"`
+	PubDate         string              `xml:"pubDate"` // Mon, 02 Jan 2006 15:04:05 -0700
+	Size            int64               `xml:"size"`
+	Grabs           int                 `xml:"grabs"`
+	Description     string              `xml:"description"`
+	Link            string              `xml:"link"`
+	Categories      []int               `xml:"category"`
+	Enclosure       tznc.ItemEnclosure  `xml:"enclosure"`
+	TorznabAttrs    tznc.TorznabAttrs   `xml:"http://torznab.com/schemas/2015/feed attr"`
+}
+
+func (o ChannelItem) isPrivate() bool {
+	for _, t := range []string{o.ProwlarrIndexer.Type, o.Type} {
+		switch strings.ToLower(t) {
+		case "private", "semi-private", "semiprivate":
+			return true
+		}
+	}
+	return false
+}
+
+func (o ChannelItem) ToTorz() *tznc.Torz {
+	t := &tznc.Torz{}
+	t.Indexer = o.ProwlarrIndexer.Name
+	if t.Indexer == "" {
+		t.Indexer = o.ProwlarrIndexer.ID
+	}
+	t.Hash = strings.ToLower(o.TorznabAttrs.Get(tznc.TorznabAttrNameInfoHash))
+	t.Title = o.Title
+	t.Size = o.Size
+	t.Seeders = util.SafeParseInt(o.TorznabAttrs.Get(tznc.TorznabAttrNameSeeders), 0)
+	if peers := util.SafeParseInt(o.TorznabAttrs.Get(tznc.TorznabAttrNamePeers), 0); peers > t.Seeders {
+		t.Leechers = peers - t.Seeders
+	}
+	t.Private = o.isPrivate()
+	magnetLink := o.TorznabAttrs.Get(tznc.TorznabAttrNameMagnetURL)
+	if magnetLink == "" && strings.HasPrefix(o.Enclosure.URL, "magnet:?") {
+		magnetLink = o.Enclosure.URL
+	}
+	if magnetLink != "" {
+		t.MagnetLink = magnetLink
+		if t.Hash == "" {
+			if m, err := core.ParseMagnetLink(t.MagnetLink); err == nil {
+				t.Hash = m.Hash
+			}
+		}
+	} else if strings.HasPrefix(o.Enclosure.URL, "http") {
+		t.SourceLink = o.Enclosure.URL
+	}
+	return t
+}
+
+type Channel struct {
+	XMLName     xml.Name      `xml:"channel"`
+	Title       string        `xml:"title,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	Link        string        `xml:"link,omitempty"`
+	Language    string        `xml:"language,omitempty"`
+	Category    string        `xml:"category,omitempty"`
+	Items       []ChannelItem `xml:"item"`
+}
+
+type SearchResponse struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr,omitempty"`
+	Channel Channel  `xml:"channel"`
+}
+
+func GetIndexerName(details *IndexerDetails) string {
+	if details.Name != "" {
+		return details.Name
+	}
+	if details.DefinitionName != "" {
+		return details.DefinitionName
+	}
+	return strconv.Itoa(details.Id)
+}
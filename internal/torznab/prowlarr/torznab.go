@@ -0,0 +1,141 @@
+package prowlarr
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	torznab_client "github.com/MunifTanjim/stremthru/internal/torznab/client"
+)
+
+type torznabURL struct {
+	raw       string
+	BaseURL   string
+	IndexerId string
+}
+
+func TorznabURL(str string) *torznabURL {
+	purl := torznabURL{raw: str}
+	return &purl
+}
+
+func (turl *torznabURL) Parse() error {
+	if turl.BaseURL != "" && turl.IndexerId != "" {
+		return nil
+	}
+	if strings.HasPrefix(turl.raw, "http://") || strings.HasPrefix(turl.raw, "https://") {
+		return turl.FromDecoded()
+	}
+	return turl.FromEncoded()
+}
+
+func (purl *torznabURL) FromDecoded() error {
+	for _, pattern := range torznabUrlPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(purl.raw, -1) {
+			for i, name := range pattern.SubexpNames() {
+				value := match[i]
+				switch name {
+				case "base_url":
+					purl.BaseURL = value
+				case "indexer_id":
+					purl.IndexerId = value
+				}
+			}
+		}
+		if purl.BaseURL != "" && purl.IndexerId != "" {
+			return nil
+		}
+	}
+	return errors.New("invalid torznab url")
+}
+
+func (purl *torznabURL) FromEncoded() error {
+	schemeHost, indexerId, ok := strings.Cut(purl.raw, "::")
+	if !ok || indexerId == "" {
+		return errors.New("invalid encoded torznab url")
+	}
+	scheme, host, ok := strings.Cut(schemeHost, ":")
+	if !ok {
+		return errors.New("invalid encoded torznab url")
+	}
+	purl.BaseURL = scheme + "://" + host
+	purl.IndexerId = indexerId
+	return nil
+}
+
+func (purl torznabURL) Encode() string {
+	if err := purl.Parse(); err != nil {
+		return ""
+	}
+	u, err := url.Parse(purl.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + ":" + u.Host + strings.TrimRight(u.Path, "/") + "::" + purl.IndexerId
+}
+
+func (purl torznabURL) Decode() string {
+	if strings.HasPrefix(purl.raw, "http://") || strings.HasPrefix(purl.raw, "https://") {
+		return purl.raw
+	}
+	if err := purl.Parse(); err != nil {
+		return ""
+	}
+	return strings.TrimRight(purl.BaseURL, "/") + "/" + purl.IndexerId + "/api"
+}
+
+var torznabUrlPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(?<base_url>https?:\/\/.+?)\/(?<indexer_id>\d+)\/api\/?$`),
+	regexp.MustCompile(`(?i)^(?<base_url>https?:\/\/.+?)\/api\/v1\/indexer\/(?<indexer_id>\d+)\/newznab\/?$`),
+}
+
+type TorznabClient struct {
+	*torznab_client.Client
+	id string
+}
+
+func (tc TorznabClient) GetId() string {
+	return "prowlarr/" + tc.id
+}
+
+func (tc TorznabClient) Search(query *torznab_client.Query) ([]torznab_client.Torz, error) {
+	params := &Ctx{}
+	q := query.Values()
+	params.Query = &q
+	var resp torznab_client.Response[SearchResponse]
+	start := time.Now()
+	_, err := tc.Client.Request("GET", "/api", params, &resp)
+	metrics.ObserveTorznabQuery(tc.GetId(), start, err)
+	if err != nil {
+		return nil, err
+	}
+	items := resp.Data.Channel.Items
+	result := make([]torznab_client.Torz, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		if item.Size == 0 && item.Grabs == 0 && item.Enclosure.Length == 0 {
+			continue
+		}
+		result = append(result, *item.ToTorz())
+	}
+	return result, nil
+}
+
+func (c *Client) GetTorznabClient(id string) *TorznabClient {
+	var client TorznabClient
+	if c.torznabClientById.Get(id, &client) {
+		return &client
+	}
+	tc := torznab_client.NewClient(&torznab_client.ClientConfig{
+		BaseURL:    c.BaseURL.JoinPath("/" + id).String(),
+		HTTPClient: c.HTTPClient,
+		APIKey:     c.apiKey,
+		UserAgent:  c.userAgent,
+	})
+	client = TorznabClient{Client: tc, id: id}
+	c.torznabClientById.Add(id, client)
+	return &client
+}
@@ -0,0 +1,104 @@
+package prowlarr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTorznabURL_Parse(t *testing.T) {
+	for _, test := range []struct {
+		input           string
+		expectedIndexer string
+		expectedBase    string
+		shouldError     bool
+	}{
+		{
+			input:           "http://localhost:9696/1/api",
+			expectedIndexer: "1",
+			expectedBase:    "http://localhost:9696",
+		},
+		{
+			input:           "https://example.com/prowlarr/12/api/",
+			expectedIndexer: "12",
+			expectedBase:    "https://example.com/prowlarr",
+		},
+		{
+			input:           "http://localhost:9696/api/v1/indexer/7/newznab",
+			expectedIndexer: "7",
+			expectedBase:    "http://localhost:9696",
+		},
+		{
+			input:           "http:localhost:9696::3",
+			expectedIndexer: "3",
+			expectedBase:    "http://localhost:9696",
+		},
+		{
+			input:           "https:example.com/prowlarr::12",
+			expectedIndexer: "12",
+			expectedBase:    "https://example.com/prowlarr",
+		},
+		{
+			input:       "http://localhost:9696/api/v2.0/indexers/all/results/torznab",
+			shouldError: true,
+		},
+		{
+			input:       "invalid-no-separator",
+			shouldError: true,
+		},
+	} {
+		turl := TorznabURL(test.input)
+		err := turl.Parse()
+
+		if test.shouldError {
+			assert.Error(t, err, "input: %s", test.input)
+		} else {
+			assert.NoError(t, err, "input: %s", test.input)
+			assert.Equal(t, test.expectedIndexer, turl.IndexerId, "input: %s", test.input)
+			assert.Equal(t, test.expectedBase, turl.BaseURL, "input: %s", test.input)
+		}
+	}
+}
+
+func TestTorznabURL_EncodeDecode(t *testing.T) {
+	for _, test := range []struct {
+		input   string
+		encoded string
+		decoded string
+	}{
+		{
+			input:   "http://localhost:9696/1/api",
+			encoded: "http:localhost:9696::1",
+			decoded: "http://localhost:9696/1/api",
+		},
+		{
+			input:   "https:example.com/prowlarr::12",
+			encoded: "https:example.com/prowlarr::12",
+			decoded: "https://example.com/prowlarr/12/api",
+		},
+		{
+			input:   "http://invalid-url",
+			encoded: "",
+			decoded: "http://invalid-url",
+		},
+	} {
+		assert.Equal(t, test.encoded, TorznabURL(test.input).Encode(), "input: %s", test.input)
+		assert.Equal(t, test.decoded, TorznabURL(test.input).Decode(), "input: %s", test.input)
+	}
+}
+
+func TestIndexerCategories_MapCategory(t *testing.T) {
+	cats := IndexerCategories{
+		{Id: 2000, Name: "Movies", SubCategories: []IndexerCategory{
+			{Id: 2040, Name: "Movies/HD"},
+			{Id: 100001, Name: "Films HD"},
+		}},
+		{Id: 5000, Name: "TV"},
+	}
+
+	assert.Equal(t, &Category{ID: 2000, Name: "Movies"}, cats.MapCategory(2000))
+	assert.Equal(t, &Category{ID: 2040, Name: "Movies/HD"}, cats.MapCategory(2040))
+	assert.Equal(t, &Category{ID: 2000, Name: "Movies"}, cats.MapCategory(100001))
+	assert.Nil(t, cats.MapCategory(8000))
+	assert.Len(t, cats.Flatten(), 4)
+}
@@ -35,6 +35,7 @@ type TraktList struct {
 const ID_PREFIX_DYNAMIC = "~:"
 const ID_PREFIX_USER_FAVORITES = ID_PREFIX_DYNAMIC + "favorites:"
 const ID_PREFIX_USER_WATCHLIST = ID_PREFIX_DYNAMIC + "watchlist:"
+const ID_PREFIX_USER_RATINGS = ID_PREFIX_DYNAMIC + "ratings:"
 
 const ID_PREFIX_DYNAMIC_USER_SPECIFIC = ID_PREFIX_DYNAMIC + "u:"
 const USER_MOVIES_RECOMMENDATIONS_ID = ID_PREFIX_DYNAMIC_USER_SPECIFIC + "movies/recommendations"
@@ -46,8 +47,15 @@ func (l *TraktList) GetURL() string {
 	}
 
 	if l.IsStandard() {
-		slug, _, _ := strings.Cut(strings.TrimPrefix(l.Id, ID_PREFIX_DYNAMIC), ":")
-		return "https://trakt.tv/users/" + l.UserId + "/" + slug
+		slug, rest, _ := strings.Cut(strings.TrimPrefix(l.Id, ID_PREFIX_DYNAMIC), ":")
+		link := "https://trakt.tv/users/" + l.UserId + "/" + slug
+		if strings.HasPrefix(l.Id, ID_PREFIX_USER_RATINGS) {
+			// e.g. {user}:{type}:{min_rating}
+			if _, filter, ok := strings.Cut(rest, ":"); ok {
+				link += "/" + strings.ReplaceAll(filter, ":", "/")
+			}
+		}
+		return link
 	}
 
 	return "https://trakt.tv/" + l.Slug
@@ -59,7 +67,8 @@ func (l *TraktList) IsDynamic() bool {
 
 func (l *TraktList) IsStandard() bool {
 	return strings.HasPrefix(l.Id, ID_PREFIX_USER_FAVORITES) ||
-		strings.HasPrefix(l.Id, ID_PREFIX_USER_WATCHLIST)
+		strings.HasPrefix(l.Id, ID_PREFIX_USER_WATCHLIST) ||
+		strings.HasPrefix(l.Id, ID_PREFIX_USER_RATINGS)
 }
 
 func (l *TraktList) IsUserSpecific() bool {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/meta"
+	"github.com/MunifTanjim/stremthru/internal/sync/stremio_trakt"
 	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
@@ -240,6 +241,10 @@ type dynamicListMeta struct {
 
 	HasUserId bool
 	UserId    string
+
+	HasRating  bool
+	RatingType string // movies / shows / all
+	MinRating  int
 }
 
 var dynamicListMetaById = map[string]dynamicListMeta{
@@ -345,6 +350,14 @@ var dynamicListMetaById = map[string]dynamicListMeta{
 		Name:      "Watchlist",
 		HasUserId: true,
 	},
+	"ratings": {
+		Endpoint:  "/users/{user_id}/ratings/{type}",
+		NoPage:    true,
+		NoLimit:   true,
+		Name:      "Ratings",
+		HasUserId: true,
+		HasRating: true,
+	},
 	"progress": {
 		Endpoint: "/users/me/progress/up_next/{sort_by}/{sort_how}",
 		BeforeRequest: func(req *http.Request) error {
@@ -438,6 +451,28 @@ func GetDynamicListMeta(id string) *dynamicListMeta {
 			meta.UserId = parts[1]
 		}
 
+		if meta.HasRating {
+			meta.RatingType = "all"
+			if len(parts) > 2 {
+				meta.RatingType = parts[2]
+			}
+			switch meta.RatingType {
+			case "movies", "shows":
+				meta.Name += " (" + strings.ToUpper(meta.RatingType[:1]) + meta.RatingType[1:] + ")"
+			case "all":
+			default:
+				return nil
+			}
+			if len(parts) > 3 {
+				minRating := util.SafeParseInt(parts[3], 0)
+				if minRating < 1 || minRating > 10 {
+					return nil
+				}
+				meta.MinRating = minRating
+				meta.Name += " (" + strconv.Itoa(minRating) + "+)"
+			}
+		}
+
 		return &meta
 	}
 
@@ -491,6 +526,9 @@ func (c APIClient) fetchDynamicListItems(params *fetchDynamicListItemsParams) (A
 	if meta.HasUserId {
 		path = strings.Replace(path, "{user_id}", meta.UserId, 1)
 	}
+	if meta.HasRating {
+		path = strings.Replace(path, "{type}", meta.RatingType, 1)
+	}
 
 	hiddenItemIds := util.NewSet[int]()
 	if meta.Endpoint == dynamicListMetaById["progress"].Endpoint {
@@ -602,6 +640,33 @@ func (c APIClient) fetchDynamicListItems(params *fetchDynamicListItemsParams) (A
 				items = append(items, item)
 			}
 
+		case dynamicListMetaById["ratings"].Endpoint:
+			response := listResponseData[RatingItem]{}
+			res, err = c.Request("GET", path, p, &response)
+			if err != nil {
+				break
+			}
+			var ratingItems []RatingItem
+			ratingItems, err = c.applyPendingRatings(meta, response.data)
+			if err != nil {
+				break
+			}
+			for i := range ratingItems {
+				data := &ratingItems[i]
+				if data.Rating < meta.MinRating {
+					continue
+				}
+				switch data.Type {
+				case ItemTypeMovie, ItemTypeShow:
+					items = append(items, ListItem{
+						Type:     data.Type,
+						ListedAt: data.RatedAt,
+						Movie:    data.Movie,
+						Show:     data.Show,
+					})
+				}
+			}
+
 		default:
 			response := listResponseData[ListItem]{}
 			res, err = c.Request("GET", path, p, &response)
@@ -628,3 +693,95 @@ func (c APIClient) fetchDynamicListItems(params *fetchDynamicListItemsParams) (A
 
 	return newAPIResponse(res, items), err
 }
+
+func (item *RatingItem) getIMDBId() string {
+	switch {
+	case item.Movie != nil:
+		return item.Movie.Ids.IMDB
+	case item.Show != nil:
+		return item.Show.Ids.IMDB
+	}
+	return ""
+}
+
+func (c APIClient) lookupRatedItem(imdbId string, itemType ItemType) (*RatingItem, error) {
+	params := &Ctx{}
+	params.Query = &url.Values{}
+	params.Query.Set("type", itemType)
+	params.Query.Set("extended", "full,images")
+	response := listResponseData[RatingItem]{}
+	if _, err := c.Request("GET", "/search/"+string(IdTypeIMDB)+"/"+imdbId, params, &response); err != nil {
+		return nil, err
+	}
+	for i := range response.data {
+		if item := &response.data[i]; item.Type == itemType && item.getIMDBId() == imdbId {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyPendingRatings applies the ratings set via StremThru for the linked
+// Stremio accounts, that are not pushed to Trakt yet. Pending rating of 0
+// means removal.
+func (c APIClient) applyPendingRatings(meta *dynamicListMeta, items []RatingItem) ([]RatingItem, error) {
+	pendingRatings, err := sync_stremio_trakt.GetPendingRatingsByTraktAccount(meta.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(pendingRatings) == 0 {
+		return items, nil
+	}
+
+	pendingRatingById := make(map[string]*sync_stremio_trakt.SyncStremioTraktRating, len(pendingRatings))
+	for i := range pendingRatings {
+		pendingRatingById[pendingRatings[i].Id] = &pendingRatings[i]
+	}
+
+	appliedIds := util.NewSet[string]()
+	ratedItems := make([]RatingItem, 0, len(items))
+	for i := range items {
+		item := items[i]
+		id := item.getIMDBId()
+		if r, ok := pendingRatingById[id]; ok {
+			appliedIds.Add(id)
+			if r.Rating == 0 {
+				continue
+			}
+			item.Rating = r.Rating
+			item.RatedAt = r.RatedAt.Time
+		}
+		ratedItems = append(ratedItems, item)
+	}
+
+	newItems := []RatingItem{}
+	for i := len(pendingRatings) - 1; i >= 0; i-- {
+		r := &pendingRatings[i]
+		if appliedIds.Has(r.Id) {
+			continue
+		}
+		appliedIds.Add(r.Id)
+		if r.Rating == 0 {
+			continue
+		}
+		itemType := ItemTypeMovie
+		if r.Type == "series" {
+			itemType = ItemTypeShow
+		}
+		if meta.RatingType != "all" && meta.RatingType != itemType+"s" {
+			continue
+		}
+		item, err := c.lookupRatedItem(r.Id, itemType)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			continue
+		}
+		item.Rating = r.Rating
+		item.RatedAt = r.RatedAt.Time
+		newItems = append(newItems, *item)
+	}
+
+	return append(newItems, ratedItems...), nil
+}
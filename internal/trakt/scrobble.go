@@ -0,0 +1,100 @@
+package trakt
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/request"
+)
+
+type PlaybackItemEpisode struct {
+	MinimalItemEpisode
+	Runtime int `json:"runtime,omitempty"` // in minutes
+}
+
+type PlaybackItem struct {
+	Id       int64                `json:"id"`
+	Progress float64              `json:"progress"` // 0.0 - 100.0
+	PausedAt time.Time            `json:"paused_at"`
+	Type     ItemType             `json:"type"` // "movie" or "episode"
+	Movie    *ListItemMovie       `json:"movie,omitempty"`
+	Episode  *PlaybackItemEpisode `json:"episode,omitempty"`
+	Show     *ListItemShow        `json:"show,omitempty"`
+}
+
+type GetPlaybackProgressData = []PlaybackItem
+
+type GetPlaybackProgressParams struct {
+	Ctx
+	Type    HistoryItemType // movies / episodes
+	StartAt *time.Time
+	EndAt   *time.Time
+	Page    int
+	Limit   int
+}
+
+func (c APIClient) GetPlaybackProgress(params *GetPlaybackProgressParams) (request.APIResponse[GetPlaybackProgressData], error) {
+	path := "/sync/playback"
+	if params.Type != "" {
+		path += "/" + string(params.Type)
+	}
+
+	params.Query = &url.Values{}
+	params.Query.Set("extended", "full")
+	if params.Page > 0 {
+		params.Query.Set("page", strconv.Itoa(params.Page))
+	}
+	if params.Limit > 0 {
+		params.Query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.StartAt != nil {
+		params.Query.Set("start_at", params.StartAt.UTC().Format(time.RFC3339))
+	}
+	if params.EndAt != nil {
+		params.Query.Set("end_at", params.EndAt.UTC().Format(time.RFC3339))
+	}
+
+	response := paginatedResponseData[PlaybackItem]{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+type ScrobbleParamsMovie struct {
+	Ids ListItemIds `json:"ids"`
+}
+
+type ScrobbleParamsShow struct {
+	Ids ListItemIds `json:"ids"`
+}
+
+type ScrobbleParamsEpisode struct {
+	Season int `json:"season"`
+	Number int `json:"number"`
+}
+
+type ScrobbleData struct {
+	ResponseError
+	Id       int64                `json:"id"`
+	Action   string               `json:"action"` // start / pause / scrobble
+	Progress float64              `json:"progress"`
+	Movie    *ListItemMovie       `json:"movie,omitempty"`
+	Episode  *PlaybackItemEpisode `json:"episode,omitempty"`
+	Show     *ListItemShow        `json:"show,omitempty"`
+}
+
+type ScrobbleParams struct {
+	Ctx
+	Movie    *ScrobbleParamsMovie   `json:"movie,omitempty"`
+	Show     *ScrobbleParamsShow    `json:"show,omitempty"`
+	Episode  *ScrobbleParamsEpisode `json:"episode,omitempty"`
+	Progress float64                `json:"progress"`
+}
+
+// Pausing saves the playback progress, which can be resumed later.
+func (c APIClient) ScrobblePause(params *ScrobbleParams) (request.APIResponse[ScrobbleData], error) {
+	params.JSON = params
+	response := ScrobbleData{}
+	res, err := c.Request("POST", "/scrobble/pause", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
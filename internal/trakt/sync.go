@@ -161,3 +161,164 @@ func (c APIClient) RemoveFromHistory(params *RemoveFromHistoryParams) (request.A
 	res, err := c.Request("POST", "/sync/history/remove", params, &response)
 	return request.NewAPIResponse(res, response), err
 }
+
+type SyncListItemsCount struct {
+	Movies   int `json:"movies"`
+	Shows    int `json:"shows"`
+	Seasons  int `json:"seasons"`
+	Episodes int `json:"episodes"`
+}
+
+type SyncListParamsItem struct {
+	Ids ListItemIds `json:"ids"`
+}
+
+type UpdateSyncListData struct {
+	ResponseError
+	Added    SyncListItemsCount `json:"added"`
+	Existing SyncListItemsCount `json:"existing"`
+	Deleted  SyncListItemsCount `json:"deleted"`
+	NotFound struct {
+		Movies []SyncHistoryResponseNotFoundItem `json:"movies"`
+		Shows  []SyncHistoryResponseNotFoundItem `json:"shows"`
+	} `json:"not_found"`
+}
+
+type UpdateSyncListParams struct {
+	Ctx
+	Movies []SyncListParamsItem `json:"movies,omitempty"`
+	Shows  []SyncListParamsItem `json:"shows,omitempty"`
+}
+
+type WatchlistItem struct {
+	Rank     int            `json:"rank"`
+	Id       int64          `json:"id"`
+	ListedAt time.Time      `json:"listed_at"`
+	Notes    string         `json:"notes,omitempty"`
+	Type     ItemType       `json:"type"` // "movie" or "show"
+	Movie    *ListItemMovie `json:"movie,omitempty"`
+	Show     *ListItemShow  `json:"show,omitempty"`
+}
+
+type GetWatchlistData = []WatchlistItem
+
+type GetWatchlistParams struct {
+	Ctx
+	Type  HistoryItemType // movies / shows
+	Page  int
+	Limit int
+}
+
+func (c APIClient) GetWatchlist(params *GetWatchlistParams) (request.APIResponse[GetWatchlistData], error) {
+	path := "/sync/watchlist"
+	if params.Type != "" {
+		path += "/" + string(params.Type)
+	}
+
+	params.Query = &url.Values{}
+	if params.Page > 0 {
+		params.Query.Set("page", strconv.Itoa(params.Page))
+	}
+	if params.Limit > 0 {
+		params.Query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := paginatedResponseData[WatchlistItem]{}
+	res, err := c.Request("GET", path, params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+func (c APIClient) AddToWatchlist(params *UpdateSyncListParams) (request.APIResponse[UpdateSyncListData], error) {
+	params.JSON = params
+	response := UpdateSyncListData{}
+	res, err := c.Request("POST", "/sync/watchlist", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+func (c APIClient) RemoveFromWatchlist(params *UpdateSyncListParams) (request.APIResponse[UpdateSyncListData], error) {
+	params.JSON = params
+	response := UpdateSyncListData{}
+	res, err := c.Request("POST", "/sync/watchlist/remove", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type CollectionItem struct {
+	CollectedAt     *time.Time     `json:"collected_at,omitempty"`      // movies
+	LastCollectedAt *time.Time     `json:"last_collected_at,omitempty"` // shows
+	Movie           *ListItemMovie `json:"movie,omitempty"`
+	Show            *ListItemShow  `json:"show,omitempty"`
+}
+
+type GetCollectionData = []CollectionItem
+
+type GetCollectionParams struct {
+	Ctx
+	Type HistoryItemType // movies / shows
+}
+
+func (c APIClient) GetCollection(params *GetCollectionParams) (request.APIResponse[GetCollectionData], error) {
+	response := paginatedResponseData[CollectionItem]{}
+	res, err := c.Request("GET", "/sync/collection/"+string(params.Type), params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+func (c APIClient) AddToCollection(params *UpdateSyncListParams) (request.APIResponse[UpdateSyncListData], error) {
+	params.JSON = params
+	response := UpdateSyncListData{}
+	res, err := c.Request("POST", "/sync/collection", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+func (c APIClient) RemoveFromCollection(params *UpdateSyncListParams) (request.APIResponse[UpdateSyncListData], error) {
+	params.JSON = params
+	response := UpdateSyncListData{}
+	res, err := c.Request("POST", "/sync/collection/remove", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+type RatingItem struct {
+	RatedAt time.Time      `json:"rated_at"`
+	Rating  int            `json:"rating"` // 1 - 10
+	Type    ItemType       `json:"type"`   // "movie" or "show"
+	Movie   *ListItemMovie `json:"movie,omitempty"`
+	Show    *ListItemShow  `json:"show,omitempty"`
+}
+
+type GetRatingsData = []RatingItem
+
+type GetRatingsParams struct {
+	Ctx
+	Type HistoryItemType // movies / shows
+}
+
+func (c APIClient) GetRatings(params *GetRatingsParams) (request.APIResponse[GetRatingsData], error) {
+	response := paginatedResponseData[RatingItem]{}
+	res, err := c.Request("GET", "/sync/ratings/"+string(params.Type), params, &response)
+	return request.NewAPIResponse(res, response.data), err
+}
+
+type SyncRatingsParamsItem struct {
+	Ids     ListItemIds `json:"ids"`
+	Rating  int         `json:"rating,omitempty"`
+	RatedAt *time.Time  `json:"rated_at,omitempty"`
+}
+
+type UpdateSyncRatingsParams struct {
+	Ctx
+	Movies []SyncRatingsParamsItem `json:"movies,omitempty"`
+	Shows  []SyncRatingsParamsItem `json:"shows,omitempty"`
+}
+
+func (c APIClient) AddRatings(params *UpdateSyncRatingsParams) (request.APIResponse[UpdateSyncListData], error) {
+	params.JSON = params
+	response := UpdateSyncListData{}
+	res, err := c.Request("POST", "/sync/ratings", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
+
+func (c APIClient) RemoveRatings(params *UpdateSyncRatingsParams) (request.APIResponse[UpdateSyncListData], error) {
+	params.JSON = params
+	response := UpdateSyncListData{}
+	res, err := c.Request("POST", "/sync/ratings/remove", params, &response)
+	return request.NewAPIResponse(res, response), err
+}
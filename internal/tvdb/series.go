@@ -190,6 +190,8 @@ func (s *ExtendedSeries) GetIdMap() *meta.IdMap {
 			idMap.IMDB = rid.Id
 		case SourceTypeTMDBTV:
 			idMap.TMDB = rid.Id
+		case SourceTypeTVMaze:
+			idMap.TVMaze = rid.Id
 		}
 	}
 	return &idMap
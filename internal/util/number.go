@@ -25,6 +25,14 @@ func SafeParseInt(str string, fallbackValue int) int {
 	return val
 }
 
+func SafeParseInt64(str string, fallbackValue int64) int64 {
+	val, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return fallbackValue
+	}
+	return val
+}
+
 func ZeroPadInt(n int, length int) string {
 	return fmt.Sprintf("%0"+strconv.Itoa(length)+"d", n)
 }
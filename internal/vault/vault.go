@@ -0,0 +1,108 @@
+package vault
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/internal/config"
+)
+
+// Ciphertext format: `v1.<key_id>.<payload>`, where `key_id` identifies the
+// secret used for `payload`. Ciphertext without version is from before
+// rotation support, and is tried with every known secret.
+const version = "v1"
+
+var ErrUnknownKey = errors.New("vault: unknown key")
+
+type key struct {
+	id     string
+	secret string
+}
+
+func newKey(secret string) key {
+	hash := sha256.Sum256([]byte("stremthru:vault:" + secret))
+	return key{
+		id:     hex.EncodeToString(hash[:4]),
+		secret: secret,
+	}
+}
+
+var currentKey = newKey(config.VaultSecret)
+
+var keys = func() []key {
+	keys := []key{currentKey}
+	for _, secret := range config.VaultOldSecrets {
+		keys = append(keys, newKey(secret))
+	}
+	return keys
+}()
+
+func getKey(id string) (key, bool) {
+	for _, k := range keys {
+		if k.id == id {
+			return k, true
+		}
+	}
+	return key{}, false
+}
+
+func parse(value string) (keyId string, payload string, ok bool) {
+	v, rest, ok := strings.Cut(value, ".")
+	if !ok || v != version {
+		return "", value, false
+	}
+	keyId, payload, ok = strings.Cut(rest, ".")
+	if !ok {
+		return "", value, false
+	}
+	return keyId, payload, true
+}
+
+func Encrypt(value string) (string, error) {
+	payload, err := core.Encrypt(currentKey.secret, value)
+	if err != nil {
+		return "", err
+	}
+	return version + "." + currentKey.id + "." + payload, nil
+}
+
+func Decrypt(value string) (string, error) {
+	keyId, payload, ok := parse(value)
+	if ok {
+		k, found := getKey(keyId)
+		if !found {
+			return "", ErrUnknownKey
+		}
+		return core.Decrypt(k.secret, payload)
+	}
+
+	var err error
+	for _, k := range keys {
+		var plaintext string
+		if plaintext, err = core.Decrypt(k.secret, payload); err == nil {
+			return plaintext, nil
+		}
+	}
+	return "", err
+}
+
+// IsCurrent checks if value is encrypted with the current secret.
+func IsCurrent(value string) bool {
+	keyId, _, ok := parse(value)
+	return ok && keyId == currentKey.id
+}
+
+// ReEncrypt returns value encrypted with the current secret.
+func ReEncrypt(value string) (string, error) {
+	if value == "" || IsCurrent(value) {
+		return value, nil
+	}
+	plaintext, err := Decrypt(value)
+	if err != nil {
+		return "", err
+	}
+	return Encrypt(plaintext)
+}
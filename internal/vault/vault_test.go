@@ -0,0 +1,63 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func withKeys(t *testing.T, current string, old ...string) {
+	prevCurrentKey, prevKeys := currentKey, keys
+	t.Cleanup(func() {
+		currentKey, keys = prevCurrentKey, prevKeys
+	})
+
+	currentKey = newKey(current)
+	keys = []key{currentKey}
+	for _, secret := range old {
+		keys = append(keys, newKey(secret))
+	}
+}
+
+func TestVault(t *testing.T) {
+	withKeys(t, "old")
+
+	oldValue, err := Encrypt("plaintext")
+	assert.NoError(t, err)
+	assert.True(t, IsCurrent(oldValue))
+
+	legacyValue, err := core.Encrypt("old", "plaintext")
+	assert.NoError(t, err)
+	assert.False(t, IsCurrent(legacyValue))
+
+	withKeys(t, "new", "old")
+
+	for _, value := range []string{oldValue, legacyValue} {
+		assert.False(t, IsCurrent(value))
+
+		plaintext, err := Decrypt(value)
+		assert.NoError(t, err)
+		assert.Equal(t, "plaintext", plaintext)
+
+		newValue, err := ReEncrypt(value)
+		assert.NoError(t, err)
+		assert.True(t, IsCurrent(newValue))
+
+		plaintext, err = Decrypt(newValue)
+		assert.NoError(t, err)
+		assert.Equal(t, "plaintext", plaintext)
+
+		sameValue, err := ReEncrypt(newValue)
+		assert.NoError(t, err)
+		assert.Equal(t, newValue, sameValue)
+	}
+
+	withKeys(t, "new")
+
+	_, err = Decrypt(oldValue)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = Decrypt(legacyValue)
+	assert.Error(t, err)
+}
@@ -18,39 +18,48 @@ func InitMapAnimeIdWorker(conf *WorkerConfig) *Worker {
 
 	conf.Executor = func(w *Worker) error {
 		worker_queue.AnimeIdMapperQueue.ProcessGroup(func(service string, items []worker_queue.AnimeIdMapperQueueItem) error {
-			if service != anime.IdMapColumn.AniList {
+			var getIdMaps func(ids []int) ([]anime.AnimeIdMap, error)
+			var getIdMapServiceId func(idMap *anime.AnimeIdMap) string
+			switch service {
+			case anime.IdMapColumn.AniList:
+				getIdMaps = anime.GetIdMapsForAniList
+				getIdMapServiceId = func(idMap *anime.AnimeIdMap) string { return idMap.AniList }
+			case anime.IdMapColumn.MAL:
+				getIdMaps = anime.GetIdMapsForMAL
+				getIdMapServiceId = func(idMap *anime.AnimeIdMap) string { return idMap.MAL }
+			default:
 				return nil
 			}
 
-			anilistIds := make([]int, len(items))
+			serviceIds := make([]int, len(items))
 			for i := range items {
 				id, err := strconv.Atoi(items[i].Id)
 				if err != nil {
 					return err
 				}
-				anilistIds[i] = id
+				serviceIds[i] = id
 			}
 
-			idMaps, err := anime.GetIdMapsForAniList(anilistIds)
+			idMaps, err := getIdMaps(serviceIds)
 			if err != nil {
 				return err
 			}
-			idMapByAnilistId := make(map[string]*anime.AnimeIdMap, len(idMaps))
+			idMapByServiceId := make(map[string]*anime.AnimeIdMap, len(idMaps))
 			for i := range idMaps {
 				idMap := &idMaps[i]
-				idMapByAnilistId[idMap.AniList] = idMap
+				idMapByServiceId[getIdMapServiceId(idMap)] = idMap
 			}
 
-			for cAnilistIds := range slices.Chunk(anilistIds, 100) {
+			for cServiceIds := range slices.Chunk(serviceIds, 100) {
 				group := pool.NewGroup()
 
-				for _, anilistId := range cAnilistIds {
-					id := strconv.Itoa(anilistId)
-					if idMap, ok := idMapByAnilistId[id]; !ok || idMap.IsStale() {
+				for _, serviceId := range cServiceIds {
+					id := strconv.Itoa(serviceId)
+					if idMap, ok := idMapByServiceId[id]; !ok || idMap.IsStale() {
 						if !ok {
-							w.Log.Debug("fetching missing idMap", "anilist_id", anilistId)
+							w.Log.Debug("fetching missing idMap", "service", service, "id", serviceId)
 						} else {
-							w.Log.Debug("fetching stale idMap", "anilist_id", anilistId)
+							w.Log.Debug("fetching stale idMap", "service", service, "id", serviceId)
 						}
 						group.SubmitErr(func() (*anizip.GetMappingsData, error) {
 							return anizipClient.GetMappings(&anizip.GetMappingsParams{
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/shared"
+	store_retention "github.com/MunifTanjim/stremthru/internal/store/retention"
+	stremio_store "github.com/MunifTanjim/stremthru/internal/stremio/store"
+)
+
+func InitStoreRetentionWorker(conf *WorkerConfig) *Worker {
+	conf.Executor = func(w *Worker) error {
+		log := w.Log
+
+		policies, err := store_retention.GetAllPolicies()
+		if err != nil {
+			return err
+		}
+
+		results := []store_retention.Result{}
+		failed := 0
+		for i := range policies {
+			policy := &policies[i]
+			if !policy.Enabled {
+				continue
+			}
+
+			var result *store_retention.Result
+			storeToken := config.StoreAuthToken.GetToken(policy.Username, string(policy.Store))
+			s := shared.GetStore(string(policy.Store))
+			if storeToken == "" || s == nil {
+				result = &store_retention.Result{
+					Id:     policy.GetId(),
+					DryRun: policy.DryRun,
+					Error:  "missing store credential",
+				}
+			} else {
+				result = policy.Apply(s, storeToken)
+				if result.Removed > 0 {
+					stremio_store.InvalidateCatalogCache(s.GetName().Code(), storeToken)
+				}
+			}
+
+			if result.Error != "" || result.Failed > 0 {
+				failed++
+				log.Warn("failed to apply policy", "policy", result.Id, "error", result.Error, "failed", result.Failed)
+			} else {
+				log.Info("applied policy", "policy", result.Id, "dry_run", result.DryRun, "total", result.TotalItems, "candidates", result.CandidateCount, "removed", result.Removed)
+			}
+
+			results = append(results, *result)
+			w.SetProgress(slices.Clone(results))
+		}
+
+		if failed > 0 {
+			return errors.New("failed to apply " + strconv.Itoa(failed) + " policy(s)")
+		}
+		return nil
+	}
+
+	worker := NewWorker(conf)
+
+	return worker
+}
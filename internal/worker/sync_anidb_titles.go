@@ -5,7 +5,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
-var syncAniDBTitlesJobTracker *JobTracker[struct{}]
+var syncAniDBTitlesJobTracker *JobTracker[any]
 
 func isAnidbTitlesSyncedToday() bool {
 	if syncAniDBTitlesJobTracker == nil {
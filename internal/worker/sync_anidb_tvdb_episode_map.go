@@ -5,7 +5,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
-var syncAniDBTVDBEpisodeMapJobTracker *JobTracker[struct{}]
+var syncAniDBTVDBEpisodeMapJobTracker *JobTracker[any]
 
 func isAniDBTVDBEpisodeMapSyncedToday() bool {
 	if syncAniDBTVDBEpisodeMapJobTracker == nil {
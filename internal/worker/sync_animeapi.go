@@ -5,7 +5,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
-var syncAnimeAPIJobTracker *JobTracker[struct{}]
+var syncAnimeAPIJobTracker *JobTracker[any]
 
 func isAnimeAPISyncedToday() bool {
 	if syncAnimeAPIJobTracker == nil {
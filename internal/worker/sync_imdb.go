@@ -7,7 +7,7 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/util"
 )
 
-var syncIMDBJobTracker *JobTracker[struct{}]
+var syncIMDBJobTracker *JobTracker[any]
 
 func isIMDBSyncedInLast24Hours() bool {
 	if syncIMDBJobTracker == nil {
@@ -2,9 +2,12 @@ package worker
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/imdb_title"
 	"github.com/MunifTanjim/stremthru/internal/logger"
 	"github.com/MunifTanjim/stremthru/internal/meta"
@@ -36,6 +39,8 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		traktClient   *trakt.APIClient
 		traktMovies   []trakt.HistoryItem
 		traktEpisodes []trakt.HistoryItem
+
+		stremioLibraryItemById map[string]*stremio_api.LibraryItem
 	}
 
 	createLibraryItem := func(ctx *Ctx, meta stremio.Meta, state stremio_api.LibraryItemState) stremio_api.LibraryItem {
@@ -56,6 +61,25 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		}
 	}
 
+	// keeps the library items fetched for the run up to date, as the syncs
+	// of a run share them.
+	updateStremioLibraryItems := func(ctx *Ctx, items []stremio_api.LibraryItem) error {
+		_, err := ctx.stremioClient.UpdateLibraryItems(&stremio_api.UpdateLibraryItemsParams{
+			Ctx:     stremio_api.Ctx{APIKey: ctx.stremioToken},
+			Changes: items,
+		})
+		if err != nil {
+			return err
+		}
+		if ctx.stremioLibraryItemById != nil {
+			for i := range items {
+				item := items[i]
+				ctx.stremioLibraryItemById[item.Id] = &item
+			}
+		}
+		return nil
+	}
+
 	getAllTraktHistory := func(client *trakt.APIClient, params trakt.GetHistoryParams) ([]trakt.HistoryItem, error) {
 		page := 1
 		limit := 100
@@ -385,11 +409,7 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 			return nil
 		}
 
-		_, err := ctx.stremioClient.UpdateLibraryItems(&stremio_api.UpdateLibraryItemsParams{
-			Ctx:     stremio_api.Ctx{APIKey: ctx.stremioToken},
-			Changes: itemsToUpdate,
-		})
-		if err != nil {
+		if err := updateStremioLibraryItems(ctx, itemsToUpdate); err != nil {
 			return err
 		}
 
@@ -496,11 +516,7 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 			return nil
 		}
 
-		_, err := ctx.stremioClient.UpdateLibraryItems(&stremio_api.UpdateLibraryItemsParams{
-			Ctx:     stremio_api.Ctx{APIKey: ctx.stremioToken},
-			Changes: itemsToUpdate,
-		})
-		if err != nil {
+		if err := updateStremioLibraryItems(ctx, itemsToUpdate); err != nil {
 			return err
 		}
 
@@ -508,7 +524,7 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		return nil
 	}
 
-	syncWatched := func(link *sync_stremio_trakt.SyncStremioTraktLink, log *logger.Logger) error {
+	initCtx := func(link *sync_stremio_trakt.SyncStremioTraktLink, log *logger.Logger) (*Ctx, error) {
 		log = log.With(
 			"stremio_account_id", link.StremioAccountId,
 			"trakt_account_id", link.TraktAccountId,
@@ -521,19 +537,19 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 
 		stremioAccount, err := stremio_account.GetById(link.StremioAccountId)
 		if err != nil || stremioAccount == nil {
-			return fmt.Errorf("stremio account not found: %w", err)
+			return nil, fmt.Errorf("stremio account not found: %w", err)
 		}
 		ctx.stremioAccount = stremioAccount
 
 		traktAccount, err := trakt_account.GetById(link.TraktAccountId)
 		if err != nil || traktAccount == nil {
-			return fmt.Errorf("trakt account not found: %w", err)
+			return nil, fmt.Errorf("trakt account not found: %w", err)
 		}
 		ctx.traktAccount = traktAccount
 
 		stremioToken, err := stremioAccount.GetValidToken()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		ctx.stremioToken = stremioToken
 
@@ -543,6 +559,13 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 
 		ctx.now = time.Now()
 
+		return ctx, nil
+	}
+
+	syncWatched := func(ctx *Ctx) error {
+		link, log := ctx.link, ctx.log
+		stremioToken := ctx.stremioToken
+
 		var startAt time.Time
 		if link.SyncState.Watched.LastSyncedAt != nil {
 			startAt = *link.SyncState.Watched.LastSyncedAt
@@ -643,6 +666,677 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		return nil
 	}
 
+	getStremioLibraryItems := func(ctx *Ctx) (map[string]*stremio_api.LibraryItem, error) {
+		if ctx.stremioLibraryItemById != nil {
+			return ctx.stremioLibraryItemById, nil
+		}
+		res, err := ctx.stremioClient.GetAllLibraryItems(&stremio_api.GetAllLibraryItemsParams{
+			Ctx: stremio_api.Ctx{APIKey: ctx.stremioToken},
+		})
+		if err != nil {
+			return nil, err
+		}
+		itemById := make(map[string]*stremio_api.LibraryItem, len(res.Data))
+		for i := range res.Data {
+			item := &res.Data[i]
+			if !strings.HasPrefix(item.Id, "tt") || (item.Type != "movie" && item.Type != "series") {
+				continue
+			}
+			itemById[item.Id] = item
+		}
+		ctx.stremioLibraryItemById = itemById
+		return itemById, nil
+	}
+
+	getAllTraktWatchlist := func(client *trakt.APIClient) (map[string]trakt.ItemType, error) {
+		itemTypeByImdbId := map[string]trakt.ItemType{}
+		for _, itemType := range []trakt.HistoryItemType{trakt.HistoryItemTypeMovies, trakt.HistoryItemTypeShows} {
+			page := 1
+			limit := 100
+			for {
+				res, err := client.GetWatchlist(&trakt.GetWatchlistParams{
+					Type:  itemType,
+					Page:  page,
+					Limit: limit,
+				})
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range res.Data {
+					if item.Movie != nil && item.Movie.Ids.IMDB != "" {
+						itemTypeByImdbId[item.Movie.Ids.IMDB] = trakt.ItemTypeMovie
+					} else if item.Show != nil && item.Show.Ids.IMDB != "" {
+						itemTypeByImdbId[item.Show.Ids.IMDB] = trakt.ItemTypeShow
+					}
+				}
+				if len(res.Data) < limit {
+					break
+				}
+				page++
+			}
+		}
+		return itemTypeByImdbId, nil
+	}
+
+	getAllTraktCollection := func(client *trakt.APIClient) (map[string]trakt.ItemType, error) {
+		itemTypeByImdbId := map[string]trakt.ItemType{}
+		for _, itemType := range []trakt.HistoryItemType{trakt.HistoryItemTypeMovies, trakt.HistoryItemTypeShows} {
+			res, err := client.GetCollection(&trakt.GetCollectionParams{
+				Type: itemType,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range res.Data {
+				if item.Movie != nil && item.Movie.Ids.IMDB != "" {
+					itemTypeByImdbId[item.Movie.Ids.IMDB] = trakt.ItemTypeMovie
+				} else if item.Show != nil && item.Show.Ids.IMDB != "" {
+					itemTypeByImdbId[item.Show.Ids.IMDB] = trakt.ItemTypeShow
+				}
+			}
+		}
+		return itemTypeByImdbId, nil
+	}
+
+	type traktListOps struct {
+		name   string
+		getAll func(client *trakt.APIClient) (map[string]trakt.ItemType, error)
+		add    func(client *trakt.APIClient, params *trakt.UpdateSyncListParams) error
+		remove func(client *trakt.APIClient, params *trakt.UpdateSyncListParams) error
+	}
+
+	traktWatchlistOps := traktListOps{
+		name:   "watchlist",
+		getAll: getAllTraktWatchlist,
+		add: func(client *trakt.APIClient, params *trakt.UpdateSyncListParams) error {
+			_, err := client.AddToWatchlist(params)
+			return err
+		},
+		remove: func(client *trakt.APIClient, params *trakt.UpdateSyncListParams) error {
+			_, err := client.RemoveFromWatchlist(params)
+			return err
+		},
+	}
+
+	traktCollectionOps := traktListOps{
+		name:   "collection",
+		getAll: getAllTraktCollection,
+		add: func(client *trakt.APIClient, params *trakt.UpdateSyncListParams) error {
+			_, err := client.AddToCollection(params)
+			return err
+		},
+		remove: func(client *trakt.APIClient, params *trakt.UpdateSyncListParams) error {
+			_, err := client.RemoveFromCollection(params)
+			return err
+		},
+	}
+
+	toUpdateSyncListParams := func(itemTypeByImdbId map[string]trakt.ItemType) *trakt.UpdateSyncListParams {
+		params := &trakt.UpdateSyncListParams{}
+		for imdbId, itemType := range itemTypeByImdbId {
+			item := trakt.SyncListParamsItem{Ids: trakt.ListItemIds{IMDB: imdbId}}
+			switch itemType {
+			case trakt.ItemTypeMovie:
+				params.Movies = append(params.Movies, item)
+			case trakt.ItemTypeShow:
+				params.Shows = append(params.Shows, item)
+			}
+		}
+		return params
+	}
+
+	// Trakt watchlist / collection is synced with the Stremio library. Items
+	// present on both sides are recorded in the sync state, so that an item
+	// missing from one side afterwards is treated as removed.
+	syncList := func(ctx *Ctx, ops traktListOps, direction sync_stremio_trakt.SyncDirection, state *sync_stremio_trakt.SyncStateList) error {
+		log := ctx.log.With("list", ops.name)
+
+		stremioItemById, err := getStremioLibraryItems(ctx)
+		if err != nil {
+			return err
+		}
+
+		traktItemTypeByImdbId, err := ops.getAll(ctx.traktClient)
+		if err != nil {
+			return err
+		}
+
+		prevIds := util.NewSet[string]()
+		for _, id := range state.Ids {
+			prevIds.Add(id)
+		}
+
+		stremioIds := util.NewSet[string]()
+		for id, item := range stremioItemById {
+			if !item.Removed && !item.Temp {
+				stremioIds.Add(id)
+			}
+		}
+
+		syncedIds := util.NewSet[string]()
+
+		toAddToTrakt := map[string]trakt.ItemType{}
+		toRemoveFromTrakt := map[string]trakt.ItemType{}
+		var stremioChanges []stremio_api.LibraryItem
+
+		for id := range stremioIds.Seq() {
+			if _, inTrakt := traktItemTypeByImdbId[id]; inTrakt {
+				syncedIds.Add(id)
+				continue
+			}
+			if prevIds.Has(id) && direction.ShouldSyncToStremio() {
+				item := *stremioItemById[id]
+				item.Removed = true
+				item.MTime = stremio_api.JSONTime{Time: ctx.now}
+				stremioChanges = append(stremioChanges, item)
+			} else if direction.ShouldSyncToTrakt() {
+				// when synced both ways, the library items untouched since
+				// the link was set up are left out, instead of pushing the
+				// whole library on the first sync.
+				if direction.ShouldSyncToStremio() && !stremioItemById[id].MTime.After(ctx.link.CAt.Time) {
+					continue
+				}
+				itemType := trakt.ItemTypeMovie
+				if stremioItemById[id].Type == "series" {
+					itemType = trakt.ItemTypeShow
+				}
+				toAddToTrakt[id] = itemType
+				syncedIds.Add(id)
+			}
+		}
+
+		for id, itemType := range traktItemTypeByImdbId {
+			if stremioIds.Has(id) {
+				continue
+			}
+			if prevIds.Has(id) && direction.ShouldSyncToTrakt() {
+				toRemoveFromTrakt[id] = itemType
+			} else if direction.ShouldSyncToStremio() {
+				if existingItem, ok := stremioItemById[id]; ok {
+					item := *existingItem
+					item.Removed = false
+					item.Temp = false
+					item.MTime = stremio_api.JSONTime{Time: ctx.now}
+					stremioChanges = append(stremioChanges, item)
+				} else {
+					sType := "movie"
+					if itemType == trakt.ItemTypeShow {
+						sType = "series"
+					}
+					meta, err := cinemeta.FetchMeta(sType, id)
+					if err != nil {
+						log.Warn("failed to fetch meta", "error", err, "type", sType, "id", id)
+						continue
+					}
+					stremioChanges = append(stremioChanges, createLibraryItem(ctx, meta, stremio_api.LibraryItemState{}))
+				}
+				syncedIds.Add(id)
+			}
+		}
+
+		if len(toAddToTrakt) > 0 {
+			if err := ops.add(ctx.traktClient, toUpdateSyncListParams(toAddToTrakt)); err != nil {
+				return err
+			}
+			log.Debug("added items to trakt", "count", len(toAddToTrakt))
+		}
+
+		if len(toRemoveFromTrakt) > 0 {
+			if err := ops.remove(ctx.traktClient, toUpdateSyncListParams(toRemoveFromTrakt)); err != nil {
+				return err
+			}
+			log.Debug("removed items from trakt", "count", len(toRemoveFromTrakt))
+		}
+
+		if len(stremioChanges) > 0 {
+			if err := updateStremioLibraryItems(ctx, stremioChanges); err != nil {
+				return err
+			}
+			log.Debug("updated stremio library items", "count", len(stremioChanges))
+		}
+
+		state.Ids = syncedIds.ToSlice()
+		slices.Sort(state.Ids)
+		state.LastSyncedAt = &ctx.now
+		return nil
+	}
+
+	// Watchlist and collection are both synced with the Stremio library, so
+	// only one of them is synced, the watchlist taking precedence.
+	syncLists := func(ctx *Ctx) error {
+		link := ctx.link
+
+		ops, direction, state := traktWatchlistOps, link.SyncConfig.Watchlist.Direction, &link.SyncState.Watchlist
+		if direction.IsDisabled() {
+			ops, direction, state = traktCollectionOps, link.SyncConfig.Collection.Direction, &link.SyncState.Collection
+		} else if !link.SyncConfig.Collection.Direction.IsDisabled() {
+			ctx.log.Warn("skipping list sync, only one of watchlist and collection can be synced", "list", traktCollectionOps.name)
+		}
+
+		ctx.log.Debug("starting list sync", "list", ops.name, "direction", direction)
+		if err := syncList(ctx, ops, direction, state); err != nil {
+			ctx.log.Error("failed to sync list", "error", err, "list", ops.name)
+			return err
+		}
+		sync_stremio_trakt.SetSyncState(link.StremioAccountId, link.TraktAccountId, link.SyncState)
+		return nil
+	}
+
+	getAllTraktPlayback := func(client *trakt.APIClient) ([]trakt.PlaybackItem, error) {
+		page := 1
+		limit := 100
+		var allItems []trakt.PlaybackItem
+		for {
+			res, err := client.GetPlaybackProgress(&trakt.GetPlaybackProgressParams{
+				Page:  page,
+				Limit: limit,
+			})
+			if err != nil {
+				return nil, err
+			}
+			allItems = append(allItems, res.Data...)
+			if len(res.Data) < limit {
+				break
+			}
+			page++
+		}
+		return allItems, nil
+	}
+
+	// progress beyond this is considered watched, which is handled by watched sync
+	const maxPlaybackProgress = 80.0
+	// progress difference below this is ignored, to avoid syncing back and forth
+	const minPlaybackProgressDelta = 1.0
+
+	getStremioPlaybackProgress := func(item *stremio_api.LibraryItem) float64 {
+		if item.State.Duration <= 0 || item.State.TimeOffset <= 0 {
+			return 0
+		}
+		return float64(item.State.TimeOffset) / float64(item.State.Duration) * 100
+	}
+
+	syncPlayback := func(ctx *Ctx) error {
+		link, log := ctx.link, ctx.log
+
+		direction := link.SyncConfig.Playback.Direction
+
+		var startAt time.Time
+		if link.SyncState.Playback.LastSyncedAt != nil {
+			startAt = *link.SyncState.Playback.LastSyncedAt
+		}
+		ctx.isFullSync = startAt.IsZero()
+
+		log.Debug("starting playback sync", "is_full_sync", ctx.isFullSync, "start_at", startAt)
+
+		stremioItemById, err := getStremioLibraryItems(ctx)
+		if err != nil {
+			return err
+		}
+
+		traktPlaybackItems, err := getAllTraktPlayback(ctx.traktClient)
+		if err != nil {
+			return err
+		}
+
+		// keyed by stremio video id
+		traktPlaybackByVideoId := map[string]*trakt.PlaybackItem{}
+		for i := range traktPlaybackItems {
+			item := &traktPlaybackItems[i]
+			videoId := ""
+			switch item.Type {
+			case trakt.ItemTypeMovie:
+				if item.Movie == nil || item.Movie.Ids.IMDB == "" {
+					continue
+				}
+				videoId = item.Movie.Ids.IMDB
+			case trakt.ItemTypeEpisode:
+				if item.Show == nil || item.Episode == nil || item.Show.Ids.IMDB == "" {
+					continue
+				}
+				videoId = fmt.Sprintf("%s:%d:%d", item.Show.Ids.IMDB, item.Episode.Season, item.Episode.Number)
+			default:
+				continue
+			}
+			if existing, ok := traktPlaybackByVideoId[videoId]; ok && existing.PausedAt.After(item.PausedAt) {
+				continue
+			}
+			traktPlaybackByVideoId[videoId] = item
+		}
+
+		log.Debug("fetched trakt playback items", "count", len(traktPlaybackByVideoId))
+
+		syncedVideoIds := util.NewSet[string]()
+
+		if direction.ShouldSyncToTrakt() {
+			count := 0
+			for _, item := range stremioItemById {
+				if item.Removed && !item.Temp {
+					continue
+				}
+				if !ctx.isFullSync && !item.State.LastWatched.After(startAt) {
+					continue
+				}
+				progress := getStremioPlaybackProgress(item)
+				if progress < minPlaybackProgressDelta || progress >= maxPlaybackProgress {
+					continue
+				}
+
+				params := &trakt.ScrobbleParams{
+					Progress: progress,
+				}
+				videoId := item.State.VideoId
+				switch item.Type {
+				case "movie":
+					if videoId == "" {
+						videoId = item.Id
+					}
+					if videoId != item.Id {
+						continue
+					}
+					params.Movie = &trakt.ScrobbleParamsMovie{
+						Ids: trakt.ListItemIds{IMDB: item.Id},
+					}
+				case "series":
+					parts := strings.Split(videoId, ":")
+					if len(parts) != 3 || parts[0] != item.Id {
+						continue
+					}
+					season, episode := util.SafeParseInt(parts[1], 0), util.SafeParseInt(parts[2], 0)
+					if season < 1 || episode < 1 {
+						continue
+					}
+					params.Show = &trakt.ScrobbleParamsShow{
+						Ids: trakt.ListItemIds{IMDB: item.Id},
+					}
+					params.Episode = &trakt.ScrobbleParamsEpisode{
+						Season: season,
+						Number: episode,
+					}
+				default:
+					continue
+				}
+
+				if tItem, ok := traktPlaybackByVideoId[videoId]; ok {
+					if !tItem.PausedAt.Before(item.State.LastWatched) || math.Abs(tItem.Progress-progress) < minPlaybackProgressDelta {
+						continue
+					}
+				}
+
+				if _, err := ctx.traktClient.ScrobblePause(params); err != nil {
+					log.Error("failed to sync playback to trakt", "error", err, "video_id", videoId)
+					return err
+				}
+				syncedVideoIds.Add(videoId)
+				count++
+			}
+			log.Debug("synced playback from stremio to trakt", "count", count)
+		}
+
+		if direction.ShouldSyncToStremio() {
+			// a library item holds a single playback, so only the latest one
+			// of a show is used.
+			latestVideoIdByImdbId := map[string]string{}
+			for videoId, tItem := range traktPlaybackByVideoId {
+				imdbId, _, _ := strings.Cut(videoId, ":")
+				if latestVideoId, ok := latestVideoIdByImdbId[imdbId]; ok {
+					latest := traktPlaybackByVideoId[latestVideoId]
+					if tItem.PausedAt.Before(latest.PausedAt) || (tItem.PausedAt.Equal(latest.PausedAt) && videoId < latestVideoId) {
+						continue
+					}
+				}
+				latestVideoIdByImdbId[imdbId] = videoId
+			}
+
+			var itemsToUpdate []stremio_api.LibraryItem
+			for _, videoId := range latestVideoIdByImdbId {
+				tItem := traktPlaybackByVideoId[videoId]
+				if syncedVideoIds.Has(videoId) {
+					continue
+				}
+				if !ctx.isFullSync && !tItem.PausedAt.After(startAt) {
+					continue
+				}
+				if tItem.Progress < minPlaybackProgressDelta || tItem.Progress >= maxPlaybackProgress {
+					continue
+				}
+
+				imdbId, sType, runtime := "", "", 0
+				season, episode := 0, 0
+				switch tItem.Type {
+				case trakt.ItemTypeMovie:
+					imdbId, sType, runtime = tItem.Movie.Ids.IMDB, "movie", tItem.Movie.Runtime
+				case trakt.ItemTypeEpisode:
+					imdbId, sType, runtime = tItem.Show.Ids.IMDB, "series", tItem.Episode.Runtime
+					season, episode = tItem.Episode.Season, tItem.Episode.Number
+				}
+
+				var libraryItem stremio_api.LibraryItem
+				if existingItem, exists := stremioItemById[imdbId]; exists {
+					if !tItem.PausedAt.After(existingItem.State.LastWatched) {
+						continue
+					}
+					if existingItem.State.VideoId == videoId && math.Abs(getStremioPlaybackProgress(existingItem)-tItem.Progress) < minPlaybackProgressDelta {
+						continue
+					}
+					libraryItem = *existingItem
+					libraryItem.MTime = stremio_api.JSONTime{Time: ctx.now}
+				} else {
+					meta, err := cinemeta.FetchMeta(sType, imdbId)
+					if err != nil {
+						log.Warn("failed to fetch meta", "error", err, "type", sType, "id", imdbId)
+						continue
+					}
+					libraryItem = createLibraryItem(ctx, meta, stremio_api.LibraryItemState{})
+					// shows up in continue watching, without adding to library
+					libraryItem.Removed = true
+					libraryItem.Temp = true
+				}
+
+				duration := 0
+				if libraryItem.State.VideoId == videoId && libraryItem.State.Duration > 0 {
+					duration = libraryItem.State.Duration
+				} else {
+					duration = runtime * 60 * 1000
+				}
+				if duration <= 0 {
+					continue
+				}
+
+				libraryItem.State.VideoId = videoId
+				libraryItem.State.Duration = duration
+				libraryItem.State.TimeOffset = int(tItem.Progress / 100 * float64(duration))
+				libraryItem.State.LastWatched = tItem.PausedAt
+				if sType == "series" {
+					libraryItem.State.Season = season
+					libraryItem.State.Episode = episode
+				}
+				itemsToUpdate = append(itemsToUpdate, libraryItem)
+			}
+
+			if len(itemsToUpdate) > 0 {
+				if err := updateStremioLibraryItems(ctx, itemsToUpdate); err != nil {
+					log.Error("failed to sync playback to stremio", "error", err)
+					return err
+				}
+			}
+			log.Debug("synced playback from trakt to stremio", "count", len(itemsToUpdate))
+		}
+
+		link.SyncState.Playback.LastSyncedAt = &ctx.now
+		sync_stremio_trakt.SetSyncState(link.StremioAccountId, link.TraktAccountId, link.SyncState)
+		return nil
+	}
+
+	getAllTraktRatings := func(client *trakt.APIClient) (map[string]*trakt.RatingItem, error) {
+		ratingByImdbId := map[string]*trakt.RatingItem{}
+		for _, itemType := range []trakt.HistoryItemType{trakt.HistoryItemTypeMovies, trakt.HistoryItemTypeShows} {
+			res, err := client.GetRatings(&trakt.GetRatingsParams{
+				Type: itemType,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for i := range res.Data {
+				item := &res.Data[i]
+				if item.Movie != nil && item.Movie.Ids.IMDB != "" {
+					ratingByImdbId[item.Movie.Ids.IMDB] = item
+				} else if item.Show != nil && item.Show.Ids.IMDB != "" {
+					ratingByImdbId[item.Show.Ids.IMDB] = item
+				}
+			}
+		}
+		return ratingByImdbId, nil
+	}
+
+	pushRatingsToTrakt := func(ctx *Ctx, ratings []sync_stremio_trakt.SyncStremioTraktRating) error {
+		toAdd := &trakt.UpdateSyncRatingsParams{}
+		toRemove := &trakt.UpdateSyncRatingsParams{}
+		var pushed []sync_stremio_trakt.SyncStremioTraktRating
+		var removedIds []string
+		for i := range ratings {
+			r := ratings[i]
+			if !r.Pending {
+				continue
+			}
+			item := trakt.SyncRatingsParamsItem{Ids: trakt.ListItemIds{IMDB: r.Id}}
+			params := toAdd
+			if r.Rating == 0 {
+				params = toRemove
+				removedIds = append(removedIds, r.Id)
+			} else {
+				item.Rating = r.Rating
+				item.RatedAt = &r.RatedAt.Time
+				r.Pending = false
+				pushed = append(pushed, r)
+			}
+			switch r.Type {
+			case "movie":
+				params.Movies = append(params.Movies, item)
+			case "series":
+				params.Shows = append(params.Shows, item)
+			}
+		}
+
+		if len(toAdd.Movies) > 0 || len(toAdd.Shows) > 0 {
+			if _, err := ctx.traktClient.AddRatings(toAdd); err != nil {
+				return err
+			}
+		}
+		if len(toRemove.Movies) > 0 || len(toRemove.Shows) > 0 {
+			if _, err := ctx.traktClient.RemoveRatings(toRemove); err != nil {
+				return err
+			}
+		}
+
+		if err := sync_stremio_trakt.UpsertRatings(pushed); err != nil {
+			return err
+		}
+		if err := sync_stremio_trakt.DeleteRatings(ctx.link.StremioAccountId, ctx.link.TraktAccountId, removedIds); err != nil {
+			return err
+		}
+
+		ctx.log.Debug("pushed ratings to trakt", "added", len(pushed), "removed", len(removedIds))
+		return nil
+	}
+
+	// Stremio has no ratings, so Trakt ratings of the items in the Stremio
+	// library are recorded on StremThru side.
+	syncRatings := func(ctx *Ctx) error {
+		link, log := ctx.link, ctx.log
+
+		config := link.SyncConfig.Ratings
+
+		if !config.Push {
+			// left over from when push was enabled
+			if err := sync_stremio_trakt.DeletePendingRatings(link.StremioAccountId, link.TraktAccountId); err != nil {
+				return err
+			}
+		}
+
+		ratings, err := sync_stremio_trakt.GetRatings(link.StremioAccountId, link.TraktAccountId)
+		if err != nil {
+			return err
+		}
+
+		if config.Push {
+			if err := pushRatingsToTrakt(ctx, ratings); err != nil {
+				log.Error("failed to push ratings to trakt", "error", err)
+				return err
+			}
+			ratings, err = sync_stremio_trakt.GetRatings(link.StremioAccountId, link.TraktAccountId)
+			if err != nil {
+				return err
+			}
+		}
+
+		if config.Direction.ShouldSyncToStremio() {
+			stremioItemById, err := getStremioLibraryItems(ctx)
+			if err != nil {
+				return err
+			}
+
+			traktRatingByImdbId, err := getAllTraktRatings(ctx.traktClient)
+			if err != nil {
+				return err
+			}
+
+			ratingById := make(map[string]*sync_stremio_trakt.SyncStremioTraktRating, len(ratings))
+			for i := range ratings {
+				ratingById[ratings[i].Id] = &ratings[i]
+			}
+
+			var toUpsert []sync_stremio_trakt.SyncStremioTraktRating
+			var toDelete []string
+			for id, item := range stremioItemById {
+				if item.Removed {
+					continue
+				}
+				existing, hasExisting := ratingById[id]
+				if hasExisting && existing.Pending {
+					continue
+				}
+				tRating, ok := traktRatingByImdbId[id]
+				if !ok {
+					if hasExisting {
+						toDelete = append(toDelete, id)
+					}
+					continue
+				}
+				if hasExisting && existing.Rating == tRating.Rating && existing.RatedAt.Equal(tRating.RatedAt) {
+					continue
+				}
+				toUpsert = append(toUpsert, sync_stremio_trakt.SyncStremioTraktRating{
+					StremioAccountId: link.StremioAccountId,
+					TraktAccountId:   link.TraktAccountId,
+					Id:               id,
+					Type:             item.Type,
+					Rating:           tRating.Rating,
+					RatedAt:          db.Timestamp{Time: tRating.RatedAt},
+				})
+			}
+			for id, existing := range ratingById {
+				if existing.Pending {
+					continue
+				}
+				if item, ok := stremioItemById[id]; !ok || item.Removed {
+					toDelete = append(toDelete, id)
+				}
+			}
+
+			if err := sync_stremio_trakt.UpsertRatings(toUpsert); err != nil {
+				return err
+			}
+			if err := sync_stremio_trakt.DeleteRatings(link.StremioAccountId, link.TraktAccountId, toDelete); err != nil {
+				return err
+			}
+			log.Debug("synced ratings from trakt to stremio", "updated", len(toUpsert), "removed", len(toDelete))
+		}
+
+		link.SyncState.Ratings.LastSyncedAt = &ctx.now
+		sync_stremio_trakt.SetSyncState(link.StremioAccountId, link.TraktAccountId, link.SyncState)
+		return nil
+	}
+
 	conf.Executor = func(w *Worker) error {
 		log := w.Log
 
@@ -652,10 +1346,46 @@ func InitSyncStremioTraktWorker(conf *WorkerConfig) *Worker {
 		}
 
 		for _, link := range links {
-			if !link.SyncConfig.Watched.Direction.IsDisabled() {
-				err := syncWatched(&link, log)
-				if err != nil {
-					return err
+			isWatchedEnabled := !link.SyncConfig.Watched.Direction.IsDisabled()
+			isListsEnabled := !link.SyncConfig.Watchlist.Direction.IsDisabled() || !link.SyncConfig.Collection.Direction.IsDisabled()
+			isPlaybackEnabled := !link.SyncConfig.Playback.Direction.IsDisabled()
+			isRatingsEnabled := !link.SyncConfig.Ratings.IsDisabled()
+			if !isWatchedEnabled && !isListsEnabled && !isPlaybackEnabled && !isRatingsEnabled {
+				continue
+			}
+
+			linkLog := log.With(
+				"stremio_account_id", link.StremioAccountId,
+				"trakt_account_id", link.TraktAccountId,
+			)
+
+			ctx, err := initCtx(&link, log)
+			if err != nil {
+				linkLog.Error("failed to init sync", "error", err)
+				continue
+			}
+			if isWatchedEnabled {
+				if err := syncWatched(ctx); err != nil {
+					linkLog.Error("failed to sync watched", "error", err)
+					continue
+				}
+			}
+			if isListsEnabled {
+				if err := syncLists(ctx); err != nil {
+					linkLog.Error("failed to sync lists", "error", err)
+					continue
+				}
+			}
+			if isPlaybackEnabled {
+				if err := syncPlayback(ctx); err != nil {
+					linkLog.Error("failed to sync playback", "error", err)
+					continue
+				}
+			}
+			if isRatingsEnabled {
+				if err := syncRatings(ctx); err != nil {
+					linkLog.Error("failed to sync ratings", "error", err)
+					continue
 				}
 			}
 		}
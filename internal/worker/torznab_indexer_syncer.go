@@ -73,7 +73,7 @@ func InitTorznabIndexerSyncerWorker(conf *WorkerConfig) *Worker {
 
 			var client tznc.Indexer
 			switch indexer.Type {
-			case torznab_indexer.IndexerTypeJackett:
+			case torznab_indexer.IndexerTypeJackett, torznab_indexer.IndexerTypeProwlarr:
 				c, err := indexer.GetClient()
 				if err != nil {
 					log.Error("failed to create torznab client", "error", err, "type", indexer.Type, "id", indexer.Id)
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"errors"
+	"strconv"
+
+	stremio_account "github.com/MunifTanjim/stremthru/internal/stremio/account"
+	torznab_indexer "github.com/MunifTanjim/stremthru/internal/torznab/indexer"
+	"github.com/MunifTanjim/stremthru/internal/worker/worker_queue"
+)
+
+type VaultReEncrypterTableProgress struct {
+	Total   int `json:"total"`
+	Done    int `json:"done"`
+	Updated int `json:"updated"`
+	Failed  int `json:"failed"`
+}
+
+type VaultReEncrypterProgress struct {
+	StremioAccount VaultReEncrypterTableProgress `json:"stremio_account"`
+	TorznabIndexer VaultReEncrypterTableProgress `json:"torznab_indexer"`
+}
+
+func InitVaultReEncrypterWorker(conf *WorkerConfig) *Worker {
+	conf.Executor = func(w *Worker) error {
+		log := w.Log
+
+		progress := VaultReEncrypterProgress{}
+
+		track := func(p *VaultReEncrypterTableProgress, updated bool, err error) {
+			p.Done++
+			if err != nil {
+				p.Failed++
+			} else if updated {
+				p.Updated++
+			}
+			w.SetProgress(progress)
+		}
+
+		var err error
+		worker_queue.VaultReEncrypterQueue.Process(func(item worker_queue.VaultReEncrypterQueueItem) error {
+			progress = VaultReEncrypterProgress{}
+
+			accounts, aerr := stremio_account.GetAll()
+			if aerr != nil {
+				log.Error("failed to get stremio accounts", "error", aerr)
+				return aerr
+			}
+			indexers, ierr := torznab_indexer.GetAll()
+			if ierr != nil {
+				log.Error("failed to get torznab indexers", "error", ierr)
+				return ierr
+			}
+
+			progress.StremioAccount.Total = len(accounts)
+			progress.TorznabIndexer.Total = len(indexers)
+			w.SetProgress(progress)
+
+			for i := range accounts {
+				account := &accounts[i]
+				updated, err := account.ReEncrypt()
+				if err != nil {
+					log.Error("failed to re-encrypt stremio account", "error", err, "id", account.Id)
+				}
+				track(&progress.StremioAccount, updated, err)
+			}
+
+			for i := range indexers {
+				indexer := &indexers[i]
+				updated, err := indexer.ReEncrypt()
+				if err != nil {
+					log.Error("failed to re-encrypt torznab indexer", "error", err, "type", indexer.Type, "id", indexer.Id)
+				}
+				track(&progress.TorznabIndexer, updated, err)
+			}
+
+			log.Info("re-encrypted vault", "stremio_account", progress.StremioAccount.Updated, "torznab_indexer", progress.TorznabIndexer.Updated)
+
+			// retrying won't help rows encrypted with an unknown secret,
+			// so failures are reported on the job instead.
+			if failed := progress.StremioAccount.Failed + progress.TorznabIndexer.Failed; failed > 0 {
+				err = errors.New("failed to re-encrypt " + strconv.Itoa(failed) + " row(s)")
+			}
+			return nil
+		})
+
+		return err
+	}
+
+	worker := NewWorker(conf)
+
+	return worker
+}
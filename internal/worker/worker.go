@@ -8,6 +8,8 @@ import (
 	"github.com/MunifTanjim/stremthru/internal/db"
 	"github.com/MunifTanjim/stremthru/internal/job_log"
 	"github.com/MunifTanjim/stremthru/internal/logger"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
+	store_retention "github.com/MunifTanjim/stremthru/internal/store/retention"
 	torznab_indexer "github.com/MunifTanjim/stremthru/internal/torznab/indexer"
 	torznab_indexer_syncinfo "github.com/MunifTanjim/stremthru/internal/torznab/indexer/syncinfo"
 	"github.com/MunifTanjim/stremthru/internal/util"
@@ -36,7 +38,27 @@ type Worker struct {
 	onStart    func()
 	onEnd      func()
 	Log        *logger.Logger
-	jobTracker *JobTracker[struct{}]
+	jobTracker *JobTracker[any]
+
+	progressMutex sync.Mutex
+	progress      any
+}
+
+// SetProgress sets the data saved with the running job's status.
+func (w *Worker) SetProgress(data any) {
+	w.progressMutex.Lock()
+	defer w.progressMutex.Unlock()
+	w.progress = data
+}
+
+func (w *Worker) getProgress() *any {
+	w.progressMutex.Lock()
+	defer w.progressMutex.Unlock()
+	if w.progress == nil {
+		return nil
+	}
+	progress := w.progress
+	return &progress
 }
 
 type WorkerConfig struct {
@@ -118,12 +140,21 @@ var WorkerDetailsById = map[string]*WorkerDetail{
 	"sync-stremio-stremio": {
 		Title: "Sync Stremio-Stremio",
 	},
+	"sync-stremio-simkl": {
+		Title: "Sync Stremio-Simkl",
+	},
 	"queue-torznab-indexer-sync": {
 		Title: "Queue Torznab Indexer Sync",
 	},
 	"sync-torznab-indexer": {
 		Title: "Sync Torznab Indexer",
 	},
+	"reencrypt-vault": {
+		Title: "Re-encrypt Vault",
+	},
+	"apply-store-retention": {
+		Title: "Apply Store Retention",
+	},
 }
 
 func NewWorker(conf *WorkerConfig) *Worker {
@@ -180,7 +211,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 	}
 
 	jobTrackerExpiresIn := max(3*24*time.Hour, 10*conf.Interval)
-	jobTracker := NewJobTracker[struct{}](conf.Name, jobTrackerExpiresIn)
+	jobTracker := NewJobTracker[any](conf.Name, jobTrackerExpiresIn)
 	worker.jobTracker = jobTracker
 
 	jobId := ""
@@ -230,7 +261,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 			}
 			defer lock.Release()
 
-			var tjob *job_log.ParsedJobLog[struct{}]
+			var tjob *job_log.ParsedJobLog[any]
 			if conf.RunExclusive {
 				tjob, err = jobTracker.GetLast()
 				if err != nil {
@@ -265,6 +296,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 			}
 
 			jobId = time.Now().Format(time.DateTime)
+			worker.SetProgress(nil)
 
 			err = jobTracker.Set(jobId, "started", "", nil)
 			if err != nil {
@@ -287,7 +319,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 						if jobId == "" {
 							return
 						}
-						if err := jobTracker.Set(jobId, "started", "", nil); err != nil {
+						if err := jobTracker.Set(jobId, "started", "", worker.getProgress()); err != nil {
 							log.Error("failed to set job status heartbeat", "error", err, "jobId", jobId)
 						}
 					case <-heartbeat_done:
@@ -297,11 +329,14 @@ func NewWorker(conf *WorkerConfig) *Worker {
 				}
 			}()
 
-			if err = conf.Executor(worker); err != nil {
+			start := time.Now()
+			err = conf.Executor(worker)
+			metrics.ObserveWorkerRun(conf.Name, start, err)
+			if err != nil {
 				return err
 			}
 
-			err = jobTracker.Set(jobId, "done", "", nil)
+			err = jobTracker.Set(jobId, "done", "", worker.getProgress())
 			if err != nil {
 				log.Error("failed to set job status", "error", err, "jobId", jobId, "status", "done")
 				return err
@@ -321,7 +356,7 @@ func NewWorker(conf *WorkerConfig) *Worker {
 				jobId = ""
 			}()
 
-			if terr := jobTracker.Set(jobId, "failed", err.Error(), nil); terr != nil {
+			if terr := jobTracker.Set(jobId, "failed", err.Error(), worker.getProgress()); terr != nil {
 				log.Error("failed to set job status", "error", terr, "jobId", jobId, "status", "failed")
 			}
 		},
@@ -849,6 +884,30 @@ func InitWorkers() func() {
 		workers = append(workers, worker)
 	}
 
+	if worker := InitVaultReEncrypterWorker(&WorkerConfig{
+		Disabled:     worker_queue.VaultReEncrypterQueue.Disabled,
+		Name:         "reencrypt-vault",
+		Interval:     1 * time.Minute,
+		RunExclusive: true,
+		ShouldSkip: func() bool {
+			return worker_queue.VaultReEncrypterQueue.IsEmpty()
+		},
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
+	if worker := InitStoreRetentionWorker(&WorkerConfig{
+		Disabled:     !config.Feature.HasVault(),
+		Name:         "apply-store-retention",
+		Interval:     6 * time.Hour,
+		RunExclusive: true,
+		ShouldSkip: func() bool {
+			return !store_retention.HasEnabledPolicy()
+		},
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
 	if worker := InitSyncStremioTraktWorker(&WorkerConfig{
 		Disabled:          !config.Feature.HasVault() || !config.Integration.Trakt.IsEnabled(),
 		Name:              "sync-stremio-trakt",
@@ -864,6 +923,21 @@ func InitWorkers() func() {
 		workers = append(workers, worker)
 	}
 
+	if worker := InitSyncStremioSimklWorker(&WorkerConfig{
+		Disabled:          !config.Feature.HasVault() || !config.Integration.Simkl.IsEnabled(),
+		Name:              "sync-stremio-simkl",
+		Interval:          30 * time.Minute,
+		RunAtStartupAfter: 5 * time.Minute,
+		RunExclusive:      true,
+		ShouldWait: func() (bool, string) {
+			return false, ""
+		},
+		OnStart: func() {},
+		OnEnd:   func() {},
+	}); worker != nil {
+		workers = append(workers, worker)
+	}
+
 	if worker := InitSyncStremioStremioWorker(&WorkerConfig{
 		Disabled:          !config.Feature.HasVault(),
 		Name:              "sync-stremio-stremio",
@@ -0,0 +1,154 @@
+package worker_queue
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/db"
+	"github.com/MunifTanjim/stremthru/internal/util"
+)
+
+const TableName = "worker_queue"
+
+type WorkerQueueRecord struct {
+	Queue     string
+	Key       string
+	GroupKey  string
+	Value     string // JSON Encoded Value
+	ProcessAt db.Timestamp
+	Version   int // bumped every time the item is queued
+	CAt       db.Timestamp
+	UAt       db.Timestamp
+}
+
+var Column = struct {
+	Queue     string
+	Key       string
+	GroupKey  string
+	Value     string
+	ProcessAt string
+	Version   string
+	CAt       string
+	UAt       string
+}{
+	Queue:     "q",
+	Key:       "k",
+	GroupKey:  "gk",
+	Value:     "v",
+	ProcessAt: "pat",
+	Version:   "ver",
+	CAt:       "cat",
+	UAt:       "uat",
+}
+
+var query_upsert_before_values = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES `,
+	TableName,
+	db.JoinColumnNames(
+		Column.Queue,
+		Column.Key,
+		Column.GroupKey,
+		Column.Value,
+		Column.ProcessAt,
+	),
+)
+var query_upsert_values_placeholder = "(" + util.RepeatJoin("?", 5, ",") + ")"
+var query_upsert_on_conflict = fmt.Sprintf(
+	` ON CONFLICT (%s, %s) DO UPDATE SET %s`,
+	Column.Queue,
+	Column.Key,
+	strings.Join([]string{
+		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.GroupKey, Column.GroupKey),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.Value, Column.Value),
+		fmt.Sprintf(`%s = EXCLUDED.%s`, Column.ProcessAt, Column.ProcessAt),
+		fmt.Sprintf(`%s = %s.%s + 1`, Column.Version, TableName, Column.Version),
+		fmt.Sprintf(`%s = %s`, Column.UAt, db.CurrentTimestamp),
+	}, ", "),
+)
+
+// records must have unique keys
+func upsert(records []WorkerQueueRecord) error {
+	errs := []error{}
+	for cRecords := range slices.Chunk(records, 100) {
+		count := len(cRecords)
+		args := make([]any, 0, count*5)
+		for i := range cRecords {
+			r := &cRecords[i]
+			args = append(args, r.Queue, r.Key, r.GroupKey, r.Value, r.ProcessAt)
+		}
+		query := query_upsert_before_values +
+			util.RepeatJoin(query_upsert_values_placeholder, count, ",") +
+			query_upsert_on_conflict
+		if _, err := db.Exec(query, args...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var query_exists = fmt.Sprintf(
+	`SELECT 1 FROM %s WHERE %s = ? LIMIT 1`,
+	TableName,
+	Column.Queue,
+)
+
+func exists(queue string) bool {
+	var one int
+	err := db.QueryRow(query_exists, queue).Scan(&one)
+	return err == nil
+}
+
+var query_get_ready = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = ? AND %s <= ? ORDER BY %s ASC`,
+	db.JoinColumnNames(
+		Column.Key,
+		Column.GroupKey,
+		Column.Value,
+		Column.ProcessAt,
+		Column.Version,
+	),
+	TableName,
+	Column.Queue,
+	Column.ProcessAt,
+	Column.ProcessAt,
+)
+
+func getReady(queue string) ([]WorkerQueueRecord, error) {
+	rows, err := db.Query(query_get_ready, queue, db.Timestamp{Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []WorkerQueueRecord{}
+	for rows.Next() {
+		item := WorkerQueueRecord{Queue: queue}
+		if err := rows.Scan(&item.Key, &item.GroupKey, &item.Value, &item.ProcessAt, &item.Version); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// deletes the item only if it was not queued again after it was read
+var query_delete = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = ? AND %s = ? AND %s = ?`,
+	TableName,
+	Column.Queue,
+	Column.Key,
+	Column.Version,
+)
+
+func deleteRecord(record *WorkerQueueRecord) error {
+	_, err := db.Exec(query_delete, record.Queue, record.Key, record.Version)
+	return err
+}
@@ -15,6 +15,7 @@ type MagnetCachePullerQueueItem struct {
 }
 
 var MagnetCachePullerQueue = WorkerQueue[MagnetCachePullerQueueItem]{
+	name:         "magnet-cache-puller",
 	debounceTime: 5 * time.Minute,
 	getKey: func(item MagnetCachePullerQueueItem) string {
 		return item.StoreCode + ":" + item.SId + ":" + item.Hash
@@ -25,5 +26,13 @@ var MagnetCachePullerQueue = WorkerQueue[MagnetCachePullerQueueItem]{
 	transform: func(item *MagnetCachePullerQueueItem) *MagnetCachePullerQueueItem {
 		return item
 	},
+	seal: func(item *MagnetCachePullerQueueItem) (err error) {
+		item.StoreToken, err = sealStoreToken(item.StoreToken)
+		return err
+	},
+	unseal: func(item *MagnetCachePullerQueueItem) (err error) {
+		item.StoreToken, err = unsealStoreToken(item.StoreToken)
+		return err
+	},
 	Disabled: !config.PeerFlag.Lazy,
 }
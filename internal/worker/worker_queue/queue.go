@@ -1,94 +1,256 @@
 package worker_queue
 
 import (
+	"encoding/json"
 	"errors"
 	"sync"
 	"time"
+
+	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/db"
 )
 
 type WorkerQueueItem[T any] struct {
 	v T
-	t time.Time
+	r *WorkerQueueRecord
 }
 
 type WorkerQueue[T any] struct {
-	m            sync.Map
+	name         string
 	getKey       func(item T) string
 	getGroupKey  func(item T) string
 	transform    func(item *T) *T
+	seal         func(item *T) error // protects secrets before persisting
+	unseal       func(item *T) error
 	debounceTime time.Duration
 	Disabled     bool
+
+	m       sync.Map // items of the queue not persisted (yet)
+	flushMu sync.Mutex
+	flusher sync.Once
 }
 
+// queued items are persisted in batches, off the request path.
+const queueFlushInterval = 1 * time.Second
+
 var ErrWorkerQueueItemDelayed = errors.New("worker queue item delayed")
 
+// Queues carrying secrets are persisted only with vault, those secrets can
+// not be protected otherwise. Without vault, those are kept in memory and
+// lost on restart.
+func (q *WorkerQueue[T]) isPersisted() bool {
+	return q.seal == nil || config.Feature.HasVault()
+}
+
 func (q *WorkerQueue[T]) Queue(item T) {
 	if q.Disabled {
 		return
 	}
 	item = *q.transform(&item)
-	q.m.Swap(q.getKey(item), WorkerQueueItem[T]{
+	key := q.getKey(item)
+	groupKey := ""
+	if q.getGroupKey != nil {
+		groupKey = q.getGroupKey(item)
+	}
+	q.m.Store(key, &WorkerQueueItem[T]{
 		v: item,
-		t: time.Now().Add(q.debounceTime),
+		r: &WorkerQueueRecord{
+			Queue:     q.name,
+			Key:       key,
+			GroupKey:  groupKey,
+			ProcessAt: db.Timestamp{Time: time.Now().Add(q.debounceTime).Truncate(time.Second)},
+		},
 	})
+	if q.isPersisted() {
+		q.flusher.Do(func() {
+			go func() {
+				for range time.Tick(queueFlushInterval) {
+					q.flush()
+				}
+			}()
+		})
+	}
 }
 
-func (q *WorkerQueue[T]) delete(item T) {
-	q.m.Delete(q.getKey(item))
-}
+// persists the queued items
+func (q *WorkerQueue[T]) flush() {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
 
-func (q *WorkerQueue[T]) IsEmpty() bool {
-	isEmpty := true
+	items := []*WorkerQueueItem[T]{}
+	records := []WorkerQueueRecord{}
 	q.m.Range(func(k, v any) bool {
-		isEmpty = false
-		return false
+		item, ok := v.(*WorkerQueueItem[T])
+		if !ok {
+			return true
+		}
+		value := item.v
+		if q.seal != nil {
+			if err := q.seal(&value); err != nil {
+				log.Error("WorkerQueue queue failed", "error", err, "queue", q.name, "key", item.r.Key)
+				q.m.CompareAndDelete(item.r.Key, item)
+				return true
+			}
+		}
+		blob, err := json.Marshal(value)
+		if err != nil {
+			log.Error("WorkerQueue queue failed", "error", err, "queue", q.name, "key", item.r.Key)
+			q.m.CompareAndDelete(item.r.Key, item)
+			return true
+		}
+		record := *item.r
+		record.Value = string(blob)
+		items = append(items, item)
+		records = append(records, record)
+		return true
 	})
-	return isEmpty
+	if len(records) == 0 {
+		return
+	}
+
+	// failed items are retried on next flush
+	if err := upsert(records); err != nil {
+		log.Error("WorkerQueue queue failed", "error", err, "queue", q.name, "count", len(records))
+		return
+	}
+	for _, item := range items {
+		// keeps the item if it was queued again meanwhile
+		q.m.CompareAndDelete(item.r.Key, item)
+	}
+}
+
+func (q *WorkerQueue[T]) delete(item *WorkerQueueItem[T]) {
+	if !q.isPersisted() {
+		// deletes the item only if it was not queued again after it was read
+		if v, ok := q.m.Load(item.r.Key); ok && v.(*WorkerQueueItem[T]).r == item.r {
+			q.m.CompareAndDelete(item.r.Key, v)
+		}
+		return
+	}
+	if err := deleteRecord(item.r); err != nil {
+		log.Error("WorkerQueue delete failed", "error", err, "queue", q.name, "key", item.r.Key)
+	}
+}
+
+func (q *WorkerQueue[T]) IsEmpty() bool {
+	if !q.isPersisted() {
+		isEmpty := true
+		q.m.Range(func(k, v any) bool {
+			isEmpty = false
+			return false
+		})
+		return isEmpty
+	}
+	q.flush()
+	return !exists(q.name)
+}
+
+func (q *WorkerQueue[T]) getReadyItems() []WorkerQueueItem[T] {
+	if !q.isPersisted() {
+		now := time.Now()
+		items := []WorkerQueueItem[T]{}
+		q.m.Range(func(k, v any) bool {
+			if item, ok := v.(*WorkerQueueItem[T]); ok && !item.r.ProcessAt.After(now) {
+				items = append(items, *item)
+			}
+			return true
+		})
+		return items
+	}
+	q.flush()
+	records, err := getReady(q.name)
+	if err != nil {
+		log.Error("WorkerQueue failed to get items", "error", err, "queue", q.name)
+		return nil
+	}
+	items := make([]WorkerQueueItem[T], 0, len(records))
+	for i := range records {
+		r := &records[i]
+		var v T
+		err := json.Unmarshal([]byte(r.Value), &v)
+		if err == nil && q.unseal != nil {
+			err = q.unseal(&v)
+		}
+		if err != nil {
+			log.Error("WorkerQueue failed to parse item", "error", err, "queue", q.name, "key", r.Key)
+			if err := deleteRecord(r); err != nil {
+				log.Error("WorkerQueue delete failed", "error", err, "queue", q.name, "key", r.Key)
+			}
+			continue
+		}
+		items = append(items, WorkerQueueItem[T]{v: v, r: r})
+	}
+	return items
+}
+
+// acquires a lock so that multiple instances sharing
+// the same database do not process the same items.
+func (q *WorkerQueue[T]) lock() db.AdvisoryLock {
+	lock := db.NewAdvisoryLock("worker_queue", q.name)
+	if lock == nil {
+		log.Error("WorkerQueue failed to create advisory lock", "queue", q.name)
+		return nil
+	}
+	if !lock.TryAcquire() {
+		log.Debug("WorkerQueue skipped, another instance is processing", "queue", q.name)
+		lock.Release()
+		return nil
+	}
+	return lock
 }
 
 func (q *WorkerQueue[T]) Process(f func(item T) error) {
-	q.m.Range(func(k, v any) bool {
-		_, keyOk := k.(string)
-		val, valOk := v.(WorkerQueueItem[T])
-		if keyOk && valOk && val.t.Before(time.Now()) {
-			if err := f(val.v); err != nil {
-				if err == ErrWorkerQueueItemDelayed {
-					log.Debug("WorkerQueue process delayed", "key", q.getKey(val.v))
-				} else {
-					log.Error("WorkerQueue process failed", "error", err, "key", q.getKey(val.v))
-				}
+	if q.isPersisted() {
+		lock := q.lock()
+		if lock == nil {
+			return
+		}
+		defer lock.Release()
+	}
+
+	items := q.getReadyItems()
+	for i := range items {
+		item := &items[i]
+		if err := f(item.v); err != nil {
+			if err == ErrWorkerQueueItemDelayed {
+				log.Debug("WorkerQueue process delayed", "queue", q.name, "key", item.r.Key)
 			} else {
-				q.delete(val.v)
+				log.Error("WorkerQueue process failed", "error", err, "queue", q.name, "key", item.r.Key)
 			}
+		} else {
+			q.delete(item)
 		}
-		return true
-	})
+	}
 }
 
 func (q *WorkerQueue[T]) ProcessGroup(f func(groupKey string, items []T) error) {
-	byGroupKey := map[string][]T{}
-	q.m.Range(func(k, v any) bool {
-		_, keyOk := k.(string)
-		val, valOk := v.(WorkerQueueItem[T])
-		if keyOk && valOk && val.t.Before(time.Now()) {
-			groupKey := q.getGroupKey(val.v)
-			if _, ok := byGroupKey[groupKey]; !ok {
-				byGroupKey[groupKey] = []T{}
-			}
-			byGroupKey[groupKey] = append(byGroupKey[groupKey], val.v)
+	if q.isPersisted() {
+		lock := q.lock()
+		if lock == nil {
+			return
+		}
+		defer lock.Release()
+	}
+
+	byGroupKey := map[string][]WorkerQueueItem[T]{}
+	for _, item := range q.getReadyItems() {
+		groupKey := item.r.GroupKey
+		byGroupKey[groupKey] = append(byGroupKey[groupKey], item)
+	}
+	for groupKey, gItems := range byGroupKey {
+		items := make([]T, len(gItems))
+		for i := range gItems {
+			items[i] = gItems[i].v
 		}
-		return true
-	})
-	for groupKey, items := range byGroupKey {
 		if err := f(groupKey, items); err != nil {
 			if err == ErrWorkerQueueItemDelayed {
-				log.Debug("WorkerQueue processGroup delayed", "group_key", groupKey)
+				log.Debug("WorkerQueue processGroup delayed", "queue", q.name, "group_key", groupKey)
 			} else {
-				log.Error("WorkerQueue processGroup failed", "error", err, "group_key", groupKey)
+				log.Error("WorkerQueue processGroup failed", "error", err, "queue", q.name, "group_key", groupKey)
 			}
 		} else {
-			for i := range items {
-				q.delete(items[i])
+			for i := range gItems {
+				q.delete(&gItems[i])
 			}
 		}
 	}
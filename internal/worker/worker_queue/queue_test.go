@@ -0,0 +1,53 @@
+package worker_queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerQueueInMemory(t *testing.T) {
+	type item struct {
+		Key   string
+		Token string
+	}
+
+	q := WorkerQueue[item]{
+		name: "test",
+		getKey: func(item item) string {
+			return item.Key
+		},
+		transform: func(item *item) *item {
+			return item
+		},
+		seal: func(item *item) error {
+			return nil
+		},
+		unseal: func(item *item) error {
+			return nil
+		},
+	}
+	assert.False(t, q.isPersisted())
+
+	q.Queue(item{Key: "a", Token: "1"})
+	q.Queue(item{Key: "b", Token: "1"})
+	assert.False(t, q.IsEmpty())
+
+	processed := []string{}
+	q.Process(func(v item) error {
+		processed = append(processed, v.Key+":"+v.Token)
+		if v.Key == "a" {
+			q.Queue(item{Key: "a", Token: "2"})
+		}
+		return nil
+	})
+	assert.ElementsMatch(t, []string{"a:1", "b:1"}, processed)
+
+	processed = []string{}
+	q.Process(func(v item) error {
+		processed = append(processed, v.Key+":"+v.Token)
+		return nil
+	})
+	assert.Equal(t, []string{"a:2"}, processed)
+	assert.True(t, q.IsEmpty())
+}
@@ -12,12 +12,21 @@ type StoreCrawlerQueueItem struct {
 }
 
 var StoreCrawlerQueue = WorkerQueue[StoreCrawlerQueueItem]{
+	name:         "store-crawler",
 	debounceTime: 15 * time.Minute,
 	getKey: func(item StoreCrawlerQueueItem) string {
-		return item.StoreCode + ":" + item.StoreToken
+		return item.StoreCode + ":" + getStoreTokenHash(item.StoreToken)
 	},
 	transform: func(item *StoreCrawlerQueueItem) *StoreCrawlerQueueItem {
 		return item
 	},
+	seal: func(item *StoreCrawlerQueueItem) (err error) {
+		item.StoreToken, err = sealStoreToken(item.StoreToken)
+		return err
+	},
+	unseal: func(item *StoreCrawlerQueueItem) (err error) {
+		item.StoreToken, err = unsealStoreToken(item.StoreToken)
+		return err
+	},
 	Disabled: !config.Feature.HasTorrentInfo(),
 }
@@ -0,0 +1,30 @@
+package worker_queue
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/MunifTanjim/stremthru/internal/vault"
+)
+
+// Store tokens are not persisted in plain text, those are encrypted with
+// vault. Queues carrying store tokens are kept in memory without vault.
+
+func getStoreTokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func sealStoreToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	return vault.Encrypt(token)
+}
+
+func unsealStoreToken(sealed string) (string, error) {
+	if sealed == "" {
+		return "", nil
+	}
+	return vault.Decrypt(sealed)
+}
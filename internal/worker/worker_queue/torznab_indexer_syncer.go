@@ -11,6 +11,7 @@ type TorznabIndexerSyncerQueueItem struct {
 }
 
 var TorznabIndexerSyncerQueue = WorkerQueue[TorznabIndexerSyncerQueueItem]{
+	name:         "torznab-indexer-syncer",
 	debounceTime: 5 * time.Minute,
 	getKey: func(item TorznabIndexerSyncerQueueItem) string {
 		return item.SId
@@ -0,0 +1,18 @@
+package worker_queue
+
+import (
+	"github.com/MunifTanjim/stremthru/internal/config"
+)
+
+type VaultReEncrypterQueueItem struct{}
+
+var VaultReEncrypterQueue = WorkerQueue[VaultReEncrypterQueueItem]{
+	name: "vault-reencrypter",
+	getKey: func(item VaultReEncrypterQueueItem) string {
+		return "*"
+	},
+	transform: func(item *VaultReEncrypterQueueItem) *VaultReEncrypterQueueItem {
+		return item
+	},
+	Disabled: !config.Feature.HasVault(),
+}
@@ -27,6 +27,9 @@ func main() {
 		},
 	})
 
+	stopTunnelHealthCheck := config.StartTunnelHealthCheck()
+	defer stopTunnelHealthCheck()
+
 	posthog.Init()
 	defer posthog.Close()
 
@@ -42,6 +45,7 @@ func main() {
 	endpoint.AddDashEndpoint(mux)
 	endpoint.AddAuthEndpoints(mux)
 	endpoint.AddHealthEndpoints(mux)
+	endpoint.AddMetricsEndpoints(mux)
 	endpoint.AddMetaEndpoints(mux)
 	endpoint.AddStoreEndpoints(mux)
 	endpoint.AddStremioEndpoints(mux)
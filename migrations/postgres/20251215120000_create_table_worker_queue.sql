@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."worker_queue" (
+  "q" text NOT NULL,
+  "k" text NOT NULL,
+  "gk" text NOT NULL DEFAULT '',
+  "v" text NOT NULL,
+  "pat" timestamptz NOT NULL,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("q", "k")
+);
+
+CREATE INDEX "worker_queue_idx_q_pat" ON "public"."worker_queue" ("q", "pat");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "public"."worker_queue_idx_q_pat";
+DROP TABLE IF EXISTS "public"."worker_queue";
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."sync_stremio_trakt_rating" (
+  "stremio_account_id" varchar NOT NULL,
+  "trakt_account_id" varchar NOT NULL,
+  "id" varchar NOT NULL,
+  "type" varchar NOT NULL,
+  "rating" int NOT NULL,
+  "rated_at" timestamptz NOT NULL,
+  "pending" boolean NOT NULL DEFAULT false,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY ("stremio_account_id", "trakt_account_id", "id")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "public"."sync_stremio_trakt_rating";
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."mal_anime" (
+    "id" int NOT NULL,
+    "type" text NOT NULL,
+    "title" text NOT NULL,
+    "description" text NOT NULL,
+    "cover" text NOT NULL,
+    "duration" int NOT NULL,
+    "is_adult" boolean NOT NULL,
+    "start_year" int NOT NULL,
+    "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "public"."mal_anime_genre" (
+    "anime_id" int NOT NULL,
+    "genre" text NOT NULL,
+
+    PRIMARY KEY ("anime_id", "genre")
+);
+
+CREATE TABLE IF NOT EXISTS "public"."mal_list" (
+    "id" text NOT NULL,
+    "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "public"."mal_list_anime" (
+    "list_id" text NOT NULL,
+    "anime_id" int NOT NULL,
+    "score" int NOT NULL,
+
+    PRIMARY KEY ("list_id", "anime_id")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "public"."mal_list_anime";
+DROP TABLE IF EXISTS "public"."mal_list";
+DROP TABLE IF EXISTS "public"."mal_anime_genre";
+DROP TABLE IF EXISTS "public"."mal_anime";
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."store_retention_policy" (
+  "username" text NOT NULL,
+  "store" text NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT false,
+  "dry_run" boolean NOT NULL DEFAULT true,
+  "older_than_days" integer NOT NULL DEFAULT 0,
+  "max_items" integer NOT NULL DEFAULT 0,
+  "remove_failed" boolean NOT NULL DEFAULT false,
+  "only_added" boolean NOT NULL DEFAULT true,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "uat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY ("username", "store")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "public"."store_retention_policy";
+-- +goose StatementEnd
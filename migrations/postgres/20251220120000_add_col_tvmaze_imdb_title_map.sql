@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "public"."imdb_title_map" ADD COLUMN "tvmaze" text NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS "imdb_title_map_idx_tvmaze" ON "imdb_title_map" ("tvmaze");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "imdb_title_map_idx_tvmaze";
+ALTER TABLE "public"."imdb_title_map" DROP COLUMN "tvmaze";
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "public"."content_proxy_connection" (
+  "id" text NOT NULL,
+  "username" text NOT NULL,
+  "ip" text NOT NULL DEFAULT '',
+  "link" text NOT NULL DEFAULT '',
+  "instance" text NOT NULL DEFAULT '',
+  "bytes" bigint NOT NULL DEFAULT 0,
+  "killed" bool NOT NULL DEFAULT false,
+  "cat" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "eat" timestamptz NOT NULL,
+
+  PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "content_proxy_connection_idx_username_eat" ON "public"."content_proxy_connection" ("username", "eat");
+
+DELETE FROM "public"."kv" WHERE "t" = 'cproxyconn';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "public"."content_proxy_connection_idx_username_eat";
+DROP TABLE IF EXISTS "public"."content_proxy_connection";
+-- +goose StatementEnd
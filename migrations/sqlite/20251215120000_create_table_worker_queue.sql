@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `worker_queue` (
+  `q` varchar NOT NULL,
+  `k` varchar NOT NULL,
+  `gk` varchar NOT NULL DEFAULT '',
+  `v` text NOT NULL,
+  `pat` datetime NOT NULL,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+  PRIMARY KEY (`q`, `k`)
+);
+
+CREATE INDEX `worker_queue_idx_q_pat` ON `worker_queue` (`q`, `pat`);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS `worker_queue_idx_q_pat`;
+DROP TABLE IF EXISTS `worker_queue`;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `sync_stremio_trakt_rating` (
+  `stremio_account_id` varchar NOT NULL,
+  `trakt_account_id` varchar NOT NULL,
+  `id` varchar NOT NULL,
+  `type` varchar NOT NULL,
+  `rating` int NOT NULL,
+  `rated_at` datetime NOT NULL,
+  `pending` bool NOT NULL DEFAULT false,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`stremio_account_id`, `trakt_account_id`, `id`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `sync_stremio_trakt_rating`;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `mal_anime` (
+    `id` int NOT NULL,
+    `type` varchar NOT NULL,
+    `title` varchar NOT NULL,
+    `description` varchar NOT NULL,
+    `cover` varchar NOT NULL,
+    `duration` int NOT NULL,
+    `is_adult` bool NOT NULL,
+    `start_year` int NOT NULL,
+    `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `mal_anime_genre` (
+    `anime_id` int NOT NULL,
+    `genre` varchar NOT NULL,
+
+    PRIMARY KEY (`anime_id`, `genre`)
+);
+
+CREATE TABLE IF NOT EXISTS `mal_list` (
+    `id` varchar NOT NULL,
+    `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `mal_list_anime` (
+    `list_id` varchar NOT NULL,
+    `anime_id` int NOT NULL,
+    `score` int NOT NULL,
+
+    PRIMARY KEY (`list_id`, `anime_id`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `mal_list_anime`;
+DROP TABLE IF EXISTS `mal_list`;
+DROP TABLE IF EXISTS `mal_anime_genre`;
+DROP TABLE IF EXISTS `mal_anime`;
+-- +goose StatementEnd
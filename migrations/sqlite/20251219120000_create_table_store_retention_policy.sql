@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `store_retention_policy` (
+  `username` varchar NOT NULL,
+  `store` varchar NOT NULL,
+  `enabled` bool NOT NULL DEFAULT false,
+  `dry_run` bool NOT NULL DEFAULT true,
+  `older_than_days` integer NOT NULL DEFAULT 0,
+  `max_items` integer NOT NULL DEFAULT 0,
+  `remove_failed` bool NOT NULL DEFAULT false,
+  `only_added` bool NOT NULL DEFAULT true,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `uat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`username`, `store`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `store_retention_policy`;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `store_added_magnet` (
+  `store` varchar NOT NULL,
+  `tid` varchar NOT NULL,
+  `hash` varchar NOT NULL,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+
+  PRIMARY KEY (`store`, `tid`, `hash`)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `store_added_magnet`;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `content_proxy_connection` (
+  `id` varchar NOT NULL,
+  `username` varchar NOT NULL,
+  `ip` varchar NOT NULL DEFAULT '',
+  `link` varchar NOT NULL DEFAULT '',
+  `instance` varchar NOT NULL DEFAULT '',
+  `bytes` integer NOT NULL DEFAULT 0,
+  `killed` bool NOT NULL DEFAULT false,
+  `cat` datetime NOT NULL DEFAULT (unixepoch()),
+  `eat` datetime NOT NULL,
+
+  PRIMARY KEY (`id`)
+);
+
+CREATE INDEX IF NOT EXISTS `content_proxy_connection_idx_username_eat` ON `content_proxy_connection` (`username`, `eat`);
+
+DELETE FROM `kv` WHERE `t` = 'cproxyconn';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS `content_proxy_connection_idx_username_eat`;
+DROP TABLE IF EXISTS `content_proxy_connection`;
+-- +goose StatementEnd
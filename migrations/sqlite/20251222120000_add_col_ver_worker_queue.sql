@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `worker_queue` ADD COLUMN `ver` integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `worker_queue` DROP COLUMN `ver`;
+-- +goose StatementEnd
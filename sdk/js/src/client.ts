@@ -120,10 +120,15 @@ class StremThruStore {
         size: number;
         video_hash?: string;
       }>;
+      eta?: number;
       hash: string;
       id: string;
       name: string;
+      peers?: number;
       private?: boolean;
+      progress?: number;
+      seeders?: number;
+      speed?: number;
       status: StoreMagnetStatus;
     }>(`/v0/store/magnets/${magnetId}`, { method: "GET" });
   }
@@ -155,10 +160,15 @@ class StremThruStore {
     return await this.#client.request<{
       items: Array<{
         added_at: string;
+        eta?: number;
         hash: string;
         id: string;
         name: string;
+        peers?: number;
         private?: boolean;
+        progress?: number;
+        seeders?: number;
+        speed?: number;
         status: StoreMagnetStatus;
       }>;
       total_items: number;
@@ -208,10 +208,15 @@ class GetMagnetDataFile(TypedDict):
 class GetMagnetData(TypedDict):
     added_at: str
     files: list[GetMagnetDataFile]
+    eta: Optional[int]
     hash: str
     id: str
     name: str
+    peers: Optional[int]
     private: Optional[bool]
+    progress: Optional[float]
+    seeders: Optional[int]
+    speed: Optional[int]
     status: StoreMagnetStatus
 
 
@@ -226,10 +231,15 @@ class GetUserData(TypedDict):
 
 class ListMagnetsDataItem(TypedDict):
     added_at: str
+    eta: Optional[int]
     hash: str
     id: str
     name: str
+    peers: Optional[int]
     private: Optional[bool]
+    progress: Optional[float]
+    seeders: Optional[int]
+    speed: Optional[int]
     status: StoreMagnetStatus
 
 
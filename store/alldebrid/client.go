@@ -3,9 +3,11 @@ package alldebrid
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
 )
@@ -78,6 +80,7 @@ func (c APIClient) Request(method, path string, params request.Context, v Respon
 		error.Cause = err
 		return nil, error
 	}
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	err = processResponseBody(res, err, v)
 	if err != nil {
@@ -86,7 +89,9 @@ func (c APIClient) Request(method, path string, params request.Context, v Respon
 		if res != nil {
 			err.StatusCode = res.StatusCode
 		}
+		metrics.ObserveStoreRequest(string(store.StoreNameAlldebrid), req.Method, start, res, err)
 		return res, err
 	}
+	metrics.ObserveStoreRequest(string(store.StoreNameAlldebrid), req.Method, start, res, nil)
 	return res, nil
 }
@@ -247,6 +247,13 @@ func (m GetMagnetStatusDataMagnet) GetAddedAt() time.Time {
 	return time.Unix(unixSeconds, 0).UTC()
 }
 
+func (m GetMagnetStatusDataMagnet) GetProgress() float64 {
+	if m.Size <= 0 {
+		return 0
+	}
+	return float64(m.Downloaded) * 100 / float64(m.Size)
+}
+
 type GetMagnetStatusData struct {
 	Magnet GetMagnetStatusDataMagnet `json:"magnets"`
 }
@@ -269,7 +269,12 @@ func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Status:  statusCodeToMagnetStatus(magnet.StatusCode),
 		Files:   []store.MagnetFile{},
 		AddedAt: magnet.GetAddedAt(),
+
+		Progress: magnet.GetProgress(),
+		Speed:    int64(magnet.DownloadSpeed),
+		Seeders:  magnet.Seeders,
 	}
+	data.ETA = store.EstimateMagnetETA(data.Size, data.Progress, data.Speed)
 
 	source := string(c.GetName().Code())
 	for _, f := range magnet.GetFiles() {
@@ -305,7 +310,12 @@ func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListM
 				Size:    magnet.Size,
 				Status:  statusCodeToMagnetStatus(magnet.StatusCode),
 				AddedAt: magnet.GetAddedAt(),
+
+				Progress: magnet.GetProgress(),
+				Speed:    int64(magnet.DownloadSpeed),
+				Seeders:  magnet.Seeders,
 			}
+			item.ETA = store.EstimateMagnetETA(item.Size, item.Progress, item.Speed)
 
 			items = append(items, *item)
 		}
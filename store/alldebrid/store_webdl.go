@@ -0,0 +1,183 @@
+package alldebrid
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func (l UserLink) toWebDL() store.ListWebDLsDataItem {
+	item := store.ListWebDLsDataItem{
+		Id:      l.Link,
+		Name:    l.Filename,
+		Size:    l.GetSize(),
+		Status:  store.MagnetStatusUnknown,
+		Files:   []store.WebDLFile{},
+		AddedAt: l.GetDate(),
+	}
+	if l.LinkDL != "" {
+		item.Status = store.MagnetStatusDownloaded
+		item.Files = append(item.Files, store.WebDLFile{
+			Link: l.LinkDL,
+			Name: l.Filename,
+			Size: l.GetSize(),
+		})
+	}
+	return item
+}
+
+// recent and saved links, without duplicates
+func (c *StoreClient) listUserLinks(ctx Ctx) ([]UserLink, error) {
+	resRecent, errRecent := c.client.GetRecentUserLinks(&GetRecentUserLinksParams{
+		Ctx: ctx,
+	})
+	if errRecent != nil {
+		return nil, errRecent
+	}
+	resSaved, errSaved := c.client.GetSavedUserLinks(&GetSavedUserLinksParams{
+		Ctx: ctx,
+	})
+	if errSaved != nil {
+		return nil, errSaved
+	}
+
+	seenLink := map[string]struct{}{}
+	links := []UserLink{}
+	for _, link := range append(resRecent.Data, resSaved.Data...) {
+		if link.Host == "error" || link.Host == "magnet" {
+			continue
+		}
+		if _, seen := seenLink[link.Link]; seen {
+			continue
+		}
+		seenLink[link.Link] = struct{}{}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+func (c *StoreClient) UnrestrictLink(params *store.UnrestrictLinkParams) (*store.UnrestrictLinkData, error) {
+	res, err := c.client.UnlockLink(&UnlockLinkParams{
+		Ctx:      params.Ctx,
+		Link:     params.Link,
+		Password: params.Password,
+		UserIP:   params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.UnrestrictLinkData{
+		Id:     params.Link,
+		Name:   res.Data.Filename,
+		Size:   int64(res.Data.Filesize),
+		Status: store.MagnetStatusQueued,
+		Files:  []store.WebDLFile{},
+	}
+	if res.Data.Link != "" {
+		data.Status = store.MagnetStatusDownloaded
+		data.Files = append(data.Files, store.WebDLFile{
+			Link: res.Data.Link,
+			Name: res.Data.Filename,
+			Size: int64(res.Data.Filesize),
+		})
+	}
+	return data, nil
+}
+
+func (c *StoreClient) GetWebDL(params *store.GetWebDLParams) (*store.GetWebDLData, error) {
+	links, err := c.listUserLinks(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links {
+		if links[i].Link != params.Id {
+			continue
+		}
+		item := links[i].toWebDL()
+		data := store.GetWebDLData(item)
+		return &data, nil
+	}
+	error := core.NewAPIError("not found")
+	error.StatusCode = http.StatusNotFound
+	error.StoreName = string(store.StoreNameAlldebrid)
+	return nil, error
+}
+
+func (c *StoreClient) ListWebDLs(params *store.ListWebDLsParams) (*store.ListWebDLsData, error) {
+	links, err := c.listUserLinks(params.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	data := &store.ListWebDLsData{
+		Items:      []store.ListWebDLsDataItem{},
+		TotalItems: len(links),
+	}
+	start, end := min(params.Offset, len(links)), min(params.Offset+params.Limit, len(links))
+	for i := range links[start:end] {
+		data.Items = append(data.Items, links[start+i].toWebDL())
+	}
+	return data, nil
+}
+
+// only saved links can be removed, recent links expire on their own
+func (c *StoreClient) RemoveWebDL(params *store.RemoveWebDLParams) (*store.RemoveWebDLData, error) {
+	_, err := c.client.DeleteSavedUserLinks(&DeleteSavedUserLinksParams{
+		Ctx:   params.Ctx,
+		Links: []string{params.Id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.RemoveWebDLData{Id: params.Id}, nil
+}
+
+func (c *StoreClient) GenerateWebDLLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	res, err := c.client.UnlockLink(&UnlockLinkParams{
+		Ctx:    params.Ctx,
+		Link:   params.Link,
+		UserIP: params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.GenerateLinkData{}
+	if res.Data.Link != "" {
+		if !core.HasVideoExtension(res.Data.Filename) {
+			error := core.NewAPIError("no video file found")
+			error.StatusCode = http.StatusUnprocessableEntity
+			error.StoreName = string(store.StoreNameAlldebrid)
+			return nil, error
+		}
+		data.Link = res.Data.Link
+		return data, nil
+	}
+
+	if len(res.Data.Streams) > 0 {
+		var stream *UnlockLinkDataStream
+		for i := range res.Data.Streams {
+			s := &res.Data.Streams[i]
+			if !core.HasVideoExtension("." + s.Ext) {
+				continue
+			}
+			stream = s
+		}
+		if stream == nil {
+			error := core.NewAPIError("no video stream found")
+			error.StatusCode = http.StatusUnprocessableEntity
+			error.StoreName = string(store.StoreNameAlldebrid)
+			return nil, error
+		}
+		sRes, err := c.client.GetStreamingLink(&GetStreamingLinkParams{
+			Ctx:    params.Ctx,
+			Id:     res.Data.Id,
+			Stream: stream.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data.Link = sRes.Data.Link
+	}
+
+	return data, nil
+}
@@ -2,6 +2,7 @@ package alldebrid
 
 import (
 	"encoding/json"
+	"net/url"
 	"time"
 )
 
@@ -79,3 +80,24 @@ func (c APIClient) GetSavedUserLinks(params *GetSavedUserLinksParams) (APIRespon
 	res, err := c.Request("GET", "/v4/user/links", params, response)
 	return newAPIResponse(res, response.Data.Links), err
 }
+
+type DeleteSavedUserLinksData struct {
+	Message string `json:"message"`
+}
+
+type DeleteSavedUserLinksParams struct {
+	Ctx
+	Links []string
+}
+
+func (c APIClient) DeleteSavedUserLinks(params *DeleteSavedUserLinksParams) (APIResponse[DeleteSavedUserLinksData], error) {
+	form := &url.Values{}
+	for _, link := range params.Links {
+		form.Add("links[]", link)
+	}
+	params.Form = form
+
+	response := &Response[DeleteSavedUserLinksData]{}
+	res, err := c.Request("POST", "/v4/user/links/delete", params, response)
+	return newAPIResponse(res, response.Data), err
+}
@@ -3,9 +3,11 @@ package debrider
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
 )
@@ -77,6 +79,7 @@ func (c APIClient) Request(method, path string, params request.Context, v reques
 		error.Cause = err
 		return nil, error
 	}
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	err = request.ProcessResponseBody(res, err, v)
 	if err != nil {
@@ -85,7 +88,9 @@ func (c APIClient) Request(method, path string, params request.Context, v reques
 		if res != nil && res.StatusCode >= http.StatusBadRequest {
 			err.StatusCode = res.StatusCode
 		}
+		metrics.ObserveStoreRequest(string(store.StoreNameDebrider), req.Method, start, res, err)
 		return res, err
 	}
+	metrics.ObserveStoreRequest(string(store.StoreNameDebrider), req.Method, start, res, nil)
 	return res, nil
 }
@@ -326,6 +326,10 @@ func (s *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Status:  getMagnetStatusFromTaskStatus(res.Data.Status),
 		Files:   []store.MagnetFile{},
 		AddedAt: res.Data.GetAddedAt(),
+
+		Progress: res.Data.Progress,
+		Speed:    int64(res.Data.DownloadSpeed),
+		ETA:      res.Data.ETA,
 	}
 	source := string(s.GetName().Code())
 	for i := range res.Data.Files {
@@ -362,6 +366,7 @@ func (s *StoreClient) GetUser(params *store.GetUserParams) (*store.User, error)
 	case "canceled":
 		data.SubscriptionStatus = store.UserSubscriptionStatusExpired
 	}
+	data.HasUsenet = data.SubscriptionStatus != store.UserSubscriptionStatusExpired
 	return data, err
 }
 
@@ -386,6 +391,10 @@ func (s *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListM
 			Size:    task.Size,
 			Status:  getMagnetStatusFromTaskStatus(task.Status),
 			AddedAt: task.GetAddedAt(),
+
+			Progress: task.Progress,
+			Speed:    int64(task.DownloadSpeed),
+			ETA:      task.ETA,
 		}
 		items = append(items, item)
 	}
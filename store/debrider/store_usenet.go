@@ -0,0 +1,160 @@
+package debrider
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func (t *Task) isUsenet() bool {
+	return t.Type == "usenet"
+}
+
+func (t *Task) toNewzFiles(source string) []store.NewzFile {
+	files := make([]store.NewzFile, 0, len(t.Files))
+	for i := range t.Files {
+		f := &t.Files[i]
+		files = append(files, store.NewzFile{
+			Idx:    i,
+			Link:   LockedFileLink("").Create(t.Id, f.Name),
+			Name:   f.GetName(),
+			Path:   f.GetPath(),
+			Size:   f.Size,
+			Source: source,
+		})
+	}
+	return files
+}
+
+func newzNotFoundError() error {
+	err := core.NewAPIError("not found")
+	err.StatusCode = http.StatusNotFound
+	err.StoreName = string(store.StoreNameDebrider)
+	return err
+}
+
+// debrider does not expose usenet cache status
+func (s *StoreClient) CheckNewz(params *store.CheckNewzParams) (*store.CheckNewzData, error) {
+	data := &store.CheckNewzData{
+		Items: make([]store.CheckNewzDataItem, 0, len(params.Hashes)),
+	}
+	for _, hash := range params.Hashes {
+		data.Items = append(data.Items, store.CheckNewzDataItem{
+			Hash:   hash,
+			Status: store.MagnetStatusUnknown,
+		})
+	}
+	return data, nil
+}
+
+func (s *StoreClient) AddNewz(params *store.AddNewzParams) (*store.AddNewzData, error) {
+	if params.File == nil {
+		err := core.NewAPIError("nzb file is required")
+		err.StatusCode = http.StatusBadRequest
+		err.StoreName = string(store.StoreNameDebrider)
+		return nil, err
+	}
+	res, err := s.client.CreateDownloadTask(&CreateDownloadTaskParams{
+		Ctx:  params.Ctx,
+		Type: DownloadTaskTypeNzb,
+		Data: CreateDownloadTaskParamsData{
+			FileContent: params.File,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.AddNewzData{
+		Id:      res.Data.Id,
+		Hash:    res.Data.Hash,
+		Name:    res.Data.Name,
+		Size:    res.Data.Size,
+		Status:  getMagnetStatusFromTaskStatus(res.Data.Status),
+		Files:   res.Data.toNewzFiles(string(s.GetName().Code())),
+		AddedAt: res.Data.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (s *StoreClient) GetNewz(params *store.GetNewzParams) (*store.GetNewzData, error) {
+	res, err := s.client.GetTask(&GetTaskParams{
+		Ctx: params.Ctx,
+		Id:  params.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !res.Data.isUsenet() {
+		return nil, newzNotFoundError()
+	}
+	data := &store.GetNewzData{
+		Id:      res.Data.Id,
+		Hash:    res.Data.Hash,
+		Name:    res.Data.Name,
+		Size:    res.Data.Size,
+		Status:  getMagnetStatusFromTaskStatus(res.Data.Status),
+		Files:   res.Data.toNewzFiles(string(s.GetName().Code())),
+		AddedAt: res.Data.GetAddedAt(),
+
+		Progress: res.Data.Progress,
+		Speed:    int64(res.Data.DownloadSpeed),
+		ETA:      res.Data.ETA,
+	}
+	return data, nil
+}
+
+func (s *StoreClient) ListNewz(params *store.ListNewzParams) (*store.ListNewzData, error) {
+	res, err := s.client.ListTask(&ListTaskParams{
+		Ctx: params.Ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := []store.ListNewzDataItem{}
+	for i := range res.Data {
+		task := &res.Data[i]
+		if !task.isUsenet() {
+			continue
+		}
+		items = append(items, store.ListNewzDataItem{
+			Id:      task.Id,
+			Hash:    task.Hash,
+			Name:    task.Name,
+			Size:    task.Size,
+			Status:  getMagnetStatusFromTaskStatus(task.Status),
+			Files:   task.toNewzFiles(string(s.GetName().Code())),
+			AddedAt: task.GetAddedAt(),
+
+			Progress: task.Progress,
+			Speed:    int64(task.DownloadSpeed),
+			ETA:      task.ETA,
+		})
+	}
+
+	totalItems := len(items)
+	startIdx := min(params.Offset, totalItems)
+	endIdx := min(startIdx+params.Limit, totalItems)
+
+	data := &store.ListNewzData{
+		Items:      items[startIdx:endIdx],
+		TotalItems: totalItems,
+	}
+	return data, nil
+}
+
+func (s *StoreClient) RemoveNewz(params *store.RemoveNewzParams) (*store.RemoveNewzData, error) {
+	_, err := s.client.DeleteTask(&DeleteTaskParams{
+		Ctx: params.Ctx,
+		Id:  params.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.RemoveNewzData{Id: params.Id}, nil
+}
+
+func (s *StoreClient) GenerateNewzLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	return s.GenerateLink(params)
+}
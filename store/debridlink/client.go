@@ -5,9 +5,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
 )
@@ -83,6 +85,7 @@ func (c APIClient) Request(method, path string, params request.Context, v Respon
 		error.Cause = err
 		return nil, error
 	}
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	err = processResponseBody(res, err, v)
 	if err != nil {
@@ -94,7 +97,9 @@ func (c APIClient) Request(method, path string, params request.Context, v Respon
 		if err.StatusCode <= http.StatusBadRequest {
 			err.StatusCode = http.StatusBadRequest
 		}
+		metrics.ObserveStoreRequest(string(store.StoreNameDebridLink), req.Method, start, res, err)
 		return res, err
 	}
+	metrics.ObserveStoreRequest(string(store.StoreNameDebridLink), req.Method, start, res, nil)
 	return res, nil
 }
@@ -208,7 +208,12 @@ func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Status:  store.MagnetStatusUnknown,
 		Files:   []store.MagnetFile{},
 		AddedAt: t.GetAddedAt(),
+
+		Progress: float64(t.DownloadPercent),
+		Speed:    int64(t.DownloadSpeed),
+		Peers:    t.PeersConnected,
 	}
+	data.ETA = store.EstimateMagnetETA(data.Size, data.Progress, data.Speed)
 	if t.DownloadPercent == 100 {
 		data.Status = store.MagnetStatusDownloaded
 	} else if t.DownloadPercent < 100 {
@@ -277,7 +282,12 @@ func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListM
 				Size:    t.TotalSize,
 				Status:  store.MagnetStatusUnknown,
 				AddedAt: t.GetAddedAt(),
+
+				Progress: float64(t.DownloadPercent),
+				Speed:    int64(t.DownloadSpeed),
+				Peers:    t.PeersConnected,
 			}
+			item.ETA = store.EstimateMagnetETA(item.Size, item.Progress, item.Speed)
 			if t.DownloadPercent == 100 {
 				item.Status = store.MagnetStatusDownloaded
 			} else if t.DownloadPercent < 100 {
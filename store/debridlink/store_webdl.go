@@ -0,0 +1,148 @@
+package debridlink
+
+import (
+	"net/http"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func (l DownloaderLink) getWebDLStatus() store.WebDLStatus {
+	if l.Expired || l.DownloadUrl == "" {
+		return store.MagnetStatusUnknown
+	}
+	return store.MagnetStatusDownloaded
+}
+
+func (l DownloaderLink) toWebDLFiles() []store.WebDLFile {
+	if l.DownloadUrl == "" {
+		return []store.WebDLFile{}
+	}
+	return []store.WebDLFile{
+		{
+			Link: l.DownloadUrl,
+			Name: l.Name,
+			Size: l.Size,
+		},
+	}
+}
+
+func (c *StoreClient) UnrestrictLink(params *store.UnrestrictLinkParams) (*store.UnrestrictLinkData, error) {
+	res, err := c.client.AddDownloaderLink(&AddDownloaderLinkParams{
+		Ctx:      params.Ctx,
+		Url:      params.Link,
+		Password: params.Password,
+		IP:       params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	l := &res.Data
+	data := &store.UnrestrictLinkData{
+		Id:      l.Id,
+		Name:    l.Name,
+		Size:    l.Size,
+		Status:  l.getWebDLStatus(),
+		Files:   l.toWebDLFiles(),
+		AddedAt: l.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (c *StoreClient) GetWebDL(params *store.GetWebDLParams) (*store.GetWebDLData, error) {
+	res, err := c.client.ListDownloaderLinks(&ListDownloaderLinksParams{
+		Ctx: params.Ctx,
+		Ids: []string{params.Id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Data.Value) == 0 {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameDebridLink)
+		return nil, error
+	}
+	l := &res.Data.Value[0]
+	data := &store.GetWebDLData{
+		Id:      l.Id,
+		Name:    l.Name,
+		Size:    l.Size,
+		Status:  l.getWebDLStatus(),
+		Files:   l.toWebDLFiles(),
+		AddedAt: l.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (c *StoreClient) ListWebDLs(params *store.ListWebDLsParams) (*store.ListWebDLsData, error) {
+	data := &store.ListWebDLsData{
+		Items:      []store.ListWebDLsDataItem{},
+		TotalItems: 0,
+	}
+	totalPages := 0
+
+	limit := LIST_DOWNLOADER_LINKS_PER_PAGE_MAX
+	page := params.Offset / limit
+	offsetInPage := params.Offset % limit
+	remainingItems := params.Limit
+	hasMore := true
+	for hasMore {
+		res, err := c.client.ListDownloaderLinks(&ListDownloaderLinksParams{
+			Ctx:     params.Ctx,
+			PerPage: limit,
+			Page:    page,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		resItems := res.Data.Value
+		totalPages = res.Data.Pagination.Pages
+		totalResItems := len(resItems)
+		if totalResItems == 0 {
+			break
+		}
+
+		if offsetInPage != 0 {
+			resItems = resItems[min(offsetInPage, totalResItems):]
+			totalResItems = len(resItems)
+			offsetInPage = 0
+		}
+
+		for _, l := range resItems[:min(totalResItems, remainingItems)] {
+			data.Items = append(data.Items, store.ListWebDLsDataItem{
+				Id:      l.Id,
+				Name:    l.Name,
+				Size:    l.Size,
+				Status:  l.getWebDLStatus(),
+				Files:   l.toWebDLFiles(),
+				AddedAt: l.GetAddedAt(),
+			})
+		}
+
+		page++
+		remainingItems -= totalResItems
+		hasMore = page < totalPages && remainingItems > 0
+	}
+
+	data.TotalItems = totalPages * limit
+
+	return data, nil
+}
+
+func (c *StoreClient) RemoveWebDL(params *store.RemoveWebDLParams) (*store.RemoveWebDLData, error) {
+	_, err := c.client.RemoveDownloaderLinks(&RemoveDownloaderLinksParams{
+		Ctx: params.Ctx,
+		Ids: []string{params.Id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.RemoveWebDLData{Id: params.Id}, nil
+}
+
+// file links are already unrestricted
+func (c *StoreClient) GenerateWebDLLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	return c.GenerateLink(params)
+}
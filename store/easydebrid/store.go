@@ -308,6 +308,9 @@ func (s *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Status:  store.MagnetStatusDownloaded,
 		Files:   []store.MagnetFile{},
 		AddedAt: time.Unix(0, 0),
+
+		// only cached magnets are served
+		Progress: 100,
 	}
 	if data.Name == "" {
 		data.Name = magnet.Name
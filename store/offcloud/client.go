@@ -9,6 +9,7 @@ import (
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/cache"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
 )
@@ -77,6 +78,7 @@ func NewAPIClient(conf *APIClientConfig) *APIClient {
 type Ctx = request.Ctx
 
 func (c APIClient) doRequest(req *http.Request, v ResponseEnvelop) (*http.Response, error) {
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	err = processResponseBody(res, err, v)
 	if err != nil {
@@ -85,8 +87,10 @@ func (c APIClient) doRequest(req *http.Request, v ResponseEnvelop) (*http.Respon
 		if res != nil {
 			err.StatusCode = res.StatusCode
 		}
+		metrics.ObserveStoreRequest(string(store.StoreNameOffcloud), req.Method, start, res, err)
 		return res, err
 	}
+	metrics.ObserveStoreRequest(string(store.StoreNameOffcloud), req.Method, start, res, nil)
 	return res, nil
 
 }
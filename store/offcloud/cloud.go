@@ -96,6 +96,21 @@ type GetCloudDownloadStatusDataStatus struct {
 	DownloadingTime  int                 `json:"downloadingTime,omitempty"`  // present when downloading, probably milliseconds
 }
 
+func (s GetCloudDownloadStatusDataStatus) GetProgress() float64 {
+	if s.FileSize <= 0 {
+		return 0
+	}
+	return float64(s.Amount) * 100 / float64(s.FileSize)
+}
+
+func (s GetCloudDownloadStatusDataStatus) GetSpeed() int64 {
+	speed, err := s.DownloadingSpeed.Float64()
+	if err != nil {
+		return 0
+	}
+	return int64(speed)
+}
+
 type GetCloudDownloadStatusData struct {
 	ResponseContainer
 	Status GetCloudDownloadStatusDataStatus `json:"status"`
@@ -248,6 +248,12 @@ func (s *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Status:  getMagnetStatus(magnet.Status),
 		Files:   []store.MagnetFile{},
 		AddedAt: time.Unix(0, 0),
+
+		Progress: magnet.GetProgress(),
+		Speed:    magnet.GetSpeed(),
+	}
+	if data.Status == store.MagnetStatusDownloading {
+		data.ETA = store.EstimateMagnetETA(data.Size, data.Progress, data.Speed)
 	}
 	if data.Status == store.MagnetStatusDownloaded {
 		files, name, err := s.getMagnetFiles(params.Ctx, data.Id, res.Data.Status.Server)
@@ -4,9 +4,11 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
 )
@@ -115,6 +117,7 @@ func (c APIClient) doRequest(params request.Context, req *http.Request, v Respon
 		ctx.PreparePikpakHeader(&req.Header)
 	}
 
+	start := time.Now()
 	res, err := c.HTTPClient.Do(req)
 	err = processResponseBody(res, err, v)
 	if err != nil {
@@ -123,8 +126,10 @@ func (c APIClient) doRequest(params request.Context, req *http.Request, v Respon
 		if res != nil {
 			err.StatusCode = res.StatusCode
 		}
+		metrics.ObserveStoreRequest(string(store.StoreNamePikPak), req.Method, start, res, err)
 		return res, err
 	}
+	metrics.ObserveStoreRequest(string(store.StoreNamePikPak), req.Method, start, res, nil)
 	return res, nil
 }
 
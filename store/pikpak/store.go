@@ -29,9 +29,10 @@ type StoreClientConfig struct {
 }
 
 type StoreClient struct {
-	Name             store.StoreName
-	client           *APIClient
-	listMagnetsCache cache.Cache[[]store.ListMagnetsDataItem]
+	Name              store.StoreName
+	client            *APIClient
+	listMagnetsCache  cache.Cache[[]store.ListMagnetsDataItem]
+	runningTasksCache cache.Cache[[]Task]
 }
 
 func NewStoreClient(config *StoreClientConfig) *StoreClient {
@@ -49,6 +50,15 @@ func NewStoreClient(config *StoreClientConfig) *StoreClient {
 		})
 	}()
 
+	// shared by the magnets being downloaded, instead of listing the tasks
+	// for each of those.
+	c.runningTasksCache = func() cache.Cache[[]Task] {
+		return cache.NewCache[[]Task](&cache.CacheConfig{
+			Name:     "store:pikpak:runningTasks",
+			Lifetime: 10 * time.Second,
+		})
+	}()
+
 	return c
 }
 
@@ -84,6 +94,31 @@ func (s *StoreClient) getRecentTask(ctx Ctx, taskId string) (*Task, error) {
 	return nil, error
 }
 
+func (s *StoreClient) getRunningTaskByFileId(ctx Ctx, fileId string) (*Task, error) {
+	tasks := []Task{}
+	if !s.runningTasksCache.Get(s.getCacheKey(ctx, ""), &tasks) {
+		res, err := s.client.ListTasks(&ListTasksParams{
+			Ctx:   ctx,
+			Limit: 200,
+			Filters: map[string]map[string]any{
+				"phase": {"eq": FilePhaseRunning},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = res.Data.Tasks
+		s.runningTasksCache.Add(s.getCacheKey(ctx, ""), tasks)
+	}
+	for i := range tasks {
+		t := &tasks[i]
+		if t.FileId == fileId {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
 func (s *StoreClient) waitForTaskComplete(ctx Ctx, taskId string, maxRetry int, retryInterval time.Duration) (*Task, error) {
 	t, err := s.getRecentTask(ctx, taskId)
 	if err != nil {
@@ -382,6 +417,10 @@ func (s *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 				Source: string(s.GetName().Code()),
 			})
 		}
+	} else if task, err := s.getRunningTaskByFileId(ctx, data.Id); err != nil {
+		log.Warn("failed to get running task", "error", err, "file_id", data.Id)
+	} else if task != nil {
+		data.Progress = float64(task.Progress)
 	}
 	return data, nil
 }
@@ -87,3 +87,25 @@ func (c APIClient) GetItem(params *GetItemParams) (APIResponse[GetItemData], err
 	res, err := c.Request("GET", "/item/details", params, response)
 	return newAPIResponse(res, response.GetItemData), err
 }
+
+type DeleteItemData struct{}
+
+type deleteItemData struct {
+	ResponseContainer
+	DeleteItemData
+}
+
+type DeleteItemParams struct {
+	Ctx
+	Id string
+}
+
+func (c APIClient) DeleteItem(params *DeleteItemParams) (APIResponse[DeleteItemData], error) {
+	form := &url.Values{}
+	form.Add("id", params.Id)
+	params.Form = form
+
+	response := &deleteItemData{}
+	res, err := c.Request("POST", "/item/delete", params, response)
+	return newAPIResponse(res, response.DeleteItemData), err
+}
@@ -698,6 +698,8 @@ func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Size:    size,
 		Status:  getMagnetStatsForTransfer(transfer),
 		AddedAt: transfer.GetAddedAt(),
+
+		Progress: transfer.GetProgress(),
 	}
 	if transfer.Status == TransferStatusFinished {
 		files, err := listFolderFlat(c, params.APIKey, transfer.FolderId, nil, &store.MagnetFile{
@@ -783,6 +785,8 @@ func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListM
 				Size:    -1,
 				Status:  getMagnetStatsForTransfer(&t),
 				AddedAt: t.GetAddedAt(),
+
+				Progress: t.GetProgress(),
 			}
 
 			items = append(items, *item)
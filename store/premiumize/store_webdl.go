@@ -0,0 +1,202 @@
+package premiumize
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+// Web downloads are the transfers for hoster links, identified by the
+// transfer id. Like magnets, file links are download links.
+
+func (t ListTransfersDataItem) isWebDL() bool {
+	return t.Src != "" && !strings.HasPrefix(t.Src, "magnet:")
+}
+
+func webDLNotFoundError() error {
+	err := core.NewAPIError("not found")
+	err.StatusCode = http.StatusNotFound
+	err.StoreName = string(store.StoreNamePremiumize)
+	return err
+}
+
+func (c *StoreClient) getWebDLTransfer(apiKey string, id string) (*ListTransfersDataItem, error) {
+	transfer, err := getTransferById(c, apiKey, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil || !transfer.isWebDL() {
+		return nil, webDLNotFoundError()
+	}
+	return transfer, nil
+}
+
+func (c *StoreClient) getWebDLFiles(params store.Ctx, transfer *ListTransfersDataItem) ([]store.WebDLFile, error) {
+	files := []store.WebDLFile{}
+	if transfer.Status != TransferStatusFinished {
+		return files, nil
+	}
+
+	if transfer.FileId != "" {
+		res, err := c.client.GetItem(&GetItemParams{
+			Ctx: params,
+			Id:  transfer.FileId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		item := &res.Data
+		file := store.WebDLFile{
+			Link: item.Link,
+			Name: item.Name,
+			Path: "/" + item.Name,
+			Size: item.Size,
+		}
+		if item.StreamLink != "" {
+			file.Link = item.StreamLink
+		}
+		return append(files, file), nil
+	}
+
+	if transfer.FolderId != "" {
+		mFiles, err := listFolderFlat(c, params.GetAPIKey(c.client.apiKey), transfer.FolderId, nil, &store.MagnetFile{
+			Path: "/",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range mFiles {
+			f := &mFiles[i]
+			files = append(files, store.WebDLFile{
+				Idx:  f.Idx,
+				Link: f.Link,
+				Name: f.Name,
+				Path: f.Path,
+				Size: f.Size,
+			})
+		}
+	}
+
+	return files, nil
+}
+
+func getWebDLSize(files []store.WebDLFile) int64 {
+	size := int64(0)
+	for i := range files {
+		size += files[i].Size
+	}
+	return size
+}
+
+func (c *StoreClient) UnrestrictLink(params *store.UnrestrictLinkParams) (*store.UnrestrictLinkData, error) {
+	res, err := c.client.CreateTransfer(&CreateTransferParams{
+		Ctx: params.Ctx,
+		Src: params.Link,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.UnrestrictLinkData{
+		Id:      res.Data.Id,
+		Name:    res.Data.Name,
+		Status:  store.MagnetStatusQueued,
+		Files:   []store.WebDLFile{},
+		AddedAt: ListTransfersDataItem{}.GetAddedAt(),
+	}
+	transfer, err := getTransferById(c, params.GetAPIKey(c.client.apiKey), data.Id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return data, nil
+	}
+	data.Status = getMagnetStatsForTransfer(transfer)
+	data.Files, err = c.getWebDLFiles(params.Ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	data.Size = getWebDLSize(data.Files)
+	return data, nil
+}
+
+func (c *StoreClient) GetWebDL(params *store.GetWebDLParams) (*store.GetWebDLData, error) {
+	transfer, err := c.getWebDLTransfer(params.GetAPIKey(c.client.apiKey), params.Id)
+	if err != nil {
+		return nil, err
+	}
+	data := &store.GetWebDLData{
+		Id:      transfer.Id,
+		Name:    transfer.Name,
+		Status:  getMagnetStatsForTransfer(transfer),
+		AddedAt: transfer.GetAddedAt(),
+	}
+	data.Files, err = c.getWebDLFiles(params.Ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	data.Size = getWebDLSize(data.Files)
+	return data, nil
+}
+
+func (c *StoreClient) ListWebDLs(params *store.ListWebDLsParams) (*store.ListWebDLsData, error) {
+	res, err := c.client.ListTransfers(&ListTransfersParams{
+		Ctx: params.Ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := []store.ListWebDLsDataItem{}
+	for i := range res.Data.Transfers {
+		t := &res.Data.Transfers[i]
+		if !t.isWebDL() {
+			continue
+		}
+		items = append(items, store.ListWebDLsDataItem{
+			Id:      t.Id,
+			Name:    t.Name,
+			Size:    -1,
+			Status:  getMagnetStatsForTransfer(t),
+			Files:   []store.WebDLFile{},
+			AddedAt: t.GetAddedAt(),
+		})
+	}
+	start, end := min(params.Offset, len(items)), min(params.Offset+params.Limit, len(items))
+	data := &store.ListWebDLsData{
+		Items:      items[start:end],
+		TotalItems: len(items),
+	}
+	return data, nil
+}
+
+// removes the transfer, along with the downloaded file or folder
+func (c *StoreClient) RemoveWebDL(params *store.RemoveWebDLParams) (*store.RemoveWebDLData, error) {
+	transfer, err := c.getWebDLTransfer(params.GetAPIKey(c.client.apiKey), params.Id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FileId != "" {
+		if _, err := c.client.DeleteItem(&DeleteItemParams{
+			Ctx: params.Ctx,
+			Id:  transfer.FileId,
+		}); err != nil {
+			return nil, err
+		}
+	} else if transfer.FolderId != "" {
+		if _, err := c.client.DeleteFolder(&DeleteFolderParams{
+			Ctx: params.Ctx,
+			Id:  transfer.FolderId,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.deleteTransferById(params.GetAPIKey(c.client.apiKey), params.Id); err != nil {
+		return nil, err
+	}
+	return &store.RemoveWebDLData{Id: params.Id}, nil
+}
+
+func (c *StoreClient) GenerateWebDLLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	return c.GenerateLink(params)
+}
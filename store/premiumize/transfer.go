@@ -50,6 +50,11 @@ func (t ListTransfersDataItem) GetAddedAt() time.Time {
 	return time.Unix(0, 0).UTC()
 }
 
+// progress is reported as a fraction, converts it to 0 to 100
+func (t ListTransfersDataItem) GetProgress() float64 {
+	return float64(t.Progress) * 100
+}
+
 type ListTransfersData struct {
 	Transfers []ListTransfersDataItem `json:"transfers"`
 }
@@ -69,3 +69,18 @@ func (c APIClient) ListDownloads(params *ListDownloadsParams) (APIResponse[ListD
 	res, err := c.Request("GET", "/rest/1.0/downloads", params, response)
 	return newAPIResponse(res, response.data), err
 }
+
+type DeleteDownloadData struct {
+	*ResponseError
+}
+
+type DeleteDownloadParams struct {
+	Ctx
+	Id string
+}
+
+func (c APIClient) DeleteDownload(params *DeleteDownloadParams) (APIResponse[DeleteDownloadData], error) {
+	response := &DeleteDownloadData{}
+	res, err := c.Request("DELETE", "/rest/1.0/downloads/delete/"+params.Id, params, response)
+	return newAPIResponse(res, *response), err
+}
@@ -412,8 +412,10 @@ func (c *StoreClient) setCachedGetMagnet(params request.Context, id string, v *s
 }
 
 func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnetData, error) {
-	if v := c.getCachedGetMagnet(params, params.Id); v != nil {
-		return v, nil
+	if !params.BypassCache {
+		if v := c.getCachedGetMagnet(params, params.Id); v != nil {
+			return v, nil
+		}
 	}
 	res, err := c.client.GetTorrentInfo(&GetTorrentInfoParams{
 		Ctx: params.Ctx,
@@ -430,7 +432,12 @@ func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Status:  torrentStatusToMagnetStatus(res.Data.Status),
 		Files:   []store.MagnetFile{},
 		AddedAt: res.Data.GetAddedAt(),
+
+		Progress: float64(res.Data.Progress),
+		Speed:    res.Data.Speed,
+		Seeders:  res.Data.Seeders,
 	}
+	data.ETA = store.EstimateMagnetETA(res.Data.Bytes, data.Progress, data.Speed)
 	totalLinks := len(res.Data.Links)
 	if data.Status == store.MagnetStatusDownloaded {
 		source := string(c.GetName().Code())
@@ -484,7 +491,12 @@ func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListM
 			Size:    t.Bytes,
 			Status:  torrentStatusToMagnetStatus(t.Status),
 			AddedAt: t.GetAddedAt(),
+
+			Progress: float64(t.Progress),
+			Speed:    t.Speed,
+			Seeders:  t.Seeders,
 		}
+		item.ETA = store.EstimateMagnetETA(t.Bytes, item.Progress, item.Speed)
 		data.Items = append(data.Items, item)
 		c.addIdHashMapCache(params, item.Id, item.Hash)
 	}
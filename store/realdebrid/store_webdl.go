@@ -0,0 +1,130 @@
+package realdebrid
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func (dl ListDownloadsDataItem) toWebDLFiles() []store.WebDLFile {
+	return []store.WebDLFile{
+		{
+			Idx:  0,
+			Link: dl.Link,
+			Name: dl.Filename,
+			Size: dl.Filesize,
+		},
+	}
+}
+
+func (c *StoreClient) UnrestrictLink(params *store.UnrestrictLinkParams) (*store.UnrestrictLinkData, error) {
+	res, err := c.client.UnrestrictLink(&UnrestrictLinkParams{
+		Ctx:      params.Ctx,
+		Link:     params.Link,
+		Password: params.Password,
+		IP:       params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.UnrestrictLinkData{
+		Id:     res.Data.Id,
+		Name:   res.Data.Filename,
+		Size:   int64(res.Data.Filesize),
+		Status: store.MagnetStatusDownloaded,
+		Files: []store.WebDLFile{
+			{
+				Idx:  0,
+				Link: res.Data.Link,
+				Name: res.Data.Filename,
+				Size: int64(res.Data.Filesize),
+			},
+		},
+	}
+	return data, nil
+}
+
+// realdebrid does not have an endpoint for a single download,
+// so it is looked up from the most recent downloads.
+func (c *StoreClient) GetWebDL(params *store.GetWebDLParams) (*store.GetWebDLData, error) {
+	res, err := c.client.ListDownloads(&ListDownloadsParams{
+		Ctx:   params.Ctx,
+		Limit: 5000,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range res.Data {
+		dl := &res.Data[i]
+		if dl.Id != params.Id {
+			continue
+		}
+		data := &store.GetWebDLData{
+			Id:      dl.Id,
+			Name:    dl.Filename,
+			Size:    dl.Filesize,
+			Status:  store.MagnetStatusDownloaded,
+			Files:   dl.toWebDLFiles(),
+			AddedAt: dl.Generated.UTC(),
+		}
+		return data, nil
+	}
+	error := core.NewAPIError("not found")
+	error.StatusCode = http.StatusNotFound
+	error.StoreName = string(store.StoreNameRealDebrid)
+	return nil, error
+}
+
+func (c *StoreClient) ListWebDLs(params *store.ListWebDLsParams) (*store.ListWebDLsData, error) {
+	res, err := c.client.ListDownloads(&ListDownloadsParams{
+		Ctx:    params.Ctx,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sTotal := res.Header.Get("X-Total-Count")
+	if sTotal == "" {
+		sTotal = "0"
+	}
+	total, err := strconv.Atoi(sTotal)
+	if err != nil {
+		return nil, err
+	}
+	data := &store.ListWebDLsData{
+		Items:      []store.ListWebDLsDataItem{},
+		TotalItems: total,
+	}
+	for i := range res.Data {
+		dl := &res.Data[i]
+		data.Items = append(data.Items, store.ListWebDLsDataItem{
+			Id:      dl.Id,
+			Name:    dl.Filename,
+			Size:    dl.Filesize,
+			Status:  store.MagnetStatusDownloaded,
+			Files:   dl.toWebDLFiles(),
+			AddedAt: dl.Generated.UTC(),
+		})
+	}
+	return data, nil
+}
+
+func (c *StoreClient) RemoveWebDL(params *store.RemoveWebDLParams) (*store.RemoveWebDLData, error) {
+	_, err := c.client.DeleteDownload(&DeleteDownloadParams{
+		Ctx: params.Ctx,
+		Id:  params.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.RemoveWebDLData{Id: params.Id}, nil
+}
+
+// file links are the original hoster links, unrestricted again to get a fresh download link
+func (c *StoreClient) GenerateWebDLLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	return c.GenerateLink(params)
+}
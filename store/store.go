@@ -219,12 +219,19 @@ type GetMagnetData struct {
 	Files   []MagnetFile `json:"files"`
 	Private bool         `json:"private,omitempty"`
 	AddedAt time.Time    `json:"added_at"`
+
+	Progress float64 `json:"progress,omitempty"` // 0 to 100
+	Speed    int64   `json:"speed,omitempty"`    // bytes per second
+	Seeders  int     `json:"seeders,omitempty"`
+	Peers    int     `json:"peers,omitempty"`
+	ETA      int64   `json:"eta,omitempty"` // seconds
 }
 
 type GetMagnetParams struct {
 	Ctx
-	Id       string
-	ClientIP string
+	Id          string
+	ClientIP    string
+	BypassCache bool
 }
 
 type ListMagnetsDataItem struct {
@@ -235,6 +242,12 @@ type ListMagnetsDataItem struct {
 	Status  MagnetStatus `json:"status"`
 	Private bool         `json:"private,omitempty"`
 	AddedAt time.Time    `json:"added_at"`
+
+	Progress float64 `json:"progress,omitempty"` // 0 to 100
+	Speed    int64   `json:"speed,omitempty"`    // bytes per second
+	Seeders  int     `json:"seeders,omitempty"`
+	Peers    int     `json:"peers,omitempty"`
+	ETA      int64   `json:"eta,omitempty"` // seconds
 }
 
 type ListMagnetsData struct {
@@ -278,3 +291,12 @@ type Store interface {
 	RemoveMagnet(params *RemoveMagnetParams) (*RemoveMagnetData, error)
 	GenerateLink(params *GenerateLinkParams) (*GenerateLinkData, error)
 }
+
+// estimates remaining seconds from progress (0 to 100) and speed (bytes per second)
+func EstimateMagnetETA(size int64, progress float64, speed int64) int64 {
+	if size <= 0 || speed <= 0 || progress >= 100 {
+		return 0
+	}
+	remaining := float64(size) * (100 - progress) / 100
+	return int64(remaining / float64(speed))
+}
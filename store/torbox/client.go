@@ -3,9 +3,11 @@ package torbox
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/MunifTanjim/stremthru/core"
 	"github.com/MunifTanjim/stremthru/internal/config"
+	"github.com/MunifTanjim/stremthru/internal/metrics"
 	"github.com/MunifTanjim/stremthru/internal/request"
 	"github.com/MunifTanjim/stremthru/store"
 )
@@ -76,6 +78,7 @@ func (c APIClient) Request(method, path string, params request.Context, v Respon
 		error.Cause = err
 		return nil, error
 	}
+	start := time.Now()
 	res, err := params.DoRequest(c.HTTPClient, req)
 	err = request.ProcessResponseBody(res, err, v)
 	if err != nil {
@@ -88,7 +91,9 @@ func (c APIClient) Request(method, path string, params request.Context, v Respon
 			err.StatusCode = http.StatusBadRequest
 		}
 		err.Pack(req)
+		metrics.ObserveStoreRequest(string(store.StoreNameTorBox), req.Method, start, res, err)
 		return res, err
 	}
+	metrics.ObserveStoreRequest(string(store.StoreNameTorBox), req.Method, start, res, nil)
 	return res, nil
 }
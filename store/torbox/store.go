@@ -404,8 +404,10 @@ func (c *StoreClient) setCachedGetMagnet(params *store.GetMagnetParams, v *store
 }
 
 func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnetData, error) {
-	if v := c.getCachedGetMagnet(params); v != nil {
-		return v, nil
+	if !params.BypassCache {
+		if v := c.getCachedGetMagnet(params); v != nil {
+			return v, nil
+		}
 	}
 	id, err := strconv.Atoi(params.Id)
 	if err != nil {
@@ -434,6 +436,12 @@ func (c *StoreClient) GetMagnet(params *store.GetMagnetParams) (*store.GetMagnet
 		Files:   []store.MagnetFile{},
 		Private: res.Data.Private,
 		AddedAt: res.Data.GetAddedAt(),
+
+		Progress: res.Data.GetProgress(),
+		Speed:    int64(res.Data.DownloadSpeed),
+		Seeders:  res.Data.Seeds,
+		Peers:    res.Data.Peers,
+		ETA:      int64(res.Data.ETA),
 	}
 	if res.Data.DownloadFinished && res.Data.DownloadPresent {
 		data.Status = store.MagnetStatusDownloaded
@@ -483,6 +491,12 @@ func (c *StoreClient) ListMagnets(params *store.ListMagnetsParams) (*store.ListM
 			Status:  store.MagnetStatusUnknown,
 			Private: t.Private,
 			AddedAt: t.GetAddedAt(),
+
+			Progress: t.GetProgress(),
+			Speed:    int64(t.DownloadSpeed),
+			Seeders:  t.Seeds,
+			Peers:    t.Peers,
+			ETA:      int64(t.ETA),
 		}
 		if t.DownloadFinished && t.DownloadPresent {
 			item.Status = store.MagnetStatusDownloaded
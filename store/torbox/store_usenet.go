@@ -0,0 +1,231 @@
+package torbox
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+var garbageNewzNameRegex = regexp.MustCompile(`(?i)^\[[a-z0-9]+\]\s*-\s*[a-z0-9]+$`)
+
+func (und UsenetDownload) getNewzStatus() store.NewzStatus {
+	if und.DownloadFinished && und.DownloadPresent {
+		return store.MagnetStatusDownloaded
+	}
+	if und.DownloadState == TorrentDownloadStateDownloading || und.Progress > 0 {
+		return store.MagnetStatusDownloading
+	}
+	return store.MagnetStatusQueued
+}
+
+// name is replaced by the largest file name, if torbox returns a garbage name
+func (und UsenetDownload) toNewzFiles(source string) (name string, files []store.NewzFile) {
+	name = und.Name
+	hasGarbageName := garbageNewzNameRegex.MatchString(name)
+	maxFileSize := int64(0)
+	files = make([]store.NewzFile, 0, len(und.Files))
+	for i := range und.Files {
+		f := &und.Files[i]
+		file := store.NewzFile{
+			Idx:    f.Id,
+			Link:   LockedFileLink("").Create(und.Id, f.Id),
+			Name:   f.ShortName,
+			Path:   "/" + f.Name,
+			Size:   f.Size,
+			Source: source,
+		}
+		if hasGarbageName && file.Size > maxFileSize {
+			name = file.Name
+			maxFileSize = file.Size
+		}
+		files = append(files, file)
+	}
+	return name, files
+}
+
+func (c *StoreClient) CheckNewz(params *store.CheckNewzParams) (*store.CheckNewzData, error) {
+	res, err := c.client.CheckUsenetCached(&CheckUsenetCachedParams{
+		Ctx:    params.Ctx,
+		Hashes: params.Hashes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cachedByHash := make(map[string]*CheckUsenetCachedDataItem, len(res.Data))
+	for i := range res.Data {
+		item := &res.Data[i]
+		cachedByHash[strings.ToLower(item.Hash)] = item
+	}
+	data := &store.CheckNewzData{
+		Items: make([]store.CheckNewzDataItem, 0, len(params.Hashes)),
+	}
+	for _, hash := range params.Hashes {
+		item := store.CheckNewzDataItem{
+			Hash:   hash,
+			Status: store.MagnetStatusUnknown,
+		}
+		if cached, ok := cachedByHash[strings.ToLower(hash)]; ok {
+			item.Name = cached.Name
+			item.Size = cached.Size
+			item.Status = store.MagnetStatusCached
+		}
+		data.Items = append(data.Items, item)
+	}
+	return data, nil
+}
+
+func (c *StoreClient) AddNewz(params *store.AddNewzParams) (*store.AddNewzData, error) {
+	res, err := c.client.CreateUsenetDownload(&CreateUsenetDownloadParams{
+		Ctx:      params.Ctx,
+		File:     params.File,
+		Link:     params.Link,
+		Name:     params.Name,
+		Password: params.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.AddNewzData{
+		Id:     strconv.Itoa(res.Data.UsenetDownloadId),
+		Hash:   res.Data.Hash,
+		Status: store.MagnetStatusQueued,
+		Files:  []store.NewzFile{},
+	}
+	und, err := c.client.GetUsenetDownload(&GetUsenetDownloadParams{
+		Ctx:         params.Ctx,
+		Id:          res.Data.UsenetDownloadId,
+		BypassCache: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data.Name, data.Files = und.Data.toNewzFiles(string(c.GetName().Code()))
+	data.Size = und.Data.Size
+	data.Status = und.Data.getNewzStatus()
+	data.AddedAt = und.Data.GetAddedAt()
+	return data, nil
+}
+
+func (c *StoreClient) GetNewz(params *store.GetNewzParams) (*store.GetNewzData, error) {
+	id, err := strconv.Atoi(params.Id)
+	if err != nil {
+		error := core.NewAPIError("invalid id")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+	res, err := c.client.GetUsenetDownload(&GetUsenetDownloadParams{
+		Ctx:         params.Ctx,
+		Id:          id,
+		BypassCache: params.BypassCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Data.Id == 0 {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameTorBox)
+		return nil, error
+	}
+	und := &res.Data
+	data := &store.GetNewzData{
+		Id:      params.Id,
+		Hash:    und.Hash,
+		Size:    und.Size,
+		Status:  und.getNewzStatus(),
+		AddedAt: und.GetAddedAt(),
+
+		Progress: float64(und.Progress) * 100,
+		Speed:    int64(und.DownloadSpeed),
+		ETA:      int64(und.ETA),
+	}
+	data.Name, data.Files = und.toNewzFiles(string(c.GetName().Code()))
+	return data, nil
+}
+
+func (c *StoreClient) ListNewz(params *store.ListNewzParams) (*store.ListNewzData, error) {
+	res, err := c.client.ListUsenetDownload(&ListUsenetDownloadParams{
+		Ctx:         params.Ctx,
+		BypassCache: true,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.ListNewzData{
+		Items:      []store.ListNewzDataItem{},
+		TotalItems: 0,
+	}
+	for i := range res.Data {
+		und := &res.Data[i]
+		item := store.ListNewzDataItem{
+			Id:      strconv.Itoa(und.Id),
+			Hash:    und.Hash,
+			Size:    und.Size,
+			Status:  und.getNewzStatus(),
+			AddedAt: und.GetAddedAt(),
+
+			Progress: float64(und.Progress) * 100,
+			Speed:    int64(und.DownloadSpeed),
+			ETA:      int64(und.ETA),
+		}
+		item.Name, item.Files = und.toNewzFiles(string(c.GetName().Code()))
+		data.Items = append(data.Items, item)
+	}
+	count := len(data.Items)
+	// torbox returns 1 extra item
+	if count > params.Limit {
+		data.Items = data.Items[0:params.Limit]
+		count = params.Limit
+	}
+	data.TotalItems = params.Offset + count
+	if count == params.Limit {
+		data.TotalItems += 1
+	}
+	return data, nil
+}
+
+func (c *StoreClient) RemoveNewz(params *store.RemoveNewzParams) (*store.RemoveNewzData, error) {
+	id, err := strconv.Atoi(params.Id)
+	if err != nil {
+		error := core.NewAPIError("invalid id")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+	_, err = c.client.ControlUsenetDownload(&ControlUsenetDownloadParams{
+		Ctx:       params.Ctx,
+		UsenetId:  id,
+		Operation: ControlUsenetDownloadOperationDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.RemoveNewzData{Id: params.Id}, nil
+}
+
+func (c *StoreClient) GenerateNewzLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	usenetId, fileId, err := LockedFileLink(params.Link).Parse()
+	if err != nil {
+		error := core.NewAPIError("invalid link")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+	res, err := c.client.RequestUsenetDownloadLink(&RequestUsenetDownloadLinkParams{
+		Ctx:      params.Ctx,
+		UsenetId: usenetId,
+		FileId:   fileId,
+		UserIP:   params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.GenerateLinkData{Link: res.Data.Link}, nil
+}
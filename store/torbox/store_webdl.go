@@ -0,0 +1,181 @@
+package torbox
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/MunifTanjim/stremthru/core"
+	"github.com/MunifTanjim/stremthru/store"
+)
+
+func (wdl WebDLDownload) getWebDLStatus() store.WebDLStatus {
+	if wdl.DownloadFinished && wdl.DownloadPresent {
+		return store.MagnetStatusDownloaded
+	}
+	if wdl.DownloadState == TorrentDownloadStateDownloading {
+		return store.MagnetStatusDownloading
+	}
+	return store.MagnetStatusUnknown
+}
+
+func (wdl WebDLDownload) toWebDLFiles() []store.WebDLFile {
+	files := make([]store.WebDLFile, 0, len(wdl.Files))
+	for i := range wdl.Files {
+		f := &wdl.Files[i]
+		files = append(files, store.WebDLFile{
+			Idx:  f.Id,
+			Link: LockedFileLink("").Create(wdl.Id, f.Id),
+			Name: f.ShortName,
+			Path: "/" + f.Name,
+			Size: f.Size,
+		})
+	}
+	return files
+}
+
+func parseWebDLId(id string) (int, error) {
+	webdlId, err := strconv.Atoi(id)
+	if err != nil {
+		error := core.NewAPIError("invalid id")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return 0, error
+	}
+	return webdlId, nil
+}
+
+func (c *StoreClient) UnrestrictLink(params *store.UnrestrictLinkParams) (*store.UnrestrictLinkData, error) {
+	res, err := c.client.CreateWebDLDownload(&CreateWebDLDownloadParams{
+		Ctx:      params.Ctx,
+		Link:     params.Link,
+		Password: params.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	wdl, err := c.client.GetWebDLDownload(&GetWebDLDownloadParams{
+		Ctx:         params.Ctx,
+		Id:          res.Data.UsenetDownloadId,
+		BypassCache: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.UnrestrictLinkData{
+		Id:      strconv.Itoa(res.Data.UsenetDownloadId),
+		Hash:    res.Data.Hash,
+		Name:    wdl.Data.Name,
+		Size:    wdl.Data.Size,
+		Status:  wdl.Data.getWebDLStatus(),
+		Files:   wdl.Data.toWebDLFiles(),
+		AddedAt: wdl.Data.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (c *StoreClient) GetWebDL(params *store.GetWebDLParams) (*store.GetWebDLData, error) {
+	id, err := parseWebDLId(params.Id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.GetWebDLDownload(&GetWebDLDownloadParams{
+		Ctx:         params.Ctx,
+		Id:          id,
+		BypassCache: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Data.Id == 0 {
+		error := core.NewAPIError("not found")
+		error.StatusCode = http.StatusNotFound
+		error.StoreName = string(store.StoreNameTorBox)
+		return nil, error
+	}
+	wdl := &res.Data
+	data := &store.GetWebDLData{
+		Id:      params.Id,
+		Hash:    wdl.Hash,
+		Name:    wdl.Name,
+		Size:    wdl.Size,
+		Status:  wdl.getWebDLStatus(),
+		Files:   wdl.toWebDLFiles(),
+		AddedAt: wdl.GetAddedAt(),
+	}
+	return data, nil
+}
+
+func (c *StoreClient) ListWebDLs(params *store.ListWebDLsParams) (*store.ListWebDLsData, error) {
+	res, err := c.client.ListWebDLDownload(&ListWebDLDownloadParams{
+		Ctx:         params.Ctx,
+		BypassCache: true,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := &store.ListWebDLsData{
+		Items:      []store.ListWebDLsDataItem{},
+		TotalItems: 0,
+	}
+	for i := range res.Data {
+		wdl := &res.Data[i]
+		data.Items = append(data.Items, store.ListWebDLsDataItem{
+			Id:      strconv.Itoa(wdl.Id),
+			Hash:    wdl.Hash,
+			Name:    wdl.Name,
+			Size:    wdl.Size,
+			Status:  wdl.getWebDLStatus(),
+			Files:   wdl.toWebDLFiles(),
+			AddedAt: wdl.GetAddedAt(),
+		})
+	}
+	count := len(data.Items)
+	// torbox returns 1 extra item
+	if count > params.Limit {
+		data.Items = data.Items[0:params.Limit]
+		count = params.Limit
+	}
+	data.TotalItems = params.Offset + count
+	if count == params.Limit {
+		data.TotalItems += 1
+	}
+	return data, nil
+}
+
+func (c *StoreClient) RemoveWebDL(params *store.RemoveWebDLParams) (*store.RemoveWebDLData, error) {
+	id, err := parseWebDLId(params.Id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.client.ControlWebDLDownload(&ControlWebDLDownloadParams{
+		Ctx:       params.Ctx,
+		WebDLId:   id,
+		Operation: ControlWebDLDownloadOperationDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.RemoveWebDLData{Id: params.Id}, nil
+}
+
+func (c *StoreClient) GenerateWebDLLink(params *store.GenerateLinkParams) (*store.GenerateLinkData, error) {
+	webdlId, fileId, err := LockedFileLink(params.Link).Parse()
+	if err != nil {
+		error := core.NewAPIError("invalid link")
+		error.StatusCode = http.StatusBadRequest
+		error.Cause = err
+		return nil, error
+	}
+	res, err := c.client.RequestWebDLDownloadLink(&RequestWebDLDownloadLinkParams{
+		Ctx:     params.Ctx,
+		WebDLId: webdlId,
+		FileId:  fileId,
+		UserIP:  params.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &store.GenerateLinkData{Link: res.Data.Link}, nil
+}
@@ -216,6 +216,11 @@ func (t Torrent) GetAddedAt() time.Time {
 	return added_at.UTC()
 }
 
+// progress is reported as a fraction, converts it to 0 to 100
+func (t Torrent) GetProgress() float64 {
+	return float64(t.Progress) * 100
+}
+
 type ListTorrentsData []Torrent
 
 type ListTorrentsParams struct {
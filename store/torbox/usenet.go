@@ -1,6 +1,7 @@
 package torbox
 
 import (
+	"mime/multipart"
 	"net/url"
 	"strconv"
 	"time"
@@ -47,6 +48,7 @@ const (
 
 type CreateUsenetDownloadParams struct {
 	Ctx
+	File           *multipart.FileHeader
 	Link           string
 	Name           string
 	Password       string
@@ -55,19 +57,28 @@ type CreateUsenetDownloadParams struct {
 }
 
 func (c APIClient) CreateUsenetDownload(params *CreateUsenetDownloadParams) (APIResponse[CreateUsenetDownloadData], error) {
-	form := &url.Values{}
-	form.Add("link", params.Link)
+	values := url.Values{}
 	if params.Name != "" {
-		form.Add("name", params.Name)
+		values.Add("name", params.Name)
 	}
 	if params.Password != "" {
-		form.Add("password", params.Password)
+		values.Add("password", params.Password)
 	}
 	if params.PostProcessing != 0 {
-		form.Add("post_processing", strconv.Itoa(int(params.PostProcessing-1)))
+		values.Add("post_processing", strconv.Itoa(int(params.PostProcessing-1)))
+	}
+	values.Add("as_queued", strconv.FormatBool(params.AsQueued))
+	if params.File != nil {
+		form := &multipart.Form{}
+		form.File = map[string][]*multipart.FileHeader{
+			"file": {params.File},
+		}
+		form.Value = values
+		params.MultiPartForm = form
+	} else {
+		values.Add("link", params.Link)
+		params.Form = &values
 	}
-	form.Add("as_queued", strconv.FormatBool(params.AsQueued))
-	params.Form = form
 	response := &Response[CreateUsenetDownloadData]{}
 	res, err := c.Request("POST", "/v1/api/usenet/createusenetdownload", params, response)
 	return newAPIResponse(res, response.Data, response.Detail), err
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type NewzStatus = MagnetStatus
+
+type NewzFile struct {
+	Idx       int    `json:"index"`
+	Link      string `json:"link,omitempty"`
+	Path      string `json:"path"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	VideoHash string `json:"video_hash,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+type CheckNewzParams struct {
+	Ctx
+	Hashes   []string
+	ClientIP string
+}
+
+type CheckNewzDataItem struct {
+	Hash   string     `json:"hash"`
+	Name   string     `json:"name"`
+	Size   int64      `json:"size"`
+	Status NewzStatus `json:"status"`
+}
+
+type CheckNewzData struct {
+	Items []CheckNewzDataItem `json:"items"`
+}
+
+type AddNewzParams struct {
+	Ctx
+	Link     string                // nzb url
+	File     *multipart.FileHeader // nzb file, if `Link` is empty
+	Name     string
+	Password string
+	ClientIP string
+}
+
+type AddNewzData struct {
+	Id      string     `json:"id"`
+	Hash    string     `json:"hash"`
+	Name    string     `json:"name"`
+	Size    int64      `json:"size"`
+	Status  NewzStatus `json:"status"`
+	Files   []NewzFile `json:"files"`
+	AddedAt time.Time  `json:"added_at"`
+}
+
+type GetNewzParams struct {
+	Ctx
+	Id          string
+	ClientIP    string
+	BypassCache bool
+}
+
+type GetNewzData struct {
+	Id      string     `json:"id"`
+	Hash    string     `json:"hash"`
+	Name    string     `json:"name"`
+	Size    int64      `json:"size"`
+	Status  NewzStatus `json:"status"`
+	Files   []NewzFile `json:"files"`
+	AddedAt time.Time  `json:"added_at"`
+
+	Progress float64 `json:"progress,omitempty"` // 0 to 100
+	Speed    int64   `json:"speed,omitempty"`    // bytes per second
+	ETA      int64   `json:"eta,omitempty"`      // seconds
+}
+
+type ListNewzParams struct {
+	Ctx
+	Limit    int // min 1, max 500, default 100
+	Offset   int // default 0
+	ClientIP string
+}
+
+type ListNewzDataItem struct {
+	Id      string     `json:"id"`
+	Hash    string     `json:"hash"`
+	Name    string     `json:"name"`
+	Size    int64      `json:"size"`
+	Status  NewzStatus `json:"status"`
+	Files   []NewzFile `json:"files"`
+	AddedAt time.Time  `json:"added_at"`
+
+	Progress float64 `json:"progress,omitempty"` // 0 to 100
+	Speed    int64   `json:"speed,omitempty"`    // bytes per second
+	ETA      int64   `json:"eta,omitempty"`      // seconds
+}
+
+type ListNewzData struct {
+	Items      []ListNewzDataItem `json:"items"`
+	TotalItems int                `json:"total_items"`
+}
+
+type RemoveNewzParams struct {
+	Ctx
+	Id string
+}
+
+type RemoveNewzData struct {
+	Id string `json:"id"`
+}
+
+// Optional capability, implemented by stores that support usenet (NZB) downloads.
+type UsenetStore interface {
+	Store
+	CheckNewz(params *CheckNewzParams) (*CheckNewzData, error)
+	AddNewz(params *AddNewzParams) (*AddNewzData, error)
+	GetNewz(params *GetNewzParams) (*GetNewzData, error)
+	ListNewz(params *ListNewzParams) (*ListNewzData, error)
+	RemoveNewz(params *RemoveNewzParams) (*RemoveNewzData, error)
+	GenerateNewzLink(params *GenerateLinkParams) (*GenerateLinkData, error)
+}
+
+func AsUsenetStore(s Store) (UsenetStore, bool) {
+	if s == nil {
+		return nil, false
+	}
+	us, ok := s.(UsenetStore)
+	return us, ok
+}
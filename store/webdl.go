@@ -0,0 +1,96 @@
+package store
+
+import "time"
+
+type WebDLStatus = MagnetStatus
+
+type WebDLFile struct {
+	Idx       int    `json:"index"`
+	Link      string `json:"link,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	VideoHash string `json:"video_hash,omitempty"`
+}
+
+type UnrestrictLinkParams struct {
+	Ctx
+	Link     string // hoster link
+	Password string
+	ClientIP string
+}
+
+type UnrestrictLinkData struct {
+	Id      string      `json:"id"`
+	Hash    string      `json:"hash"`
+	Name    string      `json:"name"`
+	Size    int64       `json:"size"`
+	Status  WebDLStatus `json:"status"`
+	Files   []WebDLFile `json:"files"`
+	AddedAt time.Time   `json:"added_at"`
+}
+
+type GetWebDLParams struct {
+	Ctx
+	Id       string
+	ClientIP string
+}
+
+type GetWebDLData struct {
+	Id      string      `json:"id"`
+	Hash    string      `json:"hash"`
+	Name    string      `json:"name"`
+	Size    int64       `json:"size"`
+	Status  WebDLStatus `json:"status"`
+	Files   []WebDLFile `json:"files"`
+	AddedAt time.Time   `json:"added_at"`
+}
+
+type ListWebDLsParams struct {
+	Ctx
+	Limit    int // min 1, max 500, default 100
+	Offset   int // default 0
+	ClientIP string
+}
+
+type ListWebDLsDataItem struct {
+	Id      string      `json:"id"`
+	Hash    string      `json:"hash"`
+	Name    string      `json:"name"`
+	Size    int64       `json:"size"`
+	Status  WebDLStatus `json:"status"`
+	Files   []WebDLFile `json:"files"`
+	AddedAt time.Time   `json:"added_at"`
+}
+
+type ListWebDLsData struct {
+	Items      []ListWebDLsDataItem `json:"items"`
+	TotalItems int                  `json:"total_items"`
+}
+
+type RemoveWebDLParams struct {
+	Ctx
+	Id string
+}
+
+type RemoveWebDLData struct {
+	Id string `json:"id"`
+}
+
+// Optional capability, implemented by stores that can unrestrict hoster links.
+type WebDLStore interface {
+	Store
+	UnrestrictLink(params *UnrestrictLinkParams) (*UnrestrictLinkData, error)
+	GetWebDL(params *GetWebDLParams) (*GetWebDLData, error)
+	ListWebDLs(params *ListWebDLsParams) (*ListWebDLsData, error)
+	RemoveWebDL(params *RemoveWebDLParams) (*RemoveWebDLData, error)
+	GenerateWebDLLink(params *GenerateLinkParams) (*GenerateLinkData, error)
+}
+
+func AsWebDLStore(s Store) (WebDLStore, bool) {
+	if s == nil {
+		return nil, false
+	}
+	ws, ok := s.(WebDLStore)
+	return ws, ok
+}